@@ -2,7 +2,10 @@ package config
 
 import (
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 
 	_ "github.com/joho/godotenv/autoload"
 )
@@ -16,6 +19,9 @@ type IAppConfig interface {
 	GetSendgridAPIKey() string
 	GetCorsAllowedOrigin() string
 	GetCookieDomain() string
+	GetMatchmakingRoomSize() int
+	GetMatchmakingMaxWaitSeconds() int
+	GetTrustedProxies() []*net.IPNet
 }
 
 type AppConfig struct {
@@ -27,6 +33,11 @@ type AppConfig struct {
 	sendgridAPIKey     string
 	corsAllowedOrigin  string
 	cookieDomain       string
+
+	matchmakingRoomSize       int
+	matchmakingMaxWaitSeconds int
+
+	trustedProxies []*net.IPNet
 }
 
 func NewAppConfig() IAppConfig {
@@ -43,6 +54,8 @@ func NewAppConfig() IAppConfig {
 	appConfig.sendgridAPIKey = ""
 	appConfig.corsAllowedOrigin = "http://localhost:4200"
 	appConfig.cookieDomain = "localhost"
+	appConfig.matchmakingRoomSize = 4
+	appConfig.matchmakingMaxWaitSeconds = 30
 
 	if appConfig.cloudEnv == "" {
 		log.Fatal("[CLOUD_ENV] is required")
@@ -61,6 +74,29 @@ func NewAppConfig() IAppConfig {
 	if cookieDomain := os.Getenv("COOKIE_DOMAIN"); cookieDomain != "" {
 		appConfig.cookieDomain = cookieDomain
 	}
+	if roomSize := os.Getenv("MATCHMAKING_ROOM_SIZE"); roomSize != "" {
+		size, err := strconv.Atoi(roomSize)
+		if err != nil || size < 2 || size > 20 {
+			log.Fatal("[MATCHMAKING_ROOM_SIZE] must be a number between 2 and 20")
+		}
+		appConfig.matchmakingRoomSize = size
+	}
+	if maxWait := os.Getenv("MATCHMAKING_MAX_WAIT_SECONDS"); maxWait != "" {
+		seconds, err := strconv.Atoi(maxWait)
+		if err != nil || seconds < 1 {
+			log.Fatal("[MATCHMAKING_MAX_WAIT_SECONDS] must be a positive number")
+		}
+		appConfig.matchmakingMaxWaitSeconds = seconds
+	}
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		for _, proxy := range strings.Split(trustedProxies, ",") {
+			network, err := parseTrustedProxy(strings.TrimSpace(proxy))
+			if err != nil {
+				log.Fatal("[TRUSTED_PROXIES] must be a comma separated list of IP addresses or CIDR ranges")
+			}
+			appConfig.trustedProxies = append(appConfig.trustedProxies, network)
+		}
+	}
 
 	errorList := ""
 
@@ -119,3 +155,33 @@ func (a *AppConfig) GetCorsAllowedOrigin() string {
 func (a *AppConfig) GetCookieDomain() string {
 	return a.cookieDomain
 }
+
+func (a *AppConfig) GetMatchmakingRoomSize() int {
+	return a.matchmakingRoomSize
+}
+
+func (a *AppConfig) GetMatchmakingMaxWaitSeconds() int {
+	return a.matchmakingMaxWaitSeconds
+}
+
+func (a *AppConfig) GetTrustedProxies() []*net.IPNet {
+	return a.trustedProxies
+}
+
+// parseTrustedProxy parses a CIDR range, or a single IP address as a range holding just that address.
+func parseTrustedProxy(proxy string) (*net.IPNet, error) {
+	if strings.Contains(proxy, "/") {
+		_, network, err := net.ParseCIDR(proxy)
+		return network, err
+	}
+	ip := net.ParseIP(proxy)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: proxy}
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
@@ -0,0 +1,14 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Deck Membership - Questions in a deck are kept in an explicit order so a deck can be played as a unit.
+
+ALTER TABLE "trivia_deck_questions" ADD COLUMN IF NOT EXISTS "position" INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_trivia_deck_questions_deck_position ON "trivia_deck_questions" ("deck_id", "position");
@@ -0,0 +1,15 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Smart Decks - A deck with rules is resolved against trivia_questions.tags when it is played
+-- instead of using the questions in trivia_deck_questions. A NULL value means a static deck.
+--
+-- Example: {"include_tags": ["science"], "exclude_tags": ["kids"], "published_only": true, "min_age_days": null, "max_age_days": 365}
+
+ALTER TABLE "trivia_decks" ADD COLUMN IF NOT EXISTS "rules" JSONB;
@@ -0,0 +1,14 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Game Sessions - A question can only be answered once per game instance.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trivia_question_attempts_game_question ON "trivia_question_attempts" ("game_instance_id", "question_id");
+
+CREATE INDEX IF NOT EXISTS idx_trivia_game_instances_user ON "trivia_game_instances" ("user_id");
@@ -0,0 +1,22 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Served Game Questions - The choices shown for a question are stored the first time it is served
+-- so that fetching the question again returns the same choices and answers can be matched by choice ID.
+
+CREATE TABLE IF NOT EXISTS "trivia_game_questions" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "game_instance_id" INTEGER NOT NULL REFERENCES trivia_game_instances(id),
+    "question_id" INTEGER NOT NULL REFERENCES trivia_questions(id),
+    "choices" TEXT[] NOT NULL, -- Includes the correct answer, in the order they were shown
+    UNIQUE ("game_instance_id", "question_id")
+);
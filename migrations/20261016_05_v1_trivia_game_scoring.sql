@@ -0,0 +1,25 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Game Timer & Scoring - Each served question has a deadline set by the server. Answers that arrive after
+-- the deadline are recorded as timed out. Attempts store their score and the streak they were part of,
+-- and the game instance keeps a running total.
+
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "time_limit_seconds" INTEGER NOT NULL DEFAULT 20;
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "total_score" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "current_streak" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "best_streak" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "trivia_game_questions" ADD COLUMN IF NOT EXISTS "served_at" TIMESTAMP NOT NULL DEFAULT (NOW());
+ALTER TABLE "trivia_game_questions" ADD COLUMN IF NOT EXISTS "deadline_at" TIMESTAMP NOT NULL DEFAULT (NOW());
+
+ALTER TABLE "trivia_question_attempts" ADD COLUMN IF NOT EXISTS "is_timed_out" BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "trivia_question_attempts" ADD COLUMN IF NOT EXISTS "response_time_ms" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "trivia_question_attempts" ADD COLUMN IF NOT EXISTS "score" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "trivia_question_attempts" ADD COLUMN IF NOT EXISTS "streak" INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,31 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Game Rooms - A multiplayer room is joined with a short code. Every player in a room gets their own
+-- game instance linked to the room so answers and scores are recorded the same way as solo games.
+
+CREATE TABLE IF NOT EXISTS "trivia_game_rooms" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "code" VARCHAR(8) NOT NULL UNIQUE,
+    "deck_id" INTEGER NOT NULL REFERENCES trivia_decks(id),
+    "host_user_id" INTEGER NOT NULL REFERENCES users(id),
+    "status" VARCHAR(20) NOT NULL DEFAULT 'waiting', -- waiting, in_progress, finished, closed
+    "num_wrong_choices" INTEGER NOT NULL DEFAULT 3,
+    "time_limit_seconds" INTEGER NOT NULL DEFAULT 20,
+    "question_count" INTEGER NOT NULL DEFAULT 10,
+    "started_at" TIMESTAMP,
+    "ended_at" TIMESTAMP
+);
+
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "room_id" INTEGER REFERENCES trivia_game_rooms(id);
+
+CREATE INDEX IF NOT EXISTS idx_trivia_game_instances_room ON "trivia_game_instances" ("room_id");
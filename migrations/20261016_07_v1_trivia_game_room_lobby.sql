@@ -0,0 +1,14 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Room Lobby - Rooms are capped at a number of players and can start on their own once a lobby
+-- countdown runs out. A room without an auto start only starts when the host or a full ready-check starts it.
+
+ALTER TABLE "trivia_game_rooms" ADD COLUMN IF NOT EXISTS "max_players" INTEGER NOT NULL DEFAULT 8;
+ALTER TABLE "trivia_game_rooms" ADD COLUMN IF NOT EXISTS "auto_start_seconds" INTEGER;
@@ -0,0 +1,44 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Leaderboards - Each answer adds its score to the player's row on every board for the all time, monthly,
+-- weekly and daily periods it falls in, so ranking never has to scan the attempts. Periods start at UTC
+-- midnight, weeks start on Monday and the all time period always starts on 1970-01-01.
+
+CREATE TABLE IF NOT EXISTS "leaderboard_scores" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "board_key" VARCHAR(120) NOT NULL, -- global
+    "period_type" VARCHAR(20) NOT NULL, -- all_time, monthly, weekly, daily
+    "period_start" DATE NOT NULL,
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "total_score" BIGINT NOT NULL DEFAULT 0,
+    "total_correct" INTEGER NOT NULL DEFAULT 0,
+    "total_answered" INTEGER NOT NULL DEFAULT 0,
+    UNIQUE ("board_key", "period_type", "period_start", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_scores_ranking ON "leaderboard_scores" ("board_key", "period_type", "period_start", "total_score" DESC);
+
+-- Backfill the boards from the answers recorded before leaderboards existed
+-- Attempt times are already UTC wall-clock time, so they are bucketed as they are stored
+INSERT INTO "leaderboard_scores" ("board_key", "period_type", "period_start", "user_id", "total_score", "total_correct", "total_answered")
+SELECT 'global', p.period_type, p.period_start, g.user_id, SUM(a.score), SUM(CASE WHEN a.is_correct THEN 1 ELSE 0 END), COUNT(*)
+FROM "trivia_question_attempts" a
+JOIN "trivia_game_instances" g ON g.id = a.game_instance_id
+CROSS JOIN LATERAL (VALUES
+    ('all_time', DATE '1970-01-01'),
+    ('monthly', date_trunc('month', a.created_at)::date),
+    ('weekly', date_trunc('week', a.created_at)::date),
+    ('daily', a.created_at::date)
+) AS p(period_type, period_start)
+GROUP BY p.period_type, p.period_start, g.user_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,35 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Deck & Tag Leaderboards - Besides the global board, every answer now also counts towards the board of
+-- the deck being played (deck:<id>) and the board of each tag on the question (tag:<tag>). Tags are
+-- stored lower cased and trimmed. Board keys can hold any tag, so the column is no longer limited.
+
+ALTER TABLE "leaderboard_scores" ALTER COLUMN "board_key" TYPE TEXT;
+
+-- Backfill the deck and tag boards from the answers recorded before they existed
+-- Attempt times are already UTC wall-clock time, so they are bucketed as they are stored
+INSERT INTO "leaderboard_scores" ("board_key", "period_type", "period_start", "user_id", "total_score", "total_correct", "total_answered")
+SELECT b.board_key, p.period_type, p.period_start, g.user_id, SUM(a.score), SUM(CASE WHEN a.is_correct THEN 1 ELSE 0 END), COUNT(*)
+FROM "trivia_question_attempts" a
+JOIN "trivia_game_instances" g ON g.id = a.game_instance_id
+JOIN "trivia_questions" q ON q.id = a.question_id
+CROSS JOIN LATERAL (
+    SELECT 'deck:' || g.deck_id AS board_key
+    UNION
+    SELECT DISTINCT 'tag:' || lower(trim(tag)) FROM unnest(q.tags) AS tag WHERE trim(tag) <> ''
+) AS b
+CROSS JOIN LATERAL (VALUES
+    ('all_time', DATE '1970-01-01'),
+    ('monthly', date_trunc('month', a.created_at)::date),
+    ('weekly', date_trunc('week', a.created_at)::date),
+    ('daily', a.created_at::date)
+) AS p(period_type, period_start)
+GROUP BY b.board_key, p.period_type, p.period_start, g.user_id
+ON CONFLICT DO NOTHING;
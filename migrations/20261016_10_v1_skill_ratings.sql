@@ -0,0 +1,35 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Skill Ratings - Every answer is treated as an Elo match between the player and the question and both
+-- ratings are updated in the same transaction that records the answer. Rows are created the first time a
+-- player or question is rated, so anyone without a row is still at the starting rating of 1200. Answers
+-- recorded before ratings existed are not replayed.
+
+CREATE TABLE IF NOT EXISTS "user_ratings" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "user_id" INTEGER NOT NULL UNIQUE REFERENCES users(id),
+    "rating" DOUBLE PRECISION NOT NULL DEFAULT 1200,
+    "rated_attempts" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "question_ratings" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "question_id" INTEGER NOT NULL UNIQUE REFERENCES trivia_questions(id),
+    "rating" DOUBLE PRECISION NOT NULL DEFAULT 1200,
+    "rated_attempts" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_ratings_rating ON "question_ratings" ("rating");
@@ -0,0 +1,57 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Achievements - Each achievement is unlocked once a player's progress on its criteria reaches the threshold.
+-- Progress is checked every time one of the player's games ends and unlocks are kept in user_achievements.
+--
+-- Criteria types:
+--   games_played   - finished games
+--   total_correct  - correct answers, only counting questions with the tag when one is set
+--   perfect_game   - correct answers in a finished game without a single wrong or timed out answer
+--   answer_streak  - correct answers in a row within a game
+--   game_score     - score in a single game
+--   daily_streak   - UTC days in a row with at least one answer, ending today or yesterday
+
+CREATE TABLE IF NOT EXISTS "achievements" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "achievement_key" VARCHAR(60) NOT NULL UNIQUE,
+    "name" TEXT NOT NULL,
+    "description" TEXT NOT NULL,
+    "icon" TEXT,
+    "criteria_type" VARCHAR(30) NOT NULL,
+    "tag" TEXT,
+    "threshold" INTEGER NOT NULL,
+    "sort_order" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "user_achievements" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "achievement_id" INTEGER NOT NULL REFERENCES achievements(id),
+    "unlocked_at" TIMESTAMP NOT NULL DEFAULT (NOW()),
+    UNIQUE ("user_id", "achievement_id")
+);
+
+INSERT INTO "achievements" ("achievement_key", "name", "description", "criteria_type", "tag", "threshold", "sort_order") VALUES
+    ('first_game', 'First Steps', 'Finish your first game', 'games_played', NULL, 1, 10),
+    ('games_50', 'Regular', 'Finish 50 games', 'games_played', NULL, 50, 20),
+    ('correct_100', 'Century', 'Answer 100 questions correctly', 'total_correct', NULL, 100, 30),
+    ('correct_1000', 'Know It All', 'Answer 1,000 questions correctly', 'total_correct', NULL, 1000, 40),
+    ('science_100', 'Science Buff', 'Answer 100 science questions correctly', 'total_correct', 'science', 100, 50),
+    ('perfect_10', 'Flawless', 'Answer 10 questions in a single game without a wrong answer', 'perfect_game', NULL, 10, 60),
+    ('streak_10', 'On Fire', 'Answer 10 questions in a row correctly', 'answer_streak', NULL, 10, 70),
+    ('score_2000', 'High Scorer', 'Score 2,000 points in a single game', 'game_score', NULL, 2000, 80),
+    ('daily_7', 'Dedicated', 'Play on 7 days in a row', 'daily_streak', NULL, 7, 90)
+ON CONFLICT ("achievement_key") DO NOTHING;
@@ -0,0 +1,27 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Daily Challenges - One shared game per UTC day. The day's questions are kept in a system deck that is
+-- never approved, so it can only be played through the challenge. Every player gets a single game instance
+-- linked to the challenge, which is what stops them from playing it twice.
+
+CREATE TABLE IF NOT EXISTS "trivia_daily_challenges" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "challenge_date" DATE NOT NULL UNIQUE,
+    "deck_id" INTEGER NOT NULL REFERENCES trivia_decks(id)
+);
+
+ALTER TABLE "trivia_game_instances" ADD COLUMN IF NOT EXISTS "daily_challenge_id" INTEGER REFERENCES trivia_daily_challenges(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trivia_game_instances_daily_challenge
+    ON "trivia_game_instances" ("daily_challenge_id", "user_id")
+    WHERE "daily_challenge_id" IS NOT NULL;
@@ -0,0 +1,26 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Question Revisions - Every edit to a question first copies the version being replaced into this table,
+-- along with who made the edit and when. Revision numbers count up from 1 for each question.
+
+CREATE TABLE IF NOT EXISTS "trivia_question_revisions" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "question_id" INTEGER NOT NULL REFERENCES trivia_questions(id),
+    "revision_number" INTEGER NOT NULL,
+    "question" TEXT NOT NULL,
+    "correct_answer" TEXT NOT NULL,
+    "tags" TEXT[] NOT NULL,
+    "is_published" BOOLEAN NOT NULL,
+    "edited_by_user_id" INTEGER REFERENCES users(id),
+    UNIQUE ("question_id", "revision_number")
+);
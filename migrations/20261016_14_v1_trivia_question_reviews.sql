@@ -0,0 +1,47 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Question Reviews - Questions move through an editorial review before they can be published:
+--
+--   draft -> submitted -> in_review -> approved -> published
+--                             |
+--                             +-> rejected -> submitted
+--
+-- A question is in review once a support or admin reviewer is assigned to it, and only that reviewer can
+-- approve or reject it. is_published is kept in step with the published status. Every status change and
+-- reviewer comment is kept in trivia_question_reviews.
+--
+-- Questions written before reviews existed were all written by admins, so they start out approved, or
+-- published when they already were.
+
+ALTER TABLE "trivia_questions" ADD COLUMN IF NOT EXISTS "review_status" VARCHAR(20);
+ALTER TABLE "trivia_questions" ADD COLUMN IF NOT EXISTS "reviewer_user_id" INTEGER REFERENCES users(id);
+ALTER TABLE "trivia_questions" ADD COLUMN IF NOT EXISTS "submitted_at" TIMESTAMP;
+
+UPDATE "trivia_questions" SET "review_status" = CASE WHEN "is_published" THEN 'published' ELSE 'approved' END
+WHERE "review_status" IS NULL;
+
+ALTER TABLE "trivia_questions" ALTER COLUMN "review_status" SET DEFAULT 'draft';
+ALTER TABLE "trivia_questions" ALTER COLUMN "review_status" SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_trivia_questions_review_queue ON "trivia_questions" ("review_status", "submitted_at");
+
+CREATE TABLE IF NOT EXISTS "trivia_question_reviews" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "question_id" INTEGER NOT NULL REFERENCES trivia_questions(id),
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "from_status" VARCHAR(20) NOT NULL,
+    "to_status" VARCHAR(20) NOT NULL, -- The same as from_status for a comment
+    "comment" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_trivia_question_reviews_question ON "trivia_question_reviews" ("question_id");
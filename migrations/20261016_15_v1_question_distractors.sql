@@ -0,0 +1,16 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Question Distractors - A wrong answer can be written for one question instead of being shared through
+-- the tag-matched pool. Questions imported from datasets such as Open Trivia DB come with their own
+-- incorrect answers, which only make sense as choices for that question.
+
+ALTER TABLE "wrong_answer_pool" ADD COLUMN IF NOT EXISTS "question_id" INTEGER REFERENCES trivia_questions(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wrong_answer_pool_question_answer ON "wrong_answer_pool" ("question_id", "answer_text") WHERE "question_id" IS NOT NULL;
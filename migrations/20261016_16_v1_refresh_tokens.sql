@@ -0,0 +1,29 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+-- Refresh Tokens - A refresh token is exchanged for a new access token and a new refresh token, so each one is
+-- only ever used once. Every token issued from the same login shares a family_id. When a token that has
+-- already been used is presented again it has been copied, so the whole family is revoked and the user has
+-- to log in again. Only a SHA-256 hash of each token is stored.
+
+CREATE TABLE IF NOT EXISTS "user_refresh_tokens" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "family_id" VARCHAR(64) NOT NULL,
+    "token_hash" VARCHAR(64) UNIQUE NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL,
+    "used_at" TIMESTAMP, -- Set once the token has been exchanged for the next one in its family
+    "revoked_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_refresh_tokens_family ON "user_refresh_tokens" ("family_id");
+CREATE INDEX IF NOT EXISTS idx_user_refresh_tokens_user ON "user_refresh_tokens" ("user_id");
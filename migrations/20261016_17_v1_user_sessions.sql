@@ -0,0 +1,35 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+
+-- User Sessions - Every login starts a session, which records the device it came from and when it was last
+-- used. Access tokens carry the session_key, and a session's refresh tokens use it as their family_id, so
+-- logging a session out stops both its access token and its refresh token from working.
+
+CREATE TABLE IF NOT EXISTS "user_sessions" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "session_key" VARCHAR(64) UNIQUE NOT NULL,
+    "device" VARCHAR(64),
+    "ip_address" VARCHAR(64),
+    "user_agent" TEXT,
+    "last_seen_at" TIMESTAMP DEFAULT (NOW()),
+    "revoked_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user ON "user_sessions" ("user_id");
+
+-- Access tokens issued before this time are rejected
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "password_changed_at" TIMESTAMP;
+
+-- Refresh tokens issued before sessions existed do not belong to one, so those logins have to start again
+UPDATE "user_refresh_tokens" SET "revoked_at" = NOW(), "modified_at" = NOW() WHERE "revoked_at" IS NULL;
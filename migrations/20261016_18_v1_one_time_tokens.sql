@@ -0,0 +1,26 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+
+-- Used One-Time Tokens - Verification and login-with-email tokens can only be used once. The jti of each one is
+-- recorded here as it is used, and a token whose jti is already here is rejected. Rows can be deleted once the
+-- token has expired, as the token would be rejected anyway.
+
+CREATE TABLE IF NOT EXISTS "used_one_time_tokens" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "created_at" TIMESTAMP DEFAULT (NOW()),
+    "modified_at" TIMESTAMP,
+    "is_archived" BOOLEAN DEFAULT false,
+    "token_id" VARCHAR(64) UNIQUE NOT NULL,
+    "user_id" INTEGER NOT NULL REFERENCES users(id),
+    "purpose" VARCHAR(32) NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_one_time_tokens_expires ON "used_one_time_tokens" ("expires_at");
@@ -0,0 +1,23 @@
+-- ############################
+-- OpenTriviaOnline Official Schema
+--
+-- https://opentriviaonline.com
+-- https://snowlynxsoftware.net 
+--
+-- Copyright 2025. SnowLynxSoftware. All Rights Reserved.
+-- ############################
+
+
+-- User Login History - Every login attempt is recorded, whether it succeeded or failed. The method is how the user
+-- logged in (password, email_link or refresh) and the outcome is success or failure, with the reason for a failure.
+-- user_id is empty for a password login with an email address that is not registered, and the email address that
+-- was tried is kept instead.
+
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "email" VARCHAR(255);
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "method" VARCHAR(20);
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "outcome" VARCHAR(20);
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "failure_reason" VARCHAR(32);
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "ip_address" VARCHAR(64);
+ALTER TABLE "user_login_history" ADD COLUMN IF NOT EXISTS "user_agent" TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_user_login_history_user ON "user_login_history" ("user_id", "created_at" DESC);
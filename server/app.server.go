@@ -58,25 +58,57 @@ func (s *AppServer) Start() {
 	waitlistRepository := repositories.NewWaitlistRepository(s.dB)
 	userRepository := repositories.NewUserRepository(s.dB)
 	triviaRepository := repositories.NewTriviaRepository(s.dB)
+	gameRepository := repositories.NewGameRepository(s.dB)
+	roomRepository := repositories.NewRoomRepository(s.dB)
+	leaderboardRepository := repositories.NewLeaderboardRepository(s.dB)
+	statsRepository := repositories.NewStatsRepository(s.dB)
+	achievementRepository := repositories.NewAchievementRepository(s.dB)
+	dailyChallengeRepository := repositories.NewDailyChallengeRepository(s.dB)
+	tokenRepository := repositories.NewTokenRepository(s.dB)
 
 	// Configure Services
 	emailService := services.NewEmailService(s.appConfig.GetSendgridAPIKey(), services.NewEmailTemplates())
 	cryptoService := services.NewCryptoService(s.appConfig.GetAuthHashPepper())
 	tokenService := services.NewTokenService(s.appConfig.GetJWTSecretKey())
-	authService := services.NewAuthService(userRepository, tokenService, cryptoService, emailService)
-	triviaService := services.NewTriviaService(triviaRepository)
+	authService := services.NewAuthService(userRepository, tokenRepository, tokenService, cryptoService, emailService)
+	triviaService := services.NewTriviaService(triviaRepository, userRepository)
+	choiceService := services.NewChoiceService(triviaRepository)
+	gameService := services.NewGameService(gameRepository, triviaRepository, choiceService)
+	roomService := services.NewRoomService(roomRepository, gameRepository, triviaRepository, choiceService, gameService)
+	matchmakingService := services.NewMatchmakingService(triviaRepository, gameRepository, roomService, s.appConfig.GetMatchmakingRoomSize(), s.appConfig.GetMatchmakingMaxWaitSeconds())
+	leaderboardService := services.NewLeaderboardService(leaderboardRepository, triviaRepository)
 	waitlistService := services.NewWaitlistService(waitlistRepository)
 	userService := services.NewUserService(userRepository)
+	statsService := services.NewStatsService(statsRepository, userRepository)
+	achievementService := services.NewAchievementService(achievementRepository)
+	dailyChallengeService := services.NewDailyChallengeService(dailyChallengeRepository)
+
+	// Configure Game Hooks
+	gameService.OnGameEnded(achievementService.HandleGameEnded)
+
+	// Close rooms left open by the last run
+	if err := roomService.CloseStaleRooms(); err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+
+	// Start Schedulers
+	dailyChallengeService.StartScheduler()
 
 	// Configure Middleware
-	authMiddleware := middleware.NewAuthMiddleware(userRepository, tokenService)
+	authMiddleware := middleware.NewAuthMiddleware(userRepository, tokenRepository, tokenService, s.appConfig.GetTrustedProxies())
 
 	// Configure Controllers
 	s.router.Mount("/health", controllers.NewHealthController().MapController())
 	s.router.Mount("/auth", controllers.NewAuthController(authMiddleware, authService, isProductionMode, s.appConfig.GetCookieDomain()).MapController())
 	s.router.Mount("/trivia", controllers.NewTriviaController(triviaService, authMiddleware).MapController())
+	s.router.Mount("/games", controllers.NewGameController(gameService, authMiddleware).MapController())
+	s.router.Mount("/matchmaking", controllers.NewMatchmakingController(matchmakingService, authMiddleware).MapController())
+	s.router.Mount("/rooms", controllers.NewRoomController(roomService, authMiddleware, s.appConfig.GetCorsAllowedOrigin()).MapController())
+	s.router.Mount("/leaderboards", controllers.NewLeaderboardController(leaderboardService, authMiddleware).MapController())
+	s.router.Mount("/daily-challenge", controllers.NewDailyChallengeController(dailyChallengeService, authMiddleware).MapController())
+	s.router.Mount("/achievements", controllers.NewAchievementController(achievementService, authMiddleware).MapController())
 	s.router.Mount("/waitlist", controllers.NewWaitlistController(waitlistService).MapController())
-	s.router.Mount("/users", controllers.NewUserController(userService, authMiddleware).MapController())
+	s.router.Mount("/users", controllers.NewUserController(userService, statsService, authMiddleware).MapController())
 
 	util.LogInfo("Starting server on localhost:3000")
 	log.Fatal(http.ListenAndServe("0.0.0.0:3000", s.router))
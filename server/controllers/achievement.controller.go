@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+type AchievementController struct {
+	achievementService services.IAchievementService
+	authMiddleware     middleware.IAuthMiddleware
+}
+
+func NewAchievementController(achievementService services.IAchievementService, authMiddleware middleware.IAuthMiddleware) *AchievementController {
+	return &AchievementController{
+		achievementService: achievementService,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (c *AchievementController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/", c.getAchievements)
+	return r
+}
+
+func (c *AchievementController) getAchievements(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	achievements, err := c.achievementService.GetPlayerAchievements(int64(userContext.Id))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve achievements", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(achievements)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
@@ -2,9 +2,12 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/snowlynxsoftware/oto-api/server/middleware"
@@ -13,6 +16,13 @@ import (
 	"github.com/snowlynxsoftware/oto-api/server/util"
 )
 
+const (
+	accessTokenCookieName  = "access_token"
+	accessTokenCookieAge   = 59 * time.Minute
+	refreshTokenCookieName = "refresh_token"
+	refreshTokenCookiePath = "/auth" // The refresh token is only ever sent to the auth endpoints
+)
+
 type AuthController struct {
 	authMiddleware    middleware.IAuthMiddleware
 	authService       services.IAuthService
@@ -37,10 +47,16 @@ func (c *AuthController) MapController() *chi.Mux {
 	router.Get("/verify", c.verify)
 	router.Post("/send-login-email", c.sendLoginEmail)
 	router.Get("/login-with-email", c.loginWithEmail)
+	router.Post("/refresh", c.refresh)
+	router.Post("/logout", c.logout)
+	router.Post("/password-reset/request", c.requestPasswordReset)
+	router.Post("/password-reset/confirm", c.confirmPasswordReset)
 
 	// Protected Routes
 	router.Get("/token", c.tokenInfo)
 	router.Post("/update-password/self", c.updateSelfPassword)
+	router.Get("/sessions", c.getSessions)
+	router.Post("/logout-everywhere", c.logoutEverywhere)
 	return router
 }
 
@@ -52,13 +68,13 @@ func (c *AuthController) login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := c.authService.Login(&authHeader)
+	response, err := c.authService.Login(&authHeader, c.authMiddleware.GetSessionClient(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	returnStr, err := json.Marshal(response)
+	returnStr, err := json.Marshal(loginResponseBody(response, wantsRefreshTokenInBody(r)))
 	if err != nil {
 		http.Error(w, "failed to create response", http.StatusInternalServerError)
 		return
@@ -66,7 +82,7 @@ func (c *AuthController) login(w http.ResponseWriter, r *http.Request) {
 
 	// log.Info().Str("Access Token: ", response.AccessToken).Msg("")
 
-	c.setCookie(w, "access_token", response.AccessToken)
+	c.setLoginCookies(w, response)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -131,23 +147,16 @@ func (c *AuthController) register(w http.ResponseWriter, r *http.Request) {
 
 func (c *AuthController) loginWithEmail(w http.ResponseWriter, r *http.Request) {
 
-	verificationToken := r.URL.Query().Get("token")
-
-	userId, err := c.authService.VerifyNewUser(&verificationToken)
-	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
+	loginToken := r.URL.Query().Get("token")
 
-	response, err := c.authService.LoginWithEmailLink(userId)
+	response, err := c.authService.LoginWithEmailLink(&loginToken, c.authMiddleware.GetSessionClient(r))
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	c.setCookie(w, "access_token", response.AccessToken)
+	c.setLoginCookies(w, response)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -212,21 +221,248 @@ func (c *AuthController) updateSelfPassword(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Changing the password logs every session out, including this one
+	c.clearLoginCookies(w)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("password updated successfully"))
 
 }
 
-func (c *AuthController) setCookie(w http.ResponseWriter, name string, value string) {
+// requestPasswordReset emails a password reset link. The response is the same whether or not the email
+// address is registered.
+func (c *AuthController) requestPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	var resetRequestDTO models.UserPasswordResetRequestDTO
+	err := json.NewDecoder(r.Body).Decode(&resetRequestDTO)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if resetRequestDTO.Email == "" {
+		http.Error(w, "Email is required", http.StatusBadRequest)
+		return
+	}
+
+	err = c.authService.RequestPasswordReset(strings.ToLower(resetRequestDTO.Email))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "an error occurred when attempting to send the password reset email", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("if an account exists for that email, a password reset link has been sent to it"))
+}
+
+func (c *AuthController) confirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	var resetConfirmDTO models.UserPasswordResetConfirmDTO
+	err := json.NewDecoder(r.Body).Decode(&resetConfirmDTO)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if resetConfirmDTO.Token == "" || resetConfirmDTO.Password == "" {
+		http.Error(w, "Token and Password are required", http.StatusBadRequest)
+		return
+	}
+
+	err = c.authService.ConfirmPasswordReset(&resetConfirmDTO.Token, resetConfirmDTO.Password)
+	if err != nil {
+		writeServiceError(w, err, "an error occurred when attempting to reset the password", passwordResetErrorStatuses)
+		return
+	}
+
+	// Every session was logged out, including any on this device
+	c.clearLoginCookies(w)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("password was reset successfully. you can now login"))
+}
+
+// passwordResetErrorStatuses lists the password reset errors that mean the reset link cannot be used.
+var passwordResetErrorStatuses = []errorStatus{
+	{services.ErrInvalidResetToken, http.StatusUnauthorized},
+	{services.ErrTokenAlreadyUsed, http.StatusUnauthorized},
+}
+
+// refresh exchanges the refresh token cookie, or a refresh_token in the body for clients that do not keep
+// cookies, for a new access token and refresh token.
+func (c *AuthController) refresh(w http.ResponseWriter, r *http.Request) {
+
+	var refreshDTO models.UserRefreshDTO
+	err := json.NewDecoder(r.Body).Decode(&refreshDTO)
+	if err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	refreshToken := refreshTokenFromRequest(r, refreshDTO)
+	if refreshToken == "" {
+		http.Error(w, "refresh token is required", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := c.authService.RefreshLogin(refreshToken, c.authMiddleware.GetSessionClient(r))
+	if err != nil {
+		c.setCookie(w, refreshTokenCookieName, "", refreshTokenCookiePath, -1)
+		writeServiceError(w, err, "an error occurred when attempting to refresh the login", refreshErrorStatuses)
+		return
+	}
+
+	// A client that sent the refresh token in the body keeps it itself, so it needs the new one back
+	returnStr, err := json.Marshal(loginResponseBody(response, refreshDTO.RefreshToken != "" || wantsRefreshTokenInBody(r)))
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	c.setLoginCookies(w, response)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// refreshErrorStatuses lists the refresh errors that mean the caller's refresh token cannot be used.
+var refreshErrorStatuses = []errorStatus{
+	{services.ErrInvalidRefreshToken, http.StatusUnauthorized},
+	{services.ErrRefreshTokenReused, http.StatusUnauthorized},
+}
+
+// logout logs out the session making the request and clears its cookies. When the access token has already
+// expired, the session is found from the refresh token instead. The cookies are cleared either way.
+func (c *AuthController) logout(w http.ResponseWriter, r *http.Request) {
+
+	var refreshDTO models.UserRefreshDTO
+	if err := json.NewDecoder(r.Body).Decode(&refreshDTO); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.clearLoginCookies(w)
+
+	var err error
+	if userContext, authErr := c.authMiddleware.Authorize(r, nil); authErr == nil {
+		err = c.authService.Logout(userContext.SessionID)
+	} else if refreshToken := refreshTokenFromRequest(r, refreshDTO); refreshToken != "" {
+		err = c.authService.LogoutWithRefreshToken(refreshToken)
+	}
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "an error occurred when attempting to log out", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("successfully logged out"))
+}
+
+// logoutEverywhere logs out every one of the user's sessions, including this one.
+func (c *AuthController) logoutEverywhere(w http.ResponseWriter, r *http.Request) {
+
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	err = c.authService.LogoutEverywhere(userContext.Id)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "an error occurred when attempting to log out", http.StatusInternalServerError)
+		return
+	}
+
+	c.clearLoginCookies(w)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("successfully logged out of every session"))
+}
+
+func (c *AuthController) getSessions(w http.ResponseWriter, r *http.Request) {
+
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := c.authService.GetSessions(userContext.Id, userContext.SessionID)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "an error occurred when attempting to get your sessions", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(sessions)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// refreshTokenFromRequest returns the refresh token from the body, or from the refresh token cookie when
+// the body has none. It returns an empty string when there is neither.
+func refreshTokenFromRequest(r *http.Request, refreshDTO models.UserRefreshDTO) string {
+	if refreshDTO.RefreshToken != "" {
+		return refreshDTO.RefreshToken
+	}
+	cookie, err := r.Cookie(refreshTokenCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// wantsRefreshTokenInBody reports whether a client that does not keep cookies asked for the refresh
+// token in the response body with include_refresh_token=true.
+func wantsRefreshTokenInBody(r *http.Request) bool {
+	return r.URL.Query().Get("include_refresh_token") == "true"
+}
+
+// loginResponseBody is the body of a login or refresh response. The refresh token is left out unless
+// the client needs it, since browsers get it in an HttpOnly cookie that scripts cannot read.
+func loginResponseBody(response *models.UserLoginResponseDTO, includeRefreshToken bool) *models.UserLoginResponseDTO {
+	body := *response
+	if !includeRefreshToken {
+		body.RefreshToken = ""
+	}
+	return &body
+}
+
+// setLoginCookies sets the access token cookie and the refresh token cookie after a login or a refresh.
+func (c *AuthController) setLoginCookies(w http.ResponseWriter, response *models.UserLoginResponseDTO) {
+	c.setCookie(w, accessTokenCookieName, response.AccessToken, "/", int(accessTokenCookieAge.Seconds()))
+	c.setCookie(w, refreshTokenCookieName, response.RefreshToken, refreshTokenCookiePath, int(services.RefreshTokenLifetime.Seconds()))
+}
+
+// clearLoginCookies deletes the access token cookie and the refresh token cookie.
+func (c *AuthController) clearLoginCookies(w http.ResponseWriter) {
+	c.setCookie(w, accessTokenCookieName, "", "/", -1)
+	c.setCookie(w, refreshTokenCookieName, "", refreshTokenCookiePath, -1)
+}
+
+// setCookie sets an HttpOnly cookie. A negative maxAge deletes the cookie.
+func (c *AuthController) setCookie(w http.ResponseWriter, name string, value string, path string, maxAge int) {
 	http.SetCookie(w, &http.Cookie{
 		Domain:   c.cookieDomain,
 		Name:     name,
 		Value:    value,
-		Path:     "/",
+		Path:     path,
 		HttpOnly: true,
 		Secure:   c.shouldEnableHTTPS, // Set to true if using HTTPS
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   59 * 60,
+		MaxAge:   maxAge,
 	})
 }
@@ -1,7 +1,53 @@
 package controllers
 
-import "github.com/go-chi/chi/v5"
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
 
 type IController interface {
 	MapController() *chi.Mux
 }
+
+// getPaginationParams reads the page_size and page query parameters, falling back
+// to a page size of 25 and the first page when they are missing or invalid.
+func getPaginationParams(r *http.Request) (pageSize int, page int) {
+	pageSize = 25
+	page = 1
+
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if psInt, err := strconv.Atoi(ps); err == nil && psInt > 0 {
+			pageSize = psInt
+		}
+	}
+	if p := r.URL.Query().Get("page"); p != "" {
+		if pInt, err := strconv.Atoi(p); err == nil && pInt > 0 {
+			page = pInt
+		}
+	}
+	return pageSize, page
+}
+
+// errorStatus pairs a service error the caller caused with the HTTP status it is answered with.
+type errorStatus struct {
+	err    error
+	status int
+}
+
+// writeServiceError logs a service error and answers it with the status of the first entry in
+// statuses that it matches. Any other error is not the caller's fault, so its details are kept
+// out of the response and message is sent with a 500 instead.
+func writeServiceError(w http.ResponseWriter, err error, message string, statuses []errorStatus) {
+	util.LogErrorWithStackTrace(err)
+	for _, s := range statuses {
+		if errors.Is(err, s.err) {
+			http.Error(w, err.Error(), s.status)
+			return
+		}
+	}
+	http.Error(w, message, http.StatusInternalServerError)
+}
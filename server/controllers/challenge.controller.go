@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+type DailyChallengeController struct {
+	dailyChallengeService services.IDailyChallengeService
+	authMiddleware        middleware.IAuthMiddleware
+}
+
+func NewDailyChallengeController(dailyChallengeService services.IDailyChallengeService, authMiddleware middleware.IAuthMiddleware) *DailyChallengeController {
+	return &DailyChallengeController{
+		dailyChallengeService: dailyChallengeService,
+		authMiddleware:        authMiddleware,
+	}
+}
+
+func (c *DailyChallengeController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+
+	// Daily challenge endpoints
+	r.Get("/", c.getTodaysChallenge)
+	r.Post("/start", c.startTodaysChallenge)
+	r.Get("/leaderboard", c.getLeaderboard)
+
+	return r
+}
+
+func (c *DailyChallengeController) getTodaysChallenge(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := c.dailyChallengeService.GetTodaysChallenge(int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve daily challenge", dailyChallengeErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(status)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *DailyChallengeController) startTodaysChallenge(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	game, err := c.dailyChallengeService.StartTodaysChallenge(int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to start daily challenge", dailyChallengeErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(game)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+// getLeaderboard returns the board for today's challenge, or for an earlier day's when date is set.
+func (c *DailyChallengeController) getLeaderboard(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	date := time.Now()
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		date, err = time.Parse(time.DateOnly, dateStr)
+		if err != nil {
+			http.Error(w, "date must be in YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	leaderboard, err := c.dailyChallengeService.GetLeaderboard(date, pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve daily challenge leaderboard", dailyChallengeErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(leaderboard)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// dailyChallengeErrorStatuses lists the daily challenge service errors a caller can cause.
+var dailyChallengeErrorStatuses = []errorStatus{
+	{services.ErrDailyChallengeNotFound, http.StatusNotFound},
+	{services.ErrDailyChallengeNoQuestions, http.StatusNotFound},
+	{services.ErrDailyChallengePlayed, http.StatusConflict},
+}
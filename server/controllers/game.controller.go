@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+type GameController struct {
+	gameService    services.IGameService
+	authMiddleware middleware.IAuthMiddleware
+}
+
+func NewGameController(gameService services.IGameService, authMiddleware middleware.IAuthMiddleware) *GameController {
+	return &GameController{
+		gameService:    gameService,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (c *GameController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+
+	// Solo game endpoints
+	r.Post("/", c.startSoloGame)
+	r.Get("/{id}", c.getGame)
+	r.Get("/{id}/next", c.getNextQuestion)
+	r.Post("/{id}/answers", c.submitAnswer)
+	r.Post("/{id}/end", c.endGame)
+	r.Get("/{id}/summary", c.getGameSummary)
+
+	return r
+}
+
+func (c *GameController) startSoloGame(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var startDTO models.GameStartDTO
+	err = json.NewDecoder(r.Body).Decode(&startDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := c.gameService.StartSoloGame(int64(userContext.Id), &startDTO)
+	if err != nil {
+		writeServiceError(w, err, "failed to start game", gameErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(game)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+func (c *GameController) getGame(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := c.gameService.GetGame(int64(userContext.Id), id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve game", gameErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(game)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *GameController) getNextQuestion(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	question, err := c.gameService.GetNextQuestion(int64(userContext.Id), id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve the next question", gameErrorStatuses)
+		return
+	}
+
+	// Every question has been answered
+	if question == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	returnStr, err := json.Marshal(question)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *GameController) submitAnswer(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var answerDTO models.GameAnswerDTO
+	err = json.NewDecoder(r.Body).Decode(&answerDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.gameService.SubmitAnswer(int64(userContext.Id), id, &answerDTO)
+	if err != nil {
+		writeServiceError(w, err, "failed to submit answer", gameErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+func (c *GameController) endGame(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := c.gameService.EndGame(int64(userContext.Id), id)
+	if err != nil {
+		writeServiceError(w, err, "failed to end game", gameErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(game)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *GameController) getGameSummary(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := c.gameService.GetGameSummary(int64(userContext.Id), id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve game summary", gameErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(summary)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// gameErrorStatuses lists the game service errors a caller can cause.
+var gameErrorStatuses = []errorStatus{
+	{services.ErrGameNotFound, http.StatusNotFound},
+	{services.ErrDeckNotFound, http.StatusNotFound},
+	{services.ErrGameEnded, http.StatusConflict},
+	{services.ErrGameInProgress, http.StatusConflict},
+	{services.ErrRoomGame, http.StatusConflict},
+	{services.ErrQuestionAlreadyAnswered, http.StatusConflict},
+	{services.ErrDeckNotPlayable, http.StatusBadRequest},
+	{services.ErrDeckHasNoQuestions, http.StatusBadRequest},
+	{services.ErrInvalidGameSettings, http.StatusBadRequest},
+	{services.ErrInvalidAnswer, http.StatusBadRequest},
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+const defaultLeaderboardNeighbors = 2
+
+type LeaderboardController struct {
+	leaderboardService services.ILeaderboardService
+	authMiddleware     middleware.IAuthMiddleware
+}
+
+func NewLeaderboardController(leaderboardService services.ILeaderboardService, authMiddleware middleware.IAuthMiddleware) *LeaderboardController {
+	return &LeaderboardController{
+		leaderboardService: leaderboardService,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (c *LeaderboardController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+
+	// Global leaderboard endpoints
+	r.Get("/global", c.getGlobalLeaderboard)
+	r.Get("/global/me", c.getGlobalStanding)
+
+	// Deck and tag leaderboard endpoints
+	r.Get("/decks/{id}", c.getDeckLeaderboard)
+	r.Get("/decks/{id}/me", c.getDeckStanding)
+	r.Get("/tags/{tag}", c.getTagLeaderboard)
+	r.Get("/tags/{tag}/me", c.getTagStanding)
+
+	return r
+}
+
+func (c *LeaderboardController) getGlobalLeaderboard(w http.ResponseWriter, r *http.Request) {
+	c.writeLeaderboard(w, r, models.LeaderboardGlobal)
+}
+
+func (c *LeaderboardController) getGlobalStanding(w http.ResponseWriter, r *http.Request) {
+	c.writeStanding(w, r, models.LeaderboardGlobal)
+}
+
+func (c *LeaderboardController) getDeckLeaderboard(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+	c.writeLeaderboard(w, r, models.DeckLeaderboardKey(id))
+}
+
+func (c *LeaderboardController) getDeckStanding(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+	c.writeStanding(w, r, models.DeckLeaderboardKey(id))
+}
+
+func (c *LeaderboardController) getTagLeaderboard(w http.ResponseWriter, r *http.Request) {
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err != nil {
+		http.Error(w, "invalid tag", http.StatusBadRequest)
+		return
+	}
+	c.writeLeaderboard(w, r, models.LeaderboardTagPrefix+tag)
+}
+
+func (c *LeaderboardController) getTagStanding(w http.ResponseWriter, r *http.Request) {
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err != nil {
+		http.Error(w, "invalid tag", http.StatusBadRequest)
+		return
+	}
+	c.writeStanding(w, r, models.LeaderboardTagPrefix+tag)
+}
+
+// writeLeaderboard and writeStanding serve every board the same way once its key is known.
+func (c *LeaderboardController) writeLeaderboard(w http.ResponseWriter, r *http.Request, board string) {
+	_, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	leaderboard, err := c.leaderboardService.GetLeaderboard(board, getLeaderboardPeriod(r), pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve leaderboard", leaderboardErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(leaderboard)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *LeaderboardController) writeStanding(w http.ResponseWriter, r *http.Request, board string) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	neighbors := defaultLeaderboardNeighbors
+	if neighborsStr := r.URL.Query().Get("neighbors"); neighborsStr != "" {
+		neighbors, err = strconv.Atoi(neighborsStr)
+		if err != nil {
+			http.Error(w, "invalid neighbors", http.StatusBadRequest)
+			return
+		}
+	}
+
+	standing, err := c.leaderboardService.GetPlayerStanding(board, getLeaderboardPeriod(r), int64(userContext.Id), neighbors)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve leaderboard standing", leaderboardErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(standing)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// getLeaderboardPeriod reads the period query parameter, falling back to all time.
+func getLeaderboardPeriod(r *http.Request) string {
+	if period := r.URL.Query().Get("period"); period != "" {
+		return period
+	}
+	return models.LeaderboardPeriodAllTime
+}
+
+// leaderboardErrorStatuses lists the leaderboard service errors a caller can cause.
+var leaderboardErrorStatuses = []errorStatus{
+	{services.ErrLeaderboardNotFound, http.StatusNotFound},
+	{services.ErrInvalidLeaderboardQuery, http.StatusBadRequest},
+}
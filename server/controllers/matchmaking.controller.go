@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+const maxMatchmakingWaitSeconds = 30
+
+type MatchmakingController struct {
+	matchmakingService services.IMatchmakingService
+	authMiddleware     middleware.IAuthMiddleware
+}
+
+func NewMatchmakingController(matchmakingService services.IMatchmakingService, authMiddleware middleware.IAuthMiddleware) *MatchmakingController {
+	return &MatchmakingController{
+		matchmakingService: matchmakingService,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (c *MatchmakingController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+
+	// Quick play queue endpoints
+	r.Post("/queue", c.joinQueue)
+	r.Get("/queue", c.getQueueStatus)
+	r.Delete("/queue", c.leaveQueue)
+
+	return r
+}
+
+func (c *MatchmakingController) joinQueue(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var queueDTO models.MatchmakingQueueDTO
+	err = json.NewDecoder(r.Body).Decode(&queueDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	status, err := c.matchmakingService.JoinQueue(int64(userContext.Id), userContext.Username, &queueDTO)
+	if err != nil {
+		writeServiceError(w, err, "failed to join matchmaking queue", matchmakingErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(status)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+// getQueueStatus returns the player's place in the queue or their match. Passing wait_seconds
+// holds the request open until a match is found or the wait runs out.
+func (c *MatchmakingController) getQueueStatus(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	waitSeconds := 0
+	if waitStr := r.URL.Query().Get("wait_seconds"); waitStr != "" {
+		waitSeconds, err = strconv.Atoi(waitStr)
+		if err != nil || waitSeconds < 0 || waitSeconds > maxMatchmakingWaitSeconds {
+			http.Error(w, "wait_seconds must be between 0 and 30", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var status *models.MatchmakingStatus
+	if waitSeconds > 0 {
+		status, err = c.matchmakingService.WaitForMatch(int64(userContext.Id), time.Duration(waitSeconds)*time.Second)
+	} else {
+		status, err = c.matchmakingService.GetStatus(int64(userContext.Id))
+	}
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve matchmaking status", matchmakingErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(status)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *MatchmakingController) leaveQueue(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	err = c.matchmakingService.LeaveQueue(int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to leave matchmaking queue", matchmakingErrorStatuses)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// matchmakingErrorStatuses lists the matchmaking service errors a caller can cause.
+var matchmakingErrorStatuses = []errorStatus{
+	{services.ErrNotInQueue, http.StatusNotFound},
+	{services.ErrDeckNotFound, http.StatusNotFound},
+	{services.ErrAlreadyInQueue, http.StatusConflict},
+	{services.ErrMatchFound, http.StatusConflict},
+	{services.ErrInvalidQueueJoin, http.StatusBadRequest},
+	{services.ErrDeckNotPlayable, http.StatusBadRequest},
+	{services.ErrDeckHasNoQuestions, http.StatusBadRequest},
+}
@@ -0,0 +1,448 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/snowlynxsoftware/oto-api/server/middleware"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/services"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+const (
+	wsWriteTimeout   = 10 * time.Second
+	wsPongTimeout    = 60 * time.Second
+	wsPingInterval   = 50 * time.Second // Must be shorter than the pong timeout
+	wsMaxMessageSize = 1024
+	wsSendQueueSize  = 64
+)
+
+var errSendQueueFull = errors.New("send queue is full")
+
+type RoomController struct {
+	roomService    services.IRoomService
+	authMiddleware middleware.IAuthMiddleware
+	upgrader       websocket.Upgrader
+}
+
+func NewRoomController(roomService services.IRoomService, authMiddleware middleware.IAuthMiddleware, allowedOrigin string) *RoomController {
+	return &RoomController{
+		roomService:    roomService,
+		authMiddleware: authMiddleware,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// The socket is authenticated with the access_token cookie, so only accept
+			// browsers coming from the same origin the CORS policy allows.
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origin == allowedOrigin
+			},
+		},
+	}
+}
+
+func (c *RoomController) MapController() *chi.Mux {
+	r := chi.NewRouter()
+
+	// Multiplayer room endpoints
+	r.Post("/", c.createRoom)
+	r.Get("/{code}", c.getRoom)
+	r.Post("/{code}/join", c.joinRoom)
+	r.Post("/{code}/leave", c.leaveRoom)
+	r.Post("/{code}/start", c.startRoom)
+	r.Post("/{code}/ready", c.setReady)
+	r.Post("/{code}/kick", c.kickPlayer)
+	r.Get("/{code}/ws", c.connectRoom)
+
+	return r
+}
+
+func (c *RoomController) createRoom(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var createDTO models.RoomCreateDTO
+	err = json.NewDecoder(r.Body).Decode(&createDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := c.roomService.CreateRoom(int64(userContext.Id), userContext.Username, &createDTO)
+	if err != nil {
+		writeServiceError(w, err, "failed to create room", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+func (c *RoomController) getRoom(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := c.roomService.GetRoom(roomCodeParam(r))
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve room", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *RoomController) joinRoom(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := c.roomService.JoinRoom(roomCodeParam(r), int64(userContext.Id), userContext.Username)
+	if err != nil {
+		writeServiceError(w, err, "failed to join room", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *RoomController) leaveRoom(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	err = c.roomService.LeaveRoom(roomCodeParam(r), int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to leave room", roomErrorStatuses)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *RoomController) startRoom(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := c.roomService.StartRoom(roomCodeParam(r), int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to start room", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *RoomController) setReady(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var readyDTO models.RoomReadyDTO
+	err = json.NewDecoder(r.Body).Decode(&readyDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := c.roomService.SetReady(roomCodeParam(r), int64(userContext.Id), readyDTO.IsReady)
+	if err != nil {
+		writeServiceError(w, err, "failed to update ready status", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *RoomController) kickPlayer(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var kickDTO models.RoomKickDTO
+	err = json.NewDecoder(r.Body).Decode(&kickDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := c.roomService.KickPlayer(roomCodeParam(r), int64(userContext.Id), &kickDTO)
+	if err != nil {
+		writeServiceError(w, err, "failed to remove player", roomErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(room)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// connectRoom upgrades the request to a WebSocket that streams the room's events. The player
+// has to be authorized and in the room before the upgrade happens.
+func (c *RoomController) connectRoom(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	code := roomCodeParam(r)
+	userId := int64(userContext.Id)
+	room, err := c.roomService.GetRoom(code)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve room", roomErrorStatuses)
+		return
+	}
+	isParticipant := false
+	for _, participant := range room.Participants {
+		if participant.UserID == userId {
+			isParticipant = true
+			break
+		}
+	}
+	if !isParticipant {
+		writeServiceError(w, services.ErrNotInRoom, "failed to connect to room", roomErrorStatuses)
+		return
+	}
+
+	ws, err := c.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		util.LogErrorWithStackTrace(err)
+		return
+	}
+
+	conn := newWSRoomConnection(ws)
+	err = c.roomService.Connect(code, userId, conn)
+	if err != nil {
+		conn.Send(&models.RoomEvent{Type: models.RoomEventError, Data: err.Error()})
+		conn.Close()
+		return
+	}
+	defer c.roomService.Disconnect(code, userId, conn)
+
+	ws.SetReadLimit(wsMaxMessageSize)
+	ws.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var message models.RoomClientMessage
+		err := ws.ReadJSON(&message)
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				util.LogErrorWithStackTrace(err)
+			}
+			conn.Close()
+			return
+		}
+
+		switch message.Type {
+		case models.RoomMessageAnswer:
+			err = c.roomService.SubmitAnswer(code, userId, &message)
+		case models.RoomMessageReady:
+			_, err = c.roomService.SetReady(code, userId, message.IsReady)
+		default:
+			err = errors.New("unknown message type")
+		}
+		if err != nil {
+			conn.Send(&models.RoomEvent{Type: models.RoomEventError, Data: err.Error()})
+		}
+	}
+}
+
+func roomCodeParam(r *http.Request) string {
+	return strings.ToUpper(chi.URLParam(r, "code"))
+}
+
+// roomErrorStatuses lists the room service errors a caller can cause.
+var roomErrorStatuses = []errorStatus{
+	{services.ErrRoomNotFound, http.StatusNotFound},
+	{services.ErrDeckNotFound, http.StatusNotFound},
+	{services.ErrNotInRoom, http.StatusForbidden},
+	{services.ErrNotRoomHost, http.StatusForbidden},
+	{services.ErrBannedFromRoom, http.StatusForbidden},
+	{services.ErrRoomNotWaiting, http.StatusConflict},
+	{services.ErrRoomFull, http.StatusConflict},
+	{services.ErrTooManyRooms, http.StatusConflict},
+	{services.ErrRoomStarted, http.StatusConflict},
+	{services.ErrInvalidGameSettings, http.StatusBadRequest},
+	{services.ErrInvalidKick, http.StatusBadRequest},
+	{services.ErrDeckNotPlayable, http.StatusBadRequest},
+	{services.ErrDeckHasNoQuestions, http.StatusBadRequest},
+}
+
+// wsRoomConnection adapts a WebSocket to services.RoomConnection. Events are queued and
+// written by a single writer goroutine, so a slow client never holds up the room, and a
+// client that falls a full queue behind is dropped.
+type wsRoomConnection struct {
+	ws     *websocket.Conn
+	queue  chan *models.RoomEvent
+	mu     sync.Mutex
+	done   chan struct{}
+	closed bool
+}
+
+func newWSRoomConnection(ws *websocket.Conn) *wsRoomConnection {
+	conn := &wsRoomConnection{
+		ws:    ws,
+		queue: make(chan *models.RoomEvent, wsSendQueueSize),
+		done:  make(chan struct{}),
+	}
+	go conn.writeLoop()
+	return conn
+}
+
+func (c *wsRoomConnection) Send(event *models.RoomEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return websocket.ErrCloseSent
+	}
+	select {
+	case c.queue <- event:
+		return nil
+	default:
+		// Closing the socket fails any write in progress and ends the read loop, which
+		// disconnects the player from the room
+		c.closed = true
+		close(c.done)
+		c.ws.Close()
+		return errSendQueueFull
+	}
+}
+
+// Close flushes the events already queued, then closes the connection.
+func (c *wsRoomConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	return nil
+}
+
+// writeLoop is the only writer to the WebSocket. It sends queued events and pings the client
+// until the connection is closed so dead clients are noticed by the read deadline.
+func (c *wsRoomConnection) writeLoop() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	defer c.ws.Close()
+
+	for {
+		select {
+		case event := <-c.queue:
+			err := c.write(event)
+			if err != nil {
+				c.Close()
+				return
+			}
+		case <-ticker.C:
+			err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			if err != nil {
+				c.Close()
+				return
+			}
+		case <-c.done:
+			for {
+				select {
+				case event := <-c.queue:
+					err := c.write(event)
+					if err != nil {
+						return
+					}
+				default:
+					c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteTimeout))
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *wsRoomConnection) write(event *models.RoomEvent) error {
+	c.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.ws.WriteJSON(event)
+}
@@ -2,8 +2,12 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/snowlynxsoftware/oto-api/server/middleware"
@@ -30,6 +34,13 @@ func (c *TriviaController) MapController() *chi.Mux {
 	// Existing endpoints
 	r.Post("/import-questions", c.importTriviaQuestions)
 	r.Post("/import-wrong-answers", c.importWrongAnswers)
+	r.Post("/import-questions/csv", c.importTriviaQuestionsCSV)
+	r.Post("/import-questions/opentdb", c.importOpenTDBQuestions)
+	r.Post("/import-wrong-answers/csv", c.importWrongAnswersCSV)
+
+	// Export endpoints
+	r.Get("/questions/export", c.exportQuestions)
+	r.Get("/wrong-answers/export", c.exportWrongAnswers)
 
 	// New CRUD endpoints for questions
 	r.Get("/questions", c.getQuestions)
@@ -39,6 +50,20 @@ func (c *TriviaController) MapController() *chi.Mux {
 	r.Patch("/questions/{id}/archived", c.toggleQuestionArchived)
 	r.Patch("/questions/{id}/published", c.toggleQuestionPublished)
 
+	// Question review endpoints
+	r.Get("/questions/review-queue", c.getReviewQueue)
+	r.Get("/questions/{id}/review/history", c.getQuestionReviews)
+	r.Post("/questions/{id}/review/submit", c.submitQuestionForReview)
+	r.Post("/questions/{id}/review/assign", c.assignQuestionReviewer)
+	r.Post("/questions/{id}/review/approve", c.approveQuestion)
+	r.Post("/questions/{id}/review/reject", c.rejectQuestion)
+	r.Post("/questions/{id}/review/comments", c.commentOnQuestion)
+
+	// Question revision endpoints
+	r.Get("/questions/{id}/revisions", c.getQuestionRevisions)
+	r.Get("/questions/{id}/revisions/diff", c.diffQuestionRevisions)
+	r.Post("/questions/{id}/revisions/{revisionId}/rollback", c.rollbackQuestion)
+
 	// New CRUD endpoints for wrong answers
 	r.Get("/wrong-answers", c.getWrongAnswers)
 	r.Get("/wrong-answers/{id}", c.getWrongAnswerById)
@@ -46,6 +71,26 @@ func (c *TriviaController) MapController() *chi.Mux {
 	r.Put("/wrong-answers/{id}", c.updateWrongAnswer)
 	r.Patch("/wrong-answers/{id}/archived", c.toggleWrongAnswerArchived)
 
+	// Deck management endpoints
+	r.Get("/decks", c.getDecks)
+	r.Get("/decks/{id}", c.getDeckById)
+	r.Post("/decks", c.createDeck)
+	r.Put("/decks/{id}", c.updateDeckMetadata)
+	r.Patch("/decks/{id}/approved", c.updateDeckApprovalStatus)
+	r.Patch("/decks/{id}/archived", c.updateDeckArchivalStatus)
+
+	// Deck membership endpoints
+	r.Get("/decks/{id}/questions", c.getDeckQuestions)
+	r.Post("/decks/{id}/questions", c.addQuestionsToDeck)
+	r.Delete("/decks/{id}/questions", c.removeQuestionsFromDeck)
+	r.Put("/decks/{id}/questions/order", c.reorderDeckQuestions)
+	r.Get("/decks/{id}/question-counts", c.getDeckQuestionCounts)
+
+	// Smart deck endpoints
+	r.Put("/decks/{id}/rules", c.updateDeckRules)
+	r.Get("/decks/{id}/rules/preview", c.previewDeckRules)
+	r.Post("/decks/rules/preview", c.previewUnsavedDeckRules)
+
 	return r
 }
 
@@ -130,6 +175,8 @@ func (c *TriviaController) getQuestions(w http.ResponseWriter, r *http.Request)
 	searchString := ""
 	statusFilter := ""
 	tagFilter := ""
+	difficultyFilter := ""
+	sortBy := ""
 
 	if ps := r.URL.Query().Get("page_size"); ps != "" {
 		if psInt, err := strconv.Atoi(ps); err == nil && psInt > 0 {
@@ -150,10 +197,16 @@ func (c *TriviaController) getQuestions(w http.ResponseWriter, r *http.Request)
 	if tags := r.URL.Query().Get("tags"); tags != "" {
 		tagFilter = tags
 	}
+	if difficulty := r.URL.Query().Get("difficulty"); difficulty != "" {
+		difficultyFilter = difficulty
+	}
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		sortBy = sort
+	}
 
 	offset := (page - 1) * pageSize
 
-	results, err := c.triviaService.GetQuestions(pageSize, offset, searchString, statusFilter, tagFilter)
+	results, err := c.triviaService.GetQuestions(pageSize, offset, searchString, statusFilter, tagFilter, difficultyFilter, sortBy)
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "failed to retrieve questions", http.StatusInternalServerError)
@@ -224,8 +277,7 @@ func (c *TriviaController) createQuestion(w http.ResponseWriter, r *http.Request
 
 	question, err := c.triviaService.CreateQuestion(&createDTO)
 	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to create question", http.StatusInternalServerError)
+		writeServiceError(w, err, "failed to create question", questionErrorStatuses)
 		return
 	}
 
@@ -241,7 +293,7 @@ func (c *TriviaController) createQuestion(w http.ResponseWriter, r *http.Request
 }
 
 func (c *TriviaController) updateQuestion(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
@@ -262,10 +314,131 @@ func (c *TriviaController) updateQuestion(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	question, err := c.triviaService.UpdateQuestion(&updateDTO, id)
+	question, err := c.triviaService.UpdateQuestion(&updateDTO, id, int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to update question", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(question)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// Question revision endpoints
+func (c *TriviaController) getQuestionRevisions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := c.triviaService.GetQuestionRevisions(id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve question revisions", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(revisions)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// diffQuestionRevisions compares the revision in from with the revision in to, or with the
+// question as it is now when to is left out.
+func (c *TriviaController) diffQuestionRevisions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	fromRevisionId, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil || fromRevisionId <= 0 {
+		http.Error(w, "from must be a revision ID", http.StatusBadRequest)
+		return
+	}
+
+	var toRevisionId *int64
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err := strconv.ParseInt(toStr, 10, 64)
+		if err != nil || to <= 0 {
+			http.Error(w, "to must be a revision ID", http.StatusBadRequest)
+			return
+		}
+		toRevisionId = &to
+	}
+
+	diff, err := c.triviaService.DiffQuestionRevisions(id, fromRevisionId, toRevisionId)
+	if err != nil {
+		writeServiceError(w, err, "failed to compare question revisions", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(diff)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) rollbackQuestion(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to update question", http.StatusInternalServerError)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	revisionIdStr := chi.URLParam(r, "revisionId")
+	revisionId, err := strconv.ParseInt(revisionIdStr, 10, 64)
+	if err != nil || revisionId <= 0 {
+		http.Error(w, "invalid revision ID", http.StatusBadRequest)
+		return
+	}
+
+	question, err := c.triviaService.RollbackQuestion(id, revisionId, int64(userContext.Id))
+	if err != nil {
+		writeServiceError(w, err, "failed to roll back question", questionErrorStatuses)
 		return
 	}
 
@@ -307,7 +480,7 @@ func (c *TriviaController) toggleQuestionArchived(w http.ResponseWriter, r *http
 }
 
 func (c *TriviaController) toggleQuestionPublished(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
@@ -321,10 +494,9 @@ func (c *TriviaController) toggleQuestionPublished(w http.ResponseWriter, r *htt
 		return
 	}
 
-	err = c.triviaService.ToggleQuestionPublished(id)
+	err = c.triviaService.ToggleQuestionPublished(id, int64(userContext.Id))
 	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to toggle question published status", http.StatusInternalServerError)
+		writeServiceError(w, err, "failed to toggle question published status", questionErrorStatuses)
 		return
 	}
 
@@ -332,55 +504,104 @@ func (c *TriviaController) toggleQuestionPublished(w http.ResponseWriter, r *htt
 	w.Write([]byte("question published status toggled successfully"))
 }
 
-// Wrong Answer CRUD endpoints
-func (c *TriviaController) getWrongAnswers(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+// Question review endpoints
+// getReviewQueue lists the questions waiting on a review. Setting mine=true only lists the questions
+// assigned to the caller.
+func (c *TriviaController) getReviewQueue(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
 		return
 	}
 
-	// Get query parameters
-	pageSize := 25
-	page := 1
-	searchString := ""
-	statusFilter := ""
-	tagFilter := ""
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+	statusFilter := r.URL.Query().Get("status")
 
-	if ps := r.URL.Query().Get("page_size"); ps != "" {
-		if psInt, err := strconv.Atoi(ps); err == nil && psInt > 0 {
-			pageSize = psInt
-		}
+	var reviewerUserId *int64
+	if r.URL.Query().Get("mine") == "true" {
+		userId := int64(userContext.Id)
+		reviewerUserId = &userId
 	}
-	if p := r.URL.Query().Get("page"); p != "" {
-		if pInt, err := strconv.Atoi(p); err == nil && pInt > 0 {
-			page = pInt
-		}
+
+	queue, err := c.triviaService.GetReviewQueue(pageSize, offset, statusFilter, reviewerUserId)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve review queue", questionErrorStatuses)
+		return
 	}
-	if search := r.URL.Query().Get("search"); search != "" {
-		searchString = search
+
+	returnStr, err := json.Marshal(queue)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
 	}
-	if status := r.URL.Query().Get("status"); status != "" {
-		statusFilter = status
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) getQuestionReviews(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
 	}
-	if tags := r.URL.Query().Get("tags"); tags != "" {
-		tagFilter = tags
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
 	}
 
-	offset := (page - 1) * pageSize
+	reviews, err := c.triviaService.GetQuestionReviews(id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve question reviews", questionErrorStatuses)
+		return
+	}
 
-	results, err := c.triviaService.GetWrongAnswers(pageSize, offset, searchString, statusFilter, tagFilter)
+	returnStr, err := json.Marshal(reviews)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) submitQuestionForReview(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to retrieve wrong answers", http.StatusInternalServerError)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
 		return
 	}
 
-	// Fix page number
-	results.Page = page
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
 
-	returnStr, err := json.Marshal(results)
+	reviewDTO, err := decodeQuestionReviewDTO(r)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.triviaService.SubmitQuestionForReview(id, int64(userContext.Id), reviewDTO.Comment)
+	if err != nil {
+		writeServiceError(w, err, "failed to submit question for review", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(result)
 	if err != nil {
 		http.Error(w, "failed to create response", http.StatusInternalServerError)
 		return
@@ -391,8 +612,9 @@ func (c *TriviaController) getWrongAnswers(w http.ResponseWriter, r *http.Reques
 	w.Write(returnStr)
 }
 
-func (c *TriviaController) getWrongAnswerById(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+// assignQuestionReviewer assigns a reviewer to a question, or the caller when no reviewer is given.
+func (c *TriviaController) assignQuestionReviewer(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
@@ -402,18 +624,28 @@ func (c *TriviaController) getWrongAnswerById(w http.ResponseWriter, r *http.Req
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
 		return
 	}
 
-	wrongAnswer, err := c.triviaService.GetWrongAnswerById(id)
+	reviewDTO, err := decodeQuestionReviewDTO(r)
 	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to retrieve wrong answer", http.StatusInternalServerError)
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
 		return
 	}
 
-	returnStr, err := json.Marshal(wrongAnswer)
+	reviewerUserId := int64(userContext.Id)
+	if reviewDTO.ReviewerUserID != nil {
+		reviewerUserId = *reviewDTO.ReviewerUserID
+	}
+
+	result, err := c.triviaService.AssignQuestionReviewer(id, reviewerUserId, int64(userContext.Id), reviewDTO.Comment)
+	if err != nil {
+		writeServiceError(w, err, "failed to assign question reviewer", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(result)
 	if err != nil {
 		http.Error(w, "failed to create response", http.StatusInternalServerError)
 		return
@@ -424,41 +656,46 @@ func (c *TriviaController) getWrongAnswerById(w http.ResponseWriter, r *http.Req
 	w.Write(returnStr)
 }
 
-func (c *TriviaController) createWrongAnswer(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+func (c *TriviaController) approveQuestion(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
 		return
 	}
 
-	var createDTO models.WrongAnswerCreateDTO
-	err = json.NewDecoder(r.Body).Decode(&createDTO)
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	reviewDTO, err := decodeQuestionReviewDTO(r)
 	if err != nil {
 		http.Error(w, "failed to decode request body", http.StatusBadRequest)
 		return
 	}
 
-	wrongAnswer, err := c.triviaService.CreateWrongAnswer(&createDTO)
+	result, err := c.triviaService.ApproveQuestion(id, int64(userContext.Id), reviewDTO.Comment)
 	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to create wrong answer", http.StatusInternalServerError)
+		writeServiceError(w, err, "failed to approve question", questionErrorStatuses)
 		return
 	}
 
-	returnStr, err := json.Marshal(wrongAnswer)
+	returnStr, err := json.Marshal(result)
 	if err != nil {
 		http.Error(w, "failed to create response", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusOK)
 	w.Write(returnStr)
 }
 
-func (c *TriviaController) updateWrongAnswer(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+func (c *TriviaController) rejectQuestion(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
@@ -468,25 +705,23 @@ func (c *TriviaController) updateWrongAnswer(w http.ResponseWriter, r *http.Requ
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
 		return
 	}
 
-	var updateDTO models.WrongAnswerUpdateDTO
-	err = json.NewDecoder(r.Body).Decode(&updateDTO)
+	reviewDTO, err := decodeQuestionReviewDTO(r)
 	if err != nil {
 		http.Error(w, "failed to decode request body", http.StatusBadRequest)
 		return
 	}
 
-	wrongAnswer, err := c.triviaService.UpdateWrongAnswer(&updateDTO, id)
+	result, err := c.triviaService.RejectQuestion(id, int64(userContext.Id), reviewDTO.Comment)
 	if err != nil {
-		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to update wrong answer", http.StatusInternalServerError)
+		writeServiceError(w, err, "failed to reject question", questionErrorStatuses)
 		return
 	}
 
-	returnStr, err := json.Marshal(wrongAnswer)
+	returnStr, err := json.Marshal(result)
 	if err != nil {
 		http.Error(w, "failed to create response", http.StatusInternalServerError)
 		return
@@ -497,8 +732,8 @@ func (c *TriviaController) updateWrongAnswer(w http.ResponseWriter, r *http.Requ
 	w.Write(returnStr)
 }
 
-func (c *TriviaController) toggleWrongAnswerArchived(w http.ResponseWriter, r *http.Request) {
-	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+func (c *TriviaController) commentOnQuestion(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
@@ -508,17 +743,1003 @@ func (c *TriviaController) toggleWrongAnswerArchived(w http.ResponseWriter, r *h
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
 		return
 	}
 
-	err = c.triviaService.ToggleWrongAnswerArchived(id)
+	reviewDTO, err := decodeQuestionReviewDTO(r)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.triviaService.CommentOnQuestion(id, int64(userContext.Id), reviewDTO.Comment)
+	if err != nil {
+		writeServiceError(w, err, "failed to comment on question", questionErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+// importOpenTDBQuestions imports questions in the Open Trivia DB format, either as a whole API response
+// or as just its array of results.
+func (c *TriviaController) importOpenTDBQuestions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
-		http.Error(w, "failed to toggle wrong answer archived status", http.StatusInternalServerError)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("wrong answer archived status toggled successfully"))
+	var importRequest models.OpenTDBImportRequest
+	err = json.NewDecoder(r.Body).Decode(&importRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, err := c.triviaService.ImportOpenTDBQuestions(importRequest.Results)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+// importTriviaQuestionsCSV imports questions from a CSV file, sent either as the request body or as
+// the file field of a multipart form.
+func (c *TriviaController) importTriviaQuestionsCSV(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	data, options, err := readCSVImportRequest(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer data.Close()
+
+	results, err := c.triviaService.ImportTriviaQuestionsCSV(data, options)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) importWrongAnswersCSV(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	data, options, err := readCSVImportRequest(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer data.Close()
+
+	results, err := c.triviaService.ImportWrongAnswersCSV(data, options)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// Export endpoints
+// exportQuestions downloads every question matching the same filters as getQuestions. The format
+// can be csv, which is the default, or json.
+func (c *TriviaController) exportQuestions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	format := exportFormat(r)
+	data, err := c.triviaService.ExportQuestions(format, query.Get("search"), query.Get("status"), query.Get("tags"), query.Get("difficulty"))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeExport(w, "questions", format, data)
+}
+
+func (c *TriviaController) exportWrongAnswers(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	format := exportFormat(r)
+	data, err := c.triviaService.ExportWrongAnswers(format, query.Get("search"), query.Get("status"), query.Get("tags"))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeExport(w, "wrong-answers", format, data)
+}
+
+// Wrong Answer CRUD endpoints
+func (c *TriviaController) getWrongAnswers(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	pageSize := 25
+	page := 1
+	searchString := ""
+	statusFilter := ""
+	tagFilter := ""
+
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if psInt, err := strconv.Atoi(ps); err == nil && psInt > 0 {
+			pageSize = psInt
+		}
+	}
+	if p := r.URL.Query().Get("page"); p != "" {
+		if pInt, err := strconv.Atoi(p); err == nil && pInt > 0 {
+			page = pInt
+		}
+	}
+	if search := r.URL.Query().Get("search"); search != "" {
+		searchString = search
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		statusFilter = status
+	}
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		tagFilter = tags
+	}
+
+	offset := (page - 1) * pageSize
+
+	results, err := c.triviaService.GetWrongAnswers(pageSize, offset, searchString, statusFilter, tagFilter)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve wrong answers", http.StatusInternalServerError)
+		return
+	}
+
+	// Fix page number
+	results.Page = page
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) getWrongAnswerById(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		return
+	}
+
+	wrongAnswer, err := c.triviaService.GetWrongAnswerById(id)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve wrong answer", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(wrongAnswer)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) createWrongAnswer(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var createDTO models.WrongAnswerCreateDTO
+	err = json.NewDecoder(r.Body).Decode(&createDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	wrongAnswer, err := c.triviaService.CreateWrongAnswer(&createDTO)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to create wrong answer", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(wrongAnswer)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) updateWrongAnswer(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		return
+	}
+
+	var updateDTO models.WrongAnswerUpdateDTO
+	err = json.NewDecoder(r.Body).Decode(&updateDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	wrongAnswer, err := c.triviaService.UpdateWrongAnswer(&updateDTO, id)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to update wrong answer", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(wrongAnswer)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) toggleWrongAnswerArchived(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid wrong answer ID", http.StatusBadRequest)
+		return
+	}
+
+	err = c.triviaService.ToggleWrongAnswerArchived(id)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to toggle wrong answer archived status", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("wrong answer archived status toggled successfully"))
+}
+
+// Deck endpoints
+func (c *TriviaController) getDecks(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	pageSize, page := getPaginationParams(r)
+	searchString := ""
+	statusFilter := ""
+
+	if search := r.URL.Query().Get("search"); search != "" {
+		searchString = search
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		statusFilter = status
+	}
+
+	offset := (page - 1) * pageSize
+
+	results, err := c.triviaService.GetTriviaDecks(pageSize, offset, searchString, statusFilter)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve decks", http.StatusInternalServerError)
+		return
+	}
+
+	// Fix page number
+	results.Page = page
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) getDeckById(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.GetTriviaDeckById(id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve deck", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) createDeck(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var createDTO models.TriviaDeckCreateDTO
+	err = json.NewDecoder(r.Body).Decode(&createDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	if createDTO.Name == "" {
+		http.Error(w, "deck name is required", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.CreateNewTriviaDeck(createDTO.Name, createDTO.Description, createDTO.IsSystemDeck)
+	if err != nil {
+		writeServiceError(w, err, "failed to create deck", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) updateDeckMetadata(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var updateRequest models.TriviaDeckMetadataUpdateRequest
+	err = json.NewDecoder(r.Body).Decode(&updateRequest)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	// The deck ID in the body is optional, but it must match the route if it is set
+	if updateRequest.DeckID != 0 && updateRequest.DeckID != id {
+		http.Error(w, "deck ID in the request body does not match the route", http.StatusBadRequest)
+		return
+	}
+
+	if updateRequest.Name == "" {
+		http.Error(w, "deck name is required", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.UpdateTriviaDeckMetadata(id, updateRequest.Name, updateRequest.Description)
+	if err != nil {
+		writeServiceError(w, err, "failed to update deck", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) updateDeckApprovalStatus(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var approvalDTO models.TriviaDeckApprovalUpdateDTO
+	err = json.NewDecoder(r.Body).Decode(&approvalDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.UpdateTriviaDeckApprovalStatus(id, approvalDTO.IsApproved)
+	if err != nil {
+		writeServiceError(w, err, "failed to update deck approval status", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) updateDeckArchivalStatus(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var archivalDTO models.TriviaDeckArchivalUpdateDTO
+	err = json.NewDecoder(r.Body).Decode(&archivalDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.UpdateTriviaDeckArchivalStatus(id, archivalDTO.IsArchived)
+	if err != nil {
+		writeServiceError(w, err, "failed to update deck archival status", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// Deck membership endpoints
+func (c *TriviaController) getDeckQuestions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	pageSize, page := getPaginationParams(r)
+	statusFilter := ""
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		statusFilter = status
+	}
+
+	offset := (page - 1) * pageSize
+
+	results, err := c.triviaService.GetDeckQuestions(id, pageSize, offset, statusFilter)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve deck questions", deckErrorStatuses)
+		return
+	}
+
+	// Fix page number
+	results.Page = page
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) addQuestionsToDeck(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var questionsDTO models.TriviaDeckQuestionsDTO
+	err = json.NewDecoder(r.Body).Decode(&questionsDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	results, err := c.triviaService.AddQuestionsToDeck(id, questionsDTO.QuestionIDs)
+	if err != nil {
+		writeServiceError(w, err, "failed to add questions to deck", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) removeQuestionsFromDeck(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var questionsDTO models.TriviaDeckQuestionsDTO
+	err = json.NewDecoder(r.Body).Decode(&questionsDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	results, err := c.triviaService.RemoveQuestionsFromDeck(id, questionsDTO.QuestionIDs)
+	if err != nil {
+		writeServiceError(w, err, "failed to remove questions from deck", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) reorderDeckQuestions(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	var questionsDTO models.TriviaDeckQuestionsDTO
+	err = json.NewDecoder(r.Body).Decode(&questionsDTO)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	err = c.triviaService.ReorderDeckQuestions(id, questionsDTO.QuestionIDs)
+	if err != nil {
+		writeServiceError(w, err, "failed to reorder deck questions", deckErrorStatuses)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("deck questions reordered successfully"))
+}
+
+func (c *TriviaController) getDeckQuestionCounts(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	counts, err := c.triviaService.GetDeckQuestionCounts(id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve deck question counts", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(counts)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// Smart deck endpoints
+func (c *TriviaController) updateDeckRules(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	// A null body clears the rules and turns the deck back into a static deck
+	var rules *models.TriviaDeckRules
+	err = json.NewDecoder(r.Body).Decode(&rules)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.UpdateTriviaDeckRules(id, rules)
+	if err != nil {
+		writeServiceError(w, err, "failed to update deck rules", deckErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(deck)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) previewDeckRules(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid deck ID", http.StatusBadRequest)
+		return
+	}
+
+	deck, err := c.triviaService.GetTriviaDeckById(id)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve deck", deckErrorStatuses)
+		return
+	}
+
+	if deck.Rules == nil {
+		http.Error(w, "deck is not a smart deck", http.StatusBadRequest)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.triviaService.PreviewTriviaDeckRules(deck.Rules, pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to preview deck rules", deckErrorStatuses)
+		return
+	}
+
+	// Fix page number
+	results.Page = page
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *TriviaController) previewUnsavedDeckRules(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	var rules models.TriviaDeckRules
+	err = json.NewDecoder(r.Body).Decode(&rules)
+	if err != nil {
+		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.triviaService.PreviewTriviaDeckRules(&rules, pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to preview deck rules", deckErrorStatuses)
+		return
+	}
+
+	// Fix page number
+	results.Page = page
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// questionErrorStatuses lists the question service errors a caller can cause.
+var questionErrorStatuses = []errorStatus{
+	{services.ErrQuestionNotFound, http.StatusNotFound},
+	{services.ErrQuestionRevisionNotFound, http.StatusNotFound},
+	{services.ErrQuestionReviewerNotFound, http.StatusNotFound},
+	{services.ErrNotQuestionReviewer, http.StatusForbidden},
+	{services.ErrQuestionReviewStatus, http.StatusConflict},
+	{services.ErrInvalidQuestion, http.StatusBadRequest},
+	{services.ErrInvalidQuestionReviewer, http.StatusBadRequest},
+	{services.ErrInvalidQuestionReview, http.StatusBadRequest},
+}
+
+// deckErrorStatuses lists the deck service errors a caller can cause.
+var deckErrorStatuses = []errorStatus{
+	{services.ErrDeckNotFound, http.StatusNotFound},
+	{services.ErrInvalidDeck, http.StatusBadRequest},
+	{services.ErrInvalidDeckQuestions, http.StatusBadRequest},
+	{services.ErrInvalidDeckRules, http.StatusBadRequest},
+}
+
+// decodeQuestionReviewDTO reads the body of a review action. The body is optional, so an empty one
+// is treated as no comment.
+func decodeQuestionReviewDTO(r *http.Request) (*models.TriviaQuestionReviewDTO, error) {
+	reviewDTO := &models.TriviaQuestionReviewDTO{}
+	err := json.NewDecoder(r.Body).Decode(reviewDTO)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return reviewDTO, nil
+}
+
+// The largest CSV file accepted by the import endpoints.
+const maxCSVImportBytes = 10 << 20
+
+// readCSVImportRequest returns the CSV file of an import request along with the import options from
+// the query string: dry_run, tag_delimiter, and a <field>_column parameter for each column mapping.
+func readCSVImportRequest(w http.ResponseWriter, r *http.Request) (io.ReadCloser, *models.TriviaCSVImportOptions, error) {
+	query := r.URL.Query()
+	options := &models.TriviaCSVImportOptions{
+		DryRun:       query.Get("dry_run") == "true",
+		TagDelimiter: query.Get("tag_delimiter"),
+		Columns:      map[string]string{},
+	}
+	for key := range query {
+		if field, ok := strings.CutSuffix(key, "_column"); ok {
+			options.Columns[field] = query.Get(key)
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCSVImportBytes)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, nil, errors.New("the form must include the CSV file in a file field")
+		}
+		return file, options, nil
+	}
+	return r.Body, options, nil
+}
+
+func exportFormat(r *http.Request) string {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return models.TriviaExportFormatCSV
+	}
+	return format
+}
+
+// writeExport sends an export as a file download.
+func writeExport(w http.ResponseWriter, name string, format string, data []byte) {
+	contentType := "text/csv; charset=utf-8"
+	if format == models.TriviaExportFormatJSON {
+		contentType = "application/json"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
 }
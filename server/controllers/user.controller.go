@@ -14,12 +14,14 @@ import (
 
 type UserController struct {
 	userService    services.IUserService
+	statsService   services.IStatsService
 	authMiddleware middleware.IAuthMiddleware
 }
 
-func NewUserController(userService services.IUserService, authMiddleware middleware.IAuthMiddleware) *UserController {
+func NewUserController(userService services.IUserService, statsService services.IStatsService, authMiddleware middleware.IAuthMiddleware) *UserController {
 	return &UserController{
 		userService:    userService,
+		statsService:   statsService,
 		authMiddleware: authMiddleware,
 	}
 }
@@ -32,9 +34,209 @@ func (c *UserController) MapController() *chi.Mux {
 	r.Patch("/{id}/archived", c.toggleUserArchived)
 	r.Post("/{id}/ban", c.banUser)
 	r.Post("/{id}/unban", c.unbanUser)
+
+	// Player stats endpoints
+	r.Get("/me/stats", c.getMyStats)
+	r.Get("/me/games", c.getMyGames)
+	r.Get("/me/logins", c.getMyLoginHistory)
+	r.Get("/{id}/stats", c.getUserStats)
+	r.Get("/{id}/games", c.getUserGames)
+	r.Get("/{id}/logins", c.getUserLoginHistory)
 	return r
 }
 
+func (c *UserController) getMyStats(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := c.statsService.GetPlayerStats(int64(userContext.Id))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve stats", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(stats)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *UserController) getMyGames(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.statsService.GetPlayerGames(int64(userContext.Id), pageSize, offset)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "failed to retrieve games", http.StatusInternalServerError)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *UserController) getUserStats(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || userId <= 0 {
+		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := c.statsService.GetUserStats(int64(userId))
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve stats", statsErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(stats)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *UserController) getUserGames(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || userId <= 0 {
+		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.statsService.GetUserGames(int64(userId), pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve game history", statsErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *UserController) getMyLoginHistory(w http.ResponseWriter, r *http.Request) {
+	userContext, err := c.authMiddleware.Authorize(r, nil)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.userService.GetLoginHistory(int64(userContext.Id), pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve login history", loginHistoryErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+func (c *UserController) getUserLoginHistory(w http.ResponseWriter, r *http.Request) {
+	_, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		http.Error(w, "you are not authorized to perform this request", http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || userId <= 0 {
+		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	pageSize, page := getPaginationParams(r)
+	offset := (page - 1) * pageSize
+
+	results, err := c.userService.GetLoginHistory(int64(userId), pageSize, offset)
+	if err != nil {
+		writeServiceError(w, err, "failed to retrieve login history", loginHistoryErrorStatuses)
+		return
+	}
+
+	returnStr, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, "failed to create response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(returnStr)
+}
+
+// loginHistoryErrorStatuses lists the login history errors a caller can cause.
+var loginHistoryErrorStatuses = []errorStatus{
+	{services.ErrUserNotFound, http.StatusNotFound},
+}
+
+// statsErrorStatuses lists the stats service errors a caller can cause.
+var statsErrorStatuses = []errorStatus{
+	{services.ErrUserNotFound, http.StatusNotFound},
+}
+
 func (c *UserController) toggleUserArchived(w http.ResponseWriter, r *http.Request) {
 	_, err := c.authMiddleware.Authorize(r, []string{"admin", "support"})
 	if err != nil {
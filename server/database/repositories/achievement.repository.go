@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+type AchievementEntity struct {
+	ID             int64      `json:"id" db:"id"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt     *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived     bool       `json:"is_archived" db:"is_archived"`
+	AchievementKey string     `json:"achievement_key" db:"achievement_key"`
+	Name           string     `json:"name" db:"name"`
+	Description    string     `json:"description" db:"description"`
+	Icon           *string    `json:"icon" db:"icon"`
+	CriteriaType   string     `json:"criteria_type" db:"criteria_type"`
+	Tag            *string    `json:"tag" db:"tag"`
+	Threshold      int        `json:"threshold" db:"threshold"`
+	SortOrder      int        `json:"sort_order" db:"sort_order"`
+}
+
+type UserAchievementEntity struct {
+	ID            int64      `json:"id" db:"id"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt    *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived    bool       `json:"is_archived" db:"is_archived"`
+	UserID        int64      `json:"user_id" db:"user_id"`
+	AchievementID int64      `json:"achievement_id" db:"achievement_id"`
+	UnlockedAt    time.Time  `json:"unlocked_at" db:"unlocked_at"`
+}
+
+type IAchievementRepository interface {
+	GetAchievements() ([]*AchievementEntity, error)
+	GetUserAchievements(userId int64) ([]*UserAchievementEntity, error)
+	UnlockAchievement(userId int64, achievementId int64) (*UserAchievementEntity, error)
+
+	// Progress methods
+	GetAchievementTotals(userId int64) (*models.AchievementTotals, error)
+	GetTagCorrectCounts(userId int64, tags []string) ([]*models.TagCorrectCount, error)
+	GetRecentPlayDays(userId int64, limit int) ([]time.Time, error)
+}
+
+type AchievementRepository struct {
+	db *database.AppDataSource
+}
+
+func NewAchievementRepository(db *database.AppDataSource) IAchievementRepository {
+	return &AchievementRepository{
+		db: db,
+	}
+}
+
+func (r *AchievementRepository) GetAchievements() ([]*AchievementEntity, error) {
+	achievements := []*AchievementEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, achievement_key, name, description, icon, criteria_type, tag, threshold, sort_order
+	FROM achievements
+	WHERE is_archived = false
+	ORDER BY sort_order ASC, id ASC`
+	err := r.db.DB.Select(&achievements, sql)
+	if err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+func (r *AchievementRepository) GetUserAchievements(userId int64) ([]*UserAchievementEntity, error) {
+	unlocked := []*UserAchievementEntity{}
+	sql := `SELECT id, created_at, modified_at, is_archived, user_id, achievement_id, unlocked_at
+	FROM user_achievements
+	WHERE user_id = $1 AND is_archived = false`
+	err := r.db.DB.Select(&unlocked, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return unlocked, nil
+}
+
+// UnlockAchievement records an unlock. It returns sql.ErrNoRows if the player had already unlocked the achievement.
+func (r *AchievementRepository) UnlockAchievement(userId int64, achievementId int64) (*UserAchievementEntity, error) {
+	unlocked := &UserAchievementEntity{}
+	sql := `INSERT INTO user_achievements (user_id, achievement_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, achievement_id) DO NOTHING
+		RETURNING id, created_at, modified_at, is_archived, user_id, achievement_id, unlocked_at`
+	err := r.db.DB.Get(unlocked, sql, userId, achievementId)
+	if err != nil {
+		return nil, err
+	}
+	return unlocked, nil
+}
+
+// Progress methods
+func (r *AchievementRepository) GetAchievementTotals(userId int64) (*models.AchievementTotals, error) {
+	totals := &models.AchievementTotals{}
+	sql := `SELECT
+		COUNT(*) FILTER (WHERE ended_at IS NOT NULL AND total_correct + total_incorrect > 0) AS games_played,
+		COALESCE(SUM(total_correct), 0) AS total_correct,
+		COALESCE(MAX(total_correct) FILTER (WHERE ended_at IS NOT NULL AND total_incorrect = 0), 0) AS best_perfect_game,
+		COALESCE(MAX(best_streak), 0) AS best_answer_streak,
+		COALESCE(MAX(total_score), 0) AS best_game_score
+	FROM trivia_game_instances
+	WHERE user_id = $1 AND is_archived = false`
+	err := r.db.DB.Get(totals, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetTagCorrectCounts reads a player's correct answers per tag from their all time tag leaderboards.
+// Tags the player has never answered are left out.
+func (r *AchievementRepository) GetTagCorrectCounts(userId int64, tags []string) ([]*models.TagCorrectCount, error) {
+	counts := []*models.TagCorrectCount{}
+	boardKeys := make([]string, len(tags))
+	for i, tag := range tags {
+		boardKeys[i] = models.TagLeaderboardKey(tag)
+	}
+	allTime, err := models.LeaderboardPeriodStart(models.LeaderboardPeriodAllTime, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `SELECT substring(board_key from $5) AS tag, total_correct AS correct
+	FROM leaderboard_scores
+	WHERE user_id = $1 AND board_key = ANY($2) AND period_type = $3 AND period_start = $4`
+	err = r.db.DB.Select(&counts, sql, userId, pq.Array(boardKeys), models.LeaderboardPeriodAllTime,
+		allTime.Format(leaderboardDateFormat), len(models.LeaderboardTagPrefix)+1)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// GetRecentPlayDays returns the most recent UTC days the player answered a question on, newest first.
+func (r *AchievementRepository) GetRecentPlayDays(userId int64, limit int) ([]time.Time, error) {
+	days := []time.Time{}
+	sql := `SELECT period_start
+	FROM leaderboard_scores
+	WHERE user_id = $1 AND board_key = $2 AND period_type = $3
+	ORDER BY period_start DESC
+	LIMIT $4`
+	err := r.db.DB.Select(&days, sql, userId, models.LeaderboardGlobal, models.LeaderboardPeriodDaily, limit)
+	if err != nil {
+		return nil, err
+	}
+	return days, nil
+}
@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+type TriviaDailyChallengeEntity struct {
+	ID            int64      `json:"id" db:"id"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt    *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived    bool       `json:"is_archived" db:"is_archived"`
+	ChallengeDate time.Time  `json:"challenge_date" db:"challenge_date"`
+	DeckID        int64      `json:"deck_id" db:"deck_id"`
+	QuestionCount int        `json:"question_count" db:"question_count"`
+}
+
+type IDailyChallengeRepository interface {
+	GetDailyChallengeByDate(date time.Time) (*TriviaDailyChallengeEntity, error)
+	GetDailyChallengeCandidateIds(date time.Time, recentDays int) ([]int64, error)
+	CreateDailyChallenge(date time.Time, deckName string, questionIds []int64) (*TriviaDailyChallengeEntity, error)
+
+	// Player methods
+	CreateDailyChallengeGame(userId int64, challenge *TriviaDailyChallengeEntity, numWrongChoices int, timeLimitSeconds int) (*TriviaGameInstanceEntity, error)
+	GetDailyChallengeGame(challengeId int64, userId int64) (*TriviaGameInstanceEntity, error)
+	GetDailyChallengeDays(userId int64, limit int) ([]time.Time, error)
+
+	// Leaderboard methods
+	GetDailyChallengeLeaderboardCount(challengeId int64) (*int, error)
+	GetDailyChallengeLeaderboard(challengeId int64, pageSize int, offset int) ([]*models.LeaderboardEntry, error)
+	GetDailyChallengeEntry(challengeId int64, userId int64) (*models.LeaderboardEntry, error)
+}
+
+type DailyChallengeRepository struct {
+	db *database.AppDataSource
+}
+
+func NewDailyChallengeRepository(db *database.AppDataSource) IDailyChallengeRepository {
+	return &DailyChallengeRepository{
+		db: db,
+	}
+}
+
+func (r *DailyChallengeRepository) GetDailyChallengeByDate(date time.Time) (*TriviaDailyChallengeEntity, error) {
+	challenge := &TriviaDailyChallengeEntity{}
+	sql := `SELECT
+		c.id, c.created_at, c.modified_at, c.is_archived, c.challenge_date, c.deck_id,
+		(SELECT COUNT(*) FROM trivia_deck_questions dq WHERE dq.deck_id = c.deck_id) AS question_count
+	FROM trivia_daily_challenges c
+	WHERE c.challenge_date = $1 AND c.is_archived = false`
+	err := r.db.DB.Get(challenge, sql, date.Format(leaderboardDateFormat))
+	if err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// GetDailyChallengeCandidateIds returns every playable question that was not in a challenge during
+// the recentDays days before date, in ID order.
+func (r *DailyChallengeRepository) GetDailyChallengeCandidateIds(date time.Time, recentDays int) ([]int64, error) {
+	questionIds := []int64{}
+	sql := `SELECT q.id
+	FROM trivia_questions q
+	WHERE q.is_published = true AND q.is_archived = false
+		AND q.id NOT IN (
+			SELECT dq.question_id
+			FROM trivia_daily_challenges c
+			JOIN trivia_deck_questions dq ON dq.deck_id = c.deck_id
+			WHERE c.challenge_date < $1::date AND c.challenge_date >= $1::date - $2::int
+		)
+	ORDER BY q.id ASC`
+	err := r.db.DB.Select(&questionIds, sql, date.Format(leaderboardDateFormat), recentDays)
+	if err != nil {
+		return nil, err
+	}
+	return questionIds, nil
+}
+
+// CreateDailyChallenge creates the day's deck with the questions in the order given, along with the
+// challenge itself. It returns sql.ErrNoRows and creates nothing when the day already has a challenge.
+func (r *DailyChallengeRepository) CreateDailyChallenge(date time.Time, deckName string, questionIds []int64) (*TriviaDailyChallengeEntity, error) {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deckId int64
+	sql := `INSERT INTO trivia_decks (name, description, is_system_deck, is_approved)
+		VALUES ($1, $2, true, false)
+		RETURNING id`
+	err = tx.QueryRowx(sql, deckName, "The questions for the daily challenge on "+date.Format(leaderboardDateFormat)).Scan(&deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	sql = `INSERT INTO trivia_deck_questions (deck_id, question_id, position)
+		SELECT $1, ids.question_id, ids.ord
+		FROM unnest($2::int[]) WITH ORDINALITY AS ids(question_id, ord)`
+	_, err = tx.Exec(sql, deckId, pq.Array(questionIds))
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &TriviaDailyChallengeEntity{}
+	sql = `INSERT INTO trivia_daily_challenges (challenge_date, deck_id)
+		VALUES ($1, $2)
+		ON CONFLICT (challenge_date) DO NOTHING
+		RETURNING id, created_at, modified_at, is_archived, challenge_date, deck_id`
+	err = tx.Get(challenge, sql, date.Format(leaderboardDateFormat), deckId)
+	if err != nil {
+		return nil, err
+	}
+	challenge.QuestionCount = len(questionIds)
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// Player methods
+
+// CreateDailyChallengeGame starts a player's game for a challenge. It returns sql.ErrNoRows when the
+// player already has a game for the challenge.
+func (r *DailyChallengeRepository) CreateDailyChallengeGame(userId int64, challenge *TriviaDailyChallengeEntity, numWrongChoices int, timeLimitSeconds int) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `INSERT INTO trivia_game_instances (user_id, deck_id, num_wrong_choices, time_limit_seconds, daily_challenge_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (daily_challenge_id, user_id) WHERE daily_challenge_id IS NOT NULL DO NOTHING
+		RETURNING id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id`
+	err := r.db.DB.Get(game, sql, userId, challenge.DeckID, numWrongChoices, timeLimitSeconds, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (r *DailyChallengeRepository) GetDailyChallengeGame(challengeId int64, userId int64) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id
+	FROM trivia_game_instances
+	WHERE daily_challenge_id = $1 AND user_id = $2`
+	err := r.db.DB.Get(game, sql, challengeId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// GetDailyChallengeDays returns the dates of the player's most recent daily challenges, newest first.
+// A challenge only counts once at least one of its questions has been answered.
+func (r *DailyChallengeRepository) GetDailyChallengeDays(userId int64, limit int) ([]time.Time, error) {
+	days := []time.Time{}
+	sql := `SELECT c.challenge_date
+	FROM trivia_game_instances g
+	JOIN trivia_daily_challenges c ON c.id = g.daily_challenge_id
+	WHERE g.user_id = $1 AND g.is_archived = false AND g.total_correct + g.total_incorrect > 0
+	ORDER BY c.challenge_date DESC
+	LIMIT $2`
+	err := r.db.DB.Select(&days, sql, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// Leaderboard methods
+
+// rankedDailyChallengeSQL ranks every player's game for a challenge by score. Games without any answers
+// and archived or banned players are left off.
+const rankedDailyChallengeSQL = `SELECT
+		g.user_id, u.display_name, g.total_score, g.total_correct, g.total_correct + g.total_incorrect AS total_answered,
+		RANK() OVER (ORDER BY g.total_score DESC) AS rank,
+		ROW_NUMBER() OVER (ORDER BY g.total_score DESC, g.user_id ASC) AS position
+	FROM trivia_game_instances g
+	JOIN users u ON u.id = g.user_id
+	WHERE g.daily_challenge_id = $1 AND g.is_archived = false AND g.total_correct + g.total_incorrect > 0
+		AND u.is_archived = false AND u.is_banned = false`
+
+func (r *DailyChallengeRepository) GetDailyChallengeLeaderboardCount(challengeId int64) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count FROM (` + rankedDailyChallengeSQL + `) ranked`
+	err := r.db.DB.Get(count, sql, challengeId)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+func (r *DailyChallengeRepository) GetDailyChallengeLeaderboard(challengeId int64, pageSize int, offset int) ([]*models.LeaderboardEntry, error) {
+	entries := []*models.LeaderboardEntry{}
+	sql := `SELECT user_id, display_name, total_score, total_correct, total_answered, rank
+	FROM (` + rankedDailyChallengeSQL + `) ranked
+	ORDER BY position ASC
+	LIMIT $2 OFFSET $3`
+	err := r.db.DB.Select(&entries, sql, challengeId, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *DailyChallengeRepository) GetDailyChallengeEntry(challengeId int64, userId int64) (*models.LeaderboardEntry, error) {
+	entry := &models.LeaderboardEntry{}
+	sql := `SELECT user_id, display_name, total_score, total_correct, total_answered, rank
+	FROM (` + rankedDailyChallengeSQL + `) ranked
+	WHERE user_id = $2`
+	err := r.db.DB.Get(entry, sql, challengeId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,423 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+// ErrDuplicateAttempt is returned when a game already has an attempt at the question.
+var ErrDuplicateAttempt = errors.New("the question already has an attempt in this game")
+
+type TriviaGameInstanceEntity struct {
+	ID               int64      `json:"id" db:"id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt       *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived       bool       `json:"is_archived" db:"is_archived"`
+	UserID           int64      `json:"user_id" db:"user_id"`
+	DeckID           int64      `json:"deck_id" db:"deck_id"`
+	StartedAt        time.Time  `json:"started_at" db:"started_at"`
+	EndedAt          *time.Time `json:"ended_at" db:"ended_at"`
+	NumWrongChoices  int        `json:"num_wrong_choices" db:"num_wrong_choices"`
+	TotalCorrect     int        `json:"total_correct" db:"total_correct"`
+	TotalIncorrect   int        `json:"total_incorrect" db:"total_incorrect"`
+	TimeLimitSeconds int        `json:"time_limit_seconds" db:"time_limit_seconds"`
+	TotalScore       int        `json:"total_score" db:"total_score"`
+	CurrentStreak    int        `json:"current_streak" db:"current_streak"`
+	BestStreak       int        `json:"best_streak" db:"best_streak"`
+	RoomID           *int64     `json:"room_id" db:"room_id"` // Set when the game is played in a multiplayer room
+}
+
+type TriviaQuestionAttemptEntity struct {
+	ID             int64      `json:"id" db:"id"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt     *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived     bool       `json:"is_archived" db:"is_archived"`
+	GameInstanceID int64      `json:"game_instance_id" db:"game_instance_id"`
+	QuestionID     int64      `json:"question_id" db:"question_id"`
+	PickedAnswer   string     `json:"picked_answer" db:"picked_answer"`
+	IsCorrect      bool       `json:"is_correct" db:"is_correct"`
+	IsTimedOut     bool       `json:"is_timed_out" db:"is_timed_out"`
+	ResponseTimeMs int        `json:"response_time_ms" db:"response_time_ms"`
+	Score          int        `json:"score" db:"score"`
+	Streak         int        `json:"streak" db:"streak"`
+}
+
+// TriviaQuestionAttemptDetailEntity is an attempt together with the question it answered.
+type TriviaQuestionAttemptDetailEntity struct {
+	TriviaQuestionAttemptEntity
+	Question      string         `json:"question" db:"question"`
+	CorrectAnswer string         `json:"correct_answer" db:"correct_answer"`
+	Tags          pq.StringArray `json:"tags" db:"tags"`
+}
+
+// TriviaGameQuestionEntity is a question as it was served in a game.
+type TriviaGameQuestionEntity struct {
+	ID             int64          `json:"id" db:"id"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	GameInstanceID int64          `json:"game_instance_id" db:"game_instance_id"`
+	QuestionID     int64          `json:"question_id" db:"question_id"`
+	Choices        pq.StringArray `json:"choices" db:"choices"`
+	ServedAt       time.Time      `json:"served_at" db:"served_at"`
+	DeadlineAt     time.Time      `json:"deadline_at" db:"deadline_at"`
+}
+
+type IGameRepository interface {
+	CreateGameInstance(userId int64, deckId int64, numWrongChoices int, timeLimitSeconds int) (*TriviaGameInstanceEntity, error)
+	CreateRoomGameInstance(userId int64, room *TriviaGameRoomEntity) (*TriviaGameInstanceEntity, error)
+	GetGameInstanceById(gameId int64) (*TriviaGameInstanceEntity, error)
+	EndGameInstance(gameId int64) (*TriviaGameInstanceEntity, error)
+	ArchiveGameInstance(gameId int64) error
+	CreateQuestionAttempt(attempt *TriviaQuestionAttemptEntity) (*TriviaQuestionAttemptEntity, error)
+
+	// Gameplay question methods
+	GetPlayableDeckQuestionCount(deckId int64, rules *models.TriviaDeckRules) (*int, error)
+	GetNextUnansweredQuestion(gameId int64, deckId int64, rules *models.TriviaDeckRules) (*TriviaQuestionEntity, error)
+	GetPlayableDeckQuestions(deckId int64, rules *models.TriviaDeckRules, limit int, seed int64) ([]*TriviaQuestionEntity, error)
+	GetServedQuestion(gameId int64, questionId int64) (*TriviaGameQuestionEntity, error)
+	SaveServedQuestion(served *TriviaGameQuestionEntity) (*TriviaGameQuestionEntity, error)
+
+	// Game summary methods
+	GetGameAttemptDetails(gameId int64) ([]*TriviaQuestionAttemptDetailEntity, error)
+	GetPlayerGameAverages(userId int64, excludeGameId int64) (*models.GameAverages, error)
+	GetDeckGameAverages(deckId int64, excludeGameId int64) (*models.GameAverages, error)
+}
+
+type GameRepository struct {
+	db *database.AppDataSource
+}
+
+func NewGameRepository(db *database.AppDataSource) IGameRepository {
+	return &GameRepository{
+		db: db,
+	}
+}
+
+func (r *GameRepository) CreateGameInstance(userId int64, deckId int64, numWrongChoices int, timeLimitSeconds int) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `INSERT INTO trivia_game_instances (user_id, deck_id, num_wrong_choices, time_limit_seconds)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id`
+	err := r.db.DB.Get(game, sql, userId, deckId, numWrongChoices, timeLimitSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (r *GameRepository) CreateRoomGameInstance(userId int64, room *TriviaGameRoomEntity) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `INSERT INTO trivia_game_instances (user_id, deck_id, num_wrong_choices, time_limit_seconds, room_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id`
+	err := r.db.DB.Get(game, sql, userId, room.DeckID, room.NumWrongChoices, room.TimeLimitSeconds, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (r *GameRepository) GetGameInstanceById(gameId int64) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id
+	FROM trivia_game_instances
+	WHERE id = $1`
+	err := r.db.DB.Get(game, sql, gameId)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (r *GameRepository) EndGameInstance(gameId int64) (*TriviaGameInstanceEntity, error) {
+	game := &TriviaGameInstanceEntity{}
+	sql := `UPDATE trivia_game_instances SET ended_at = NOW(), modified_at = NOW()
+		WHERE id = $1 AND ended_at IS NULL
+		RETURNING id, created_at, modified_at, is_archived, user_id, deck_id, started_at, ended_at, num_wrong_choices, total_correct, total_incorrect, time_limit_seconds, total_score, current_streak, best_streak, room_id`
+	err := r.db.DB.Get(game, sql, gameId)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// ArchiveGameInstance ends and hides a game that was never played, such as one created for a room
+// that failed to start.
+func (r *GameRepository) ArchiveGameInstance(gameId int64) error {
+	sql := `UPDATE trivia_game_instances SET
+		is_archived = true,
+		ended_at = COALESCE(ended_at, NOW()),
+		modified_at = NOW()
+	WHERE id = $1`
+	_, err := r.db.DB.Exec(sql, gameId)
+	return err
+}
+
+// CreateQuestionAttempt records an answer, rolls its score and streak up onto the game, updates the
+// player's and question's skill ratings and adds the score to the player's leaderboards in a single transaction.
+// It returns ErrDuplicateAttempt when the question was already answered, and sql.ErrNoRows when the game has ended.
+func (r *GameRepository) CreateQuestionAttempt(attempt *TriviaQuestionAttemptEntity) (*TriviaQuestionAttemptEntity, error) {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	created := &TriviaQuestionAttemptEntity{}
+	sql := `INSERT INTO trivia_question_attempts
+			(game_instance_id, question_id, picked_answer, is_correct, is_timed_out, response_time_ms, score, streak)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (game_instance_id, question_id) DO NOTHING
+		RETURNING id, created_at, modified_at, is_archived, game_instance_id, question_id, picked_answer, is_correct,
+			is_timed_out, response_time_ms, score, streak`
+	rows, err := tx.Queryx(sql, attempt.GameInstanceID, attempt.QuestionID, attempt.PickedAnswer, attempt.IsCorrect,
+		attempt.IsTimedOut, attempt.ResponseTimeMs, attempt.Score, attempt.Streak)
+	if err != nil {
+		return nil, err
+	}
+	inserted := rows.Next()
+	if inserted {
+		err = rows.StructScan(created)
+	}
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		return nil, ErrDuplicateAttempt
+	}
+
+	sql = `UPDATE trivia_game_instances SET
+		total_correct = total_correct + CASE WHEN $2 THEN 1 ELSE 0 END,
+		total_incorrect = total_incorrect + CASE WHEN $2 THEN 0 ELSE 1 END,
+		total_score = total_score + $3,
+		current_streak = $4,
+		best_streak = GREATEST(best_streak, $4),
+		modified_at = NOW()
+	WHERE id = $1 AND ended_at IS NULL
+	RETURNING user_id, deck_id`
+	game := &TriviaGameInstanceEntity{}
+	err = tx.Get(game, sql, attempt.GameInstanceID, attempt.IsCorrect, attempt.Score, attempt.Streak)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateSkillRatings(tx, game.UserID, created.QuestionID, created.IsCorrect)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addAttemptToLeaderboards(tx, game, created, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// updateSkillRatings scores an answer as an Elo match between the player and the question. Both rows
+// are locked, always player first, so concurrent answers cannot lose an update.
+func updateSkillRatings(tx *sqlx.Tx, userId int64, questionId int64, isCorrect bool) error {
+	sql := `INSERT INTO user_ratings (user_id, rating) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`
+	_, err := tx.Exec(sql, userId, models.DefaultSkillRating)
+	if err != nil {
+		return err
+	}
+	sql = `INSERT INTO question_ratings (question_id, rating) VALUES ($1, $2) ON CONFLICT (question_id) DO NOTHING`
+	_, err = tx.Exec(sql, questionId, models.DefaultSkillRating)
+	if err != nil {
+		return err
+	}
+
+	player := models.SkillRating{}
+	err = tx.Get(&player, `SELECT rating, rated_attempts FROM user_ratings WHERE user_id = $1 FOR UPDATE`, userId)
+	if err != nil {
+		return err
+	}
+	question := models.SkillRating{}
+	err = tx.Get(&question, `SELECT rating, rated_attempts FROM question_ratings WHERE question_id = $1 FOR UPDATE`, questionId)
+	if err != nil {
+		return err
+	}
+
+	player, question = models.CalculateEloRatings(player, question, isCorrect)
+
+	sql = `UPDATE user_ratings SET rating = $2, rated_attempts = $3, modified_at = NOW() WHERE user_id = $1`
+	_, err = tx.Exec(sql, userId, player.Rating, player.RatedAttempts)
+	if err != nil {
+		return err
+	}
+	sql = `UPDATE question_ratings SET rating = $2, rated_attempts = $3, modified_at = NOW() WHERE question_id = $1`
+	_, err = tx.Exec(sql, questionId, question.Rating, question.RatedAttempts)
+	return err
+}
+
+// Gameplay question methods
+func (r *GameRepository) GetPlayableDeckQuestionCount(deckId int64, rules *models.TriviaDeckRules) (*int, error) {
+	count := new(int)
+	var err error
+
+	if rules != nil {
+		where, args := deckRulesWhereClause(rules, 1)
+		sql := `SELECT COUNT(*) as count FROM trivia_questions WHERE is_published = true` + where
+		err = r.db.DB.Get(count, sql, args...)
+	} else {
+		sql := `SELECT COUNT(*) as count
+			FROM trivia_deck_questions dq
+			JOIN trivia_questions q ON q.id = dq.question_id
+			WHERE dq.deck_id = $1 AND q.is_archived = false AND q.is_published = true`
+		err = r.db.DB.Get(count, sql, deckId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// GetNextUnansweredQuestion returns the first playable question in the deck that has no attempt in the game.
+// Static decks are played in position order. Smart decks are shuffled using the game ID as the seed, so
+// each game gets its own order that stays the same between requests. A question that was already served
+// comes first so that newly published questions cannot replace the one being played.
+func (r *GameRepository) GetNextUnansweredQuestion(gameId int64, deckId int64, rules *models.TriviaDeckRules) (*TriviaQuestionEntity, error) {
+	question := &TriviaQuestionEntity{}
+	var err error
+
+	if rules != nil {
+		where, ruleArgs := deckRulesWhereClause(rules, 3)
+		sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags
+			FROM trivia_questions
+			WHERE is_published = true` + where + `
+			AND id NOT IN (SELECT question_id FROM trivia_question_attempts WHERE game_instance_id = $1)
+			ORDER BY EXISTS (SELECT 1 FROM trivia_game_questions WHERE game_instance_id = $1 AND question_id = trivia_questions.id) DESC,
+				md5($2::text || ':' || id::text) ASC, id ASC
+			LIMIT 1`
+		args := append([]interface{}{gameId, gameId}, ruleArgs...)
+		err = r.db.DB.Get(question, sql, args...)
+	} else {
+		sql := `SELECT q.id, q.created_at, q.modified_at, q.is_archived, q.is_published, q.question, q.correct_answer, q.tags
+			FROM trivia_deck_questions dq
+			JOIN trivia_questions q ON q.id = dq.question_id
+			WHERE dq.deck_id = $2 AND q.is_archived = false AND q.is_published = true
+			AND q.id NOT IN (SELECT question_id FROM trivia_question_attempts WHERE game_instance_id = $1)
+			ORDER BY dq.position ASC, dq.id ASC
+			LIMIT 1`
+		err = r.db.DB.Get(question, sql, gameId, deckId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// GetPlayableDeckQuestions returns up to limit playable questions. Static decks are returned in position
+// order and smart decks are shuffled using seed, so the same seed always picks the same questions.
+func (r *GameRepository) GetPlayableDeckQuestions(deckId int64, rules *models.TriviaDeckRules, limit int, seed int64) ([]*TriviaQuestionEntity, error) {
+	questions := []*TriviaQuestionEntity{}
+	var err error
+
+	if rules != nil {
+		where, ruleArgs := deckRulesWhereClause(rules, 3)
+		sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags
+			FROM trivia_questions
+			WHERE is_published = true` + where + `
+			ORDER BY md5($2::text || ':' || id::text) ASC, id ASC
+			LIMIT $1`
+		args := append([]interface{}{limit, seed}, ruleArgs...)
+		err = r.db.DB.Select(&questions, sql, args...)
+	} else {
+		sql := `SELECT q.id, q.created_at, q.modified_at, q.is_archived, q.is_published, q.question, q.correct_answer, q.tags
+			FROM trivia_deck_questions dq
+			JOIN trivia_questions q ON q.id = dq.question_id
+			WHERE dq.deck_id = $2 AND q.is_archived = false AND q.is_published = true
+			ORDER BY dq.position ASC, dq.id ASC
+			LIMIT $1`
+		err = r.db.DB.Select(&questions, sql, limit, deckId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// GetServedQuestion returns the choices and deadline that were set the first time a question was served in a game.
+func (r *GameRepository) GetServedQuestion(gameId int64, questionId int64) (*TriviaGameQuestionEntity, error) {
+	served := &TriviaGameQuestionEntity{}
+	sql := `SELECT id, created_at, game_instance_id, question_id, choices, served_at, deadline_at
+		FROM trivia_game_questions
+		WHERE game_instance_id = $1 AND question_id = $2`
+	err := r.db.DB.Get(served, sql, gameId, questionId)
+	if err != nil {
+		return nil, err
+	}
+	return served, nil
+}
+
+// SaveServedQuestion stores a served question. If the question was already served the stored
+// row is kept and returned so that concurrent requests agree on the choices and deadline.
+func (r *GameRepository) SaveServedQuestion(served *TriviaGameQuestionEntity) (*TriviaGameQuestionEntity, error) {
+	stored := &TriviaGameQuestionEntity{}
+	sql := `INSERT INTO trivia_game_questions (game_instance_id, question_id, choices, served_at, deadline_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (game_instance_id, question_id) DO UPDATE SET choices = trivia_game_questions.choices
+		RETURNING id, created_at, game_instance_id, question_id, choices, served_at, deadline_at`
+	err := r.db.DB.Get(stored, sql, served.GameInstanceID, served.QuestionID, pq.Array(served.Choices), served.ServedAt, served.DeadlineAt)
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// Game summary methods
+func (r *GameRepository) GetGameAttemptDetails(gameId int64) ([]*TriviaQuestionAttemptDetailEntity, error) {
+	attempts := []*TriviaQuestionAttemptDetailEntity{}
+	sql := `SELECT
+		a.id, a.created_at, a.modified_at, a.is_archived, a.game_instance_id, a.question_id, a.picked_answer, a.is_correct,
+		a.is_timed_out, a.response_time_ms, a.score, a.streak, q.question, q.correct_answer, q.tags
+	FROM trivia_question_attempts a
+	JOIN trivia_questions q ON q.id = a.question_id
+	WHERE a.game_instance_id = $1
+	ORDER BY a.created_at ASC, a.id ASC`
+	err := r.db.DB.Select(&attempts, sql, gameId)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (r *GameRepository) GetPlayerGameAverages(userId int64, excludeGameId int64) (*models.GameAverages, error) {
+	return r.getGameAverages(`user_id = $1`, userId, excludeGameId)
+}
+
+func (r *GameRepository) GetDeckGameAverages(deckId int64, excludeGameId int64) (*models.GameAverages, error) {
+	return r.getGameAverages(`deck_id = $1`, deckId, excludeGameId)
+}
+
+// getGameAverages averages the finished games matching a condition. Games without
+// any answers are left out so abandoned games do not drag the averages down.
+func (r *GameRepository) getGameAverages(condition string, id int64, excludeGameId int64) (*models.GameAverages, error) {
+	averages := &models.GameAverages{}
+	sql := `SELECT
+		COUNT(*) AS games_played,
+		COALESCE(AVG(total_score), 0) AS average_score,
+		COALESCE(AVG(total_correct * 100.0 / (total_correct + total_incorrect)), 0) AS average_accuracy
+	FROM trivia_game_instances
+	WHERE ` + condition + ` AND id <> $2 AND ended_at IS NOT NULL AND is_archived = false
+		AND total_correct + total_incorrect > 0`
+	err := r.db.DB.Get(averages, sql, id, excludeGameId)
+	if err != nil {
+		return nil, err
+	}
+	return averages, nil
+}
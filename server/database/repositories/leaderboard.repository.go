@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+const leaderboardDateFormat = "2006-01-02"
+
+type ILeaderboardRepository interface {
+	GetLeaderboardCount(boardKey string, period string, periodStart time.Time) (*int, error)
+	GetLeaderboard(boardKey string, period string, periodStart time.Time, pageSize int, offset int) ([]*models.LeaderboardEntry, error)
+	GetLeaderboardNeighborhood(boardKey string, period string, periodStart time.Time, userId int64, neighbors int) ([]*models.LeaderboardEntry, error)
+}
+
+type LeaderboardRepository struct {
+	db *database.AppDataSource
+}
+
+func NewLeaderboardRepository(db *database.AppDataSource) ILeaderboardRepository {
+	return &LeaderboardRepository{
+		db: db,
+	}
+}
+
+// rankedLeaderboardSQL ranks every player on a board for one period. Archived and banned players are left off.
+const rankedLeaderboardSQL = `SELECT
+		s.user_id, u.display_name, s.total_score, s.total_correct, s.total_answered,
+		RANK() OVER (ORDER BY s.total_score DESC) AS rank,
+		ROW_NUMBER() OVER (ORDER BY s.total_score DESC, s.user_id ASC) AS position
+	FROM leaderboard_scores s
+	JOIN users u ON u.id = s.user_id
+	WHERE s.board_key = $1 AND s.period_type = $2 AND s.period_start = $3
+		AND u.is_archived = false AND u.is_banned = false`
+
+func (r *LeaderboardRepository) GetLeaderboardCount(boardKey string, period string, periodStart time.Time) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count
+	FROM leaderboard_scores s
+	JOIN users u ON u.id = s.user_id
+	WHERE s.board_key = $1 AND s.period_type = $2 AND s.period_start = $3
+		AND u.is_archived = false AND u.is_banned = false`
+	err := r.db.DB.Get(count, sql, boardKey, period, periodStart.Format(leaderboardDateFormat))
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+func (r *LeaderboardRepository) GetLeaderboard(boardKey string, period string, periodStart time.Time, pageSize int, offset int) ([]*models.LeaderboardEntry, error) {
+	entries := []*models.LeaderboardEntry{}
+	sql := `SELECT user_id, display_name, total_score, total_correct, total_answered, rank
+	FROM (` + rankedLeaderboardSQL + `) ranked
+	ORDER BY position ASC
+	LIMIT $4 OFFSET $5`
+	err := r.db.DB.Select(&entries, sql, boardKey, period, periodStart.Format(leaderboardDateFormat), pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLeaderboardNeighborhood returns a player's entry together with up to neighbors players ranked
+// directly above and below them. It returns no entries when the player is not on the board.
+func (r *LeaderboardRepository) GetLeaderboardNeighborhood(boardKey string, period string, periodStart time.Time, userId int64, neighbors int) ([]*models.LeaderboardEntry, error) {
+	entries := []*models.LeaderboardEntry{}
+	sql := `WITH ranked AS (` + rankedLeaderboardSQL + `),
+		player AS (SELECT position FROM ranked WHERE user_id = $4)
+	SELECT user_id, display_name, total_score, total_correct, total_answered, rank
+	FROM ranked, player
+	WHERE ranked.position BETWEEN player.position - $5 AND player.position + $5
+	ORDER BY ranked.position ASC`
+	err := r.db.DB.Select(&entries, sql, boardKey, period, periodStart.Format(leaderboardDateFormat), userId, neighbors)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// addAttemptToLeaderboards adds an answer to the player's row on the global board, the game's deck
+// board and the board of every tag on the question, for each period it falls in. It runs inside the
+// transaction that records the attempt so the boards never drift.
+func addAttemptToLeaderboards(tx *sqlx.Tx, game *TriviaGameInstanceEntity, attempt *TriviaQuestionAttemptEntity, at time.Time) error {
+	tags := []string{}
+	sql := `SELECT DISTINCT lower(trim(tag))
+		FROM trivia_questions, unnest(tags) AS tag
+		WHERE id = $1 AND trim(tag) <> ''`
+	err := tx.Select(&tags, sql, attempt.QuestionID)
+	if err != nil {
+		return err
+	}
+
+	boardKeys := []string{models.LeaderboardGlobal, models.DeckLeaderboardKey(game.DeckID)}
+	for _, tag := range tags {
+		boardKeys = append(boardKeys, models.TagLeaderboardKey(tag))
+	}
+
+	var keys, periods, starts []string
+	for _, boardKey := range boardKeys {
+		for _, period := range models.LeaderboardPeriods {
+			periodStart, err := models.LeaderboardPeriodStart(period, at)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, boardKey)
+			periods = append(periods, period)
+			starts = append(starts, periodStart.Format(leaderboardDateFormat))
+		}
+	}
+
+	correct := 0
+	if attempt.IsCorrect {
+		correct = 1
+	}
+
+	sql = `INSERT INTO leaderboard_scores (board_key, period_type, period_start, user_id, total_score, total_correct, total_answered)
+		SELECT b.board_key, b.period_type, b.period_start, $4, $5, $6, 1
+		FROM unnest($1::text[], $2::text[], $3::date[]) AS b(board_key, period_type, period_start)
+		ON CONFLICT (board_key, period_type, period_start, user_id) DO UPDATE SET
+			total_score = leaderboard_scores.total_score + EXCLUDED.total_score,
+			total_correct = leaderboard_scores.total_correct + EXCLUDED.total_correct,
+			total_answered = leaderboard_scores.total_answered + EXCLUDED.total_answered,
+			modified_at = NOW()`
+	_, err = tx.Exec(sql, pq.Array(keys), pq.Array(periods), pq.Array(starts), game.UserID, attempt.Score, correct)
+	return err
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database"
+)
+
+var (
+	RoomStatusWaiting    = "waiting"
+	RoomStatusInProgress = "in_progress"
+	RoomStatusFinished   = "finished"
+	RoomStatusClosed     = "closed"
+)
+
+type TriviaGameRoomEntity struct {
+	ID               int64      `json:"id" db:"id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt       *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived       bool       `json:"is_archived" db:"is_archived"`
+	Code             string     `json:"code" db:"code"`
+	DeckID           int64      `json:"deck_id" db:"deck_id"`
+	HostUserID       int64      `json:"host_user_id" db:"host_user_id"`
+	Status           string     `json:"status" db:"status"`
+	NumWrongChoices  int        `json:"num_wrong_choices" db:"num_wrong_choices"`
+	TimeLimitSeconds int        `json:"time_limit_seconds" db:"time_limit_seconds"`
+	QuestionCount    int        `json:"question_count" db:"question_count"`
+	MaxPlayers       int        `json:"max_players" db:"max_players"`
+	AutoStartSeconds *int       `json:"auto_start_seconds" db:"auto_start_seconds"`
+	StartedAt        *time.Time `json:"started_at" db:"started_at"`
+	EndedAt          *time.Time `json:"ended_at" db:"ended_at"`
+}
+
+type IRoomRepository interface {
+	CreateRoom(room *TriviaGameRoomEntity) (*TriviaGameRoomEntity, error)
+	GetRoomByCode(code string) (*TriviaGameRoomEntity, error)
+	UpdateRoomStatus(roomId int64, status string) (*TriviaGameRoomEntity, error)
+	UpdateRoomHost(roomId int64, hostUserId int64) (*TriviaGameRoomEntity, error)
+	CloseStaleRooms() (int, error)
+}
+
+type RoomRepository struct {
+	db *database.AppDataSource
+}
+
+func NewRoomRepository(db *database.AppDataSource) IRoomRepository {
+	return &RoomRepository{
+		db: db,
+	}
+}
+
+func (r *RoomRepository) CreateRoom(room *TriviaGameRoomEntity) (*TriviaGameRoomEntity, error) {
+	created := &TriviaGameRoomEntity{}
+	sql := `INSERT INTO trivia_game_rooms (code, deck_id, host_user_id, num_wrong_choices, time_limit_seconds, question_count, max_players, auto_start_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, modified_at, is_archived, code, deck_id, host_user_id, status, num_wrong_choices,
+			time_limit_seconds, question_count, max_players, auto_start_seconds, started_at, ended_at`
+	err := r.db.DB.Get(created, sql, room.Code, room.DeckID, room.HostUserID, room.NumWrongChoices, room.TimeLimitSeconds, room.QuestionCount, room.MaxPlayers, room.AutoStartSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *RoomRepository) GetRoomByCode(code string) (*TriviaGameRoomEntity, error) {
+	room := &TriviaGameRoomEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, code, deck_id, host_user_id, status, num_wrong_choices,
+		time_limit_seconds, question_count, max_players, auto_start_seconds, started_at, ended_at
+	FROM trivia_game_rooms
+	WHERE code = $1`
+	err := r.db.DB.Get(room, sql, code)
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// UpdateRoomStatus moves a room to a new status, stamping started_at when it starts
+// and ended_at when it finishes or is closed.
+func (r *RoomRepository) UpdateRoomStatus(roomId int64, status string) (*TriviaGameRoomEntity, error) {
+	room := &TriviaGameRoomEntity{}
+	sql := `UPDATE trivia_game_rooms SET
+		status = $2,
+		started_at = CASE WHEN $2 = 'in_progress' THEN NOW() ELSE started_at END,
+		ended_at = CASE WHEN $2 IN ('finished', 'closed') THEN NOW() ELSE ended_at END,
+		modified_at = NOW()
+	WHERE id = $1
+	RETURNING id, created_at, modified_at, is_archived, code, deck_id, host_user_id, status, num_wrong_choices,
+		time_limit_seconds, question_count, max_players, auto_start_seconds, started_at, ended_at`
+	err := r.db.DB.Get(room, sql, roomId, status)
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (r *RoomRepository) UpdateRoomHost(roomId int64, hostUserId int64) (*TriviaGameRoomEntity, error) {
+	room := &TriviaGameRoomEntity{}
+	sql := `UPDATE trivia_game_rooms SET
+		host_user_id = $2,
+		modified_at = NOW()
+	WHERE id = $1
+	RETURNING id, created_at, modified_at, is_archived, code, deck_id, host_user_id, status, num_wrong_choices,
+		time_limit_seconds, question_count, max_players, auto_start_seconds, started_at, ended_at`
+	err := r.db.DB.Get(room, sql, roomId, hostUserId)
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// CloseStaleRooms closes every room still waiting or in progress and ends the games played in
+// them. Rooms only live in memory, so these were left open by a previous run of the server.
+func (r *RoomRepository) CloseStaleRooms() (int, error) {
+	var count int
+	sql := `WITH closed_rooms AS (
+		UPDATE trivia_game_rooms SET
+			status = 'closed',
+			ended_at = NOW(),
+			modified_at = NOW()
+		WHERE status IN ('waiting', 'in_progress')
+		RETURNING id
+	), ended_games AS (
+		UPDATE trivia_game_instances SET
+			ended_at = NOW(),
+			modified_at = NOW()
+		WHERE room_id IN (SELECT id FROM closed_rooms) AND ended_at IS NULL
+		RETURNING id
+	)
+	SELECT COUNT(*) FROM closed_rooms`
+	err := r.db.DB.Get(&count, sql)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+type IStatsRepository interface {
+	GetPlayerTotals(userId int64) (*models.PlayerStatTotals, error)
+	GetPlayerRating(userId int64) (*models.SkillRating, error)
+	GetPlayerTagStats(userId int64) ([]*models.PlayerTagStats, error)
+	GetPlayerFavoriteDecks(userId int64, limit int) ([]*models.PlayerDeckStats, error)
+	GetPlayerGamesCount(userId int64) (*int, error)
+	GetPlayerGames(userId int64, pageSize int, offset int) ([]*models.PlayerGameHistoryEntry, error)
+}
+
+type StatsRepository struct {
+	db *database.AppDataSource
+}
+
+func NewStatsRepository(db *database.AppDataSource) IStatsRepository {
+	return &StatsRepository{
+		db: db,
+	}
+}
+
+// GetPlayerTotals adds up a player's games. Games without any answers are left out so abandoned
+// games do not count as played.
+func (r *StatsRepository) GetPlayerTotals(userId int64) (*models.PlayerStatTotals, error) {
+	totals := &models.PlayerStatTotals{}
+	sql := `SELECT
+		COUNT(*) AS games_played,
+		COUNT(g.ended_at) AS games_finished,
+		COALESCE(SUM(g.total_correct + g.total_incorrect), 0) AS total_answered,
+		COALESCE(SUM(g.total_correct), 0) AS total_correct,
+		COALESCE(SUM(g.total_score), 0) AS total_score,
+		COALESCE(MAX(g.total_score), 0) AS best_game_score,
+		COALESCE(MAX(g.best_streak), 0) AS best_streak,
+		COALESCE((
+			SELECT COUNT(*) FROM trivia_question_attempts a
+			JOIN trivia_game_instances ag ON ag.id = a.game_instance_id
+			WHERE ag.user_id = $1 AND ag.is_archived = false AND a.is_timed_out = true
+		), 0) AS total_timed_out,
+		COALESCE((
+			SELECT AVG(a.response_time_ms) FROM trivia_question_attempts a
+			JOIN trivia_game_instances ag ON ag.id = a.game_instance_id
+			WHERE ag.user_id = $1 AND ag.is_archived = false AND a.is_timed_out = false
+		), 0) AS average_response_time_ms
+	FROM trivia_game_instances g
+	WHERE g.user_id = $1 AND g.is_archived = false AND g.total_correct + g.total_incorrect > 0`
+	err := r.db.DB.Get(totals, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetPlayerRating returns a player's skill rating, or the starting rating if they have never answered a question.
+func (r *StatsRepository) GetPlayerRating(userId int64) (*models.SkillRating, error) {
+	rating := &models.SkillRating{}
+	sql := `SELECT
+		COALESCE((SELECT rating FROM user_ratings WHERE user_id = $1), $2) AS rating,
+		COALESCE((SELECT rated_attempts FROM user_ratings WHERE user_id = $1), 0) AS rated_attempts`
+	err := r.db.DB.Get(rating, sql, userId, models.DefaultSkillRating)
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// GetPlayerTagStats counts a player's answers for every tag they have answered, most answered first.
+func (r *StatsRepository) GetPlayerTagStats(userId int64) ([]*models.PlayerTagStats, error) {
+	tags := []*models.PlayerTagStats{}
+	sql := `SELECT
+		lower(trim(tag)) AS tag,
+		COUNT(*) AS answered,
+		SUM(CASE WHEN a.is_correct THEN 1 ELSE 0 END) AS correct
+	FROM trivia_question_attempts a
+	JOIN trivia_game_instances g ON g.id = a.game_instance_id
+	JOIN trivia_questions q ON q.id = a.question_id
+	CROSS JOIN LATERAL unnest(q.tags) AS tag
+	WHERE g.user_id = $1 AND g.is_archived = false AND trim(tag) <> ''
+	GROUP BY lower(trim(tag))
+	ORDER BY answered DESC, tag ASC`
+	err := r.db.DB.Select(&tags, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetPlayerFavoriteDecks returns the decks a player has played the most, breaking ties by the most recently played.
+func (r *StatsRepository) GetPlayerFavoriteDecks(userId int64, limit int) ([]*models.PlayerDeckStats, error) {
+	decks := []*models.PlayerDeckStats{}
+	sql := `SELECT
+		g.deck_id, d.name AS deck_name, COUNT(*) AS games_played, MAX(g.total_score) AS best_score, MAX(g.started_at) AS last_played_at
+	FROM trivia_game_instances g
+	JOIN trivia_decks d ON d.id = g.deck_id
+	WHERE g.user_id = $1 AND g.is_archived = false AND g.total_correct + g.total_incorrect > 0
+	GROUP BY g.deck_id, d.name
+	ORDER BY games_played DESC, last_played_at DESC
+	LIMIT $2`
+	err := r.db.DB.Select(&decks, sql, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return decks, nil
+}
+
+func (r *StatsRepository) GetPlayerGamesCount(userId int64) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count FROM trivia_game_instances WHERE user_id = $1 AND is_archived = false`
+	err := r.db.DB.Get(count, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// GetPlayerGames returns a page of a player's games, newest first. Games that are still in progress are included.
+func (r *StatsRepository) GetPlayerGames(userId int64, pageSize int, offset int) ([]*models.PlayerGameHistoryEntry, error) {
+	games := []*models.PlayerGameHistoryEntry{}
+	sql := `SELECT
+		g.id AS game_id, g.deck_id, d.name AS deck_name, g.room_id, g.started_at, g.ended_at,
+		g.total_score, g.total_correct, g.total_incorrect, g.best_streak
+	FROM trivia_game_instances g
+	JOIN trivia_decks d ON d.id = g.deck_id
+	WHERE g.user_id = $1 AND g.is_archived = false
+	ORDER BY g.started_at DESC, g.id DESC
+	LIMIT $2 OFFSET $3`
+	err := r.db.DB.Select(&games, sql, userId, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
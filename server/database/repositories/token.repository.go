@@ -0,0 +1,248 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+type RefreshTokenEntity struct {
+	ID         int64      `json:"id" db:"id"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived bool       `json:"is_archived" db:"is_archived"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt     *time.Time `json:"used_at" db:"used_at"`
+	RevokedAt  *time.Time `json:"revoked_at" db:"revoked_at"`
+}
+
+type UserSessionEntity struct {
+	ID         int64      `json:"id" db:"id"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived bool       `json:"is_archived" db:"is_archived"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	SessionKey string     `json:"-" db:"session_key"`
+	Device     *string    `json:"device" db:"device"`
+	IPAddress  *string    `json:"ip_address" db:"ip_address"`
+	UserAgent  *string    `json:"user_agent" db:"user_agent"`
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at" db:"revoked_at"`
+	IsCurrent  bool       `json:"is_current" db:"-"` // Set when listing sessions, for the session making the request
+}
+
+type ITokenRepository interface {
+	// Session methods
+	CreateSession(userId int64, sessionKey string, client *models.UserSessionClientDTO, refreshTokenHash string, refreshExpiresAt time.Time) (*UserSessionEntity, error)
+	GetSessionByKey(sessionKey string) (*UserSessionEntity, error)
+	GetActiveSessions(userId int64, seenSince time.Time) ([]*UserSessionEntity, error)
+	TouchSession(id int64, client *models.UserSessionClientDTO) error
+	RevokeSession(sessionKey string) error
+	RevokeUserSessions(userId int64) error
+
+	// Refresh token methods
+	GetRefreshTokenByHash(tokenHash string) (*RefreshTokenEntity, error)
+	RotateRefreshToken(id int64, tokenHash string, expiresAt time.Time) (*RefreshTokenEntity, error)
+
+	// One-time token methods
+	ConsumeOneTimeToken(tokenId string, userId int64, purpose string, expiresAt time.Time) error
+}
+
+type TokenRepository struct {
+	db *database.AppDataSource
+}
+
+func NewTokenRepository(db *database.AppDataSource) ITokenRepository {
+	return &TokenRepository{
+		db: db,
+	}
+}
+
+// Session methods
+
+const userSessionColumns = `id, created_at, modified_at, is_archived, user_id, session_key, device, ip_address, user_agent, last_seen_at, revoked_at`
+
+// CreateSession starts a session along with the first refresh token of its family.
+func (r *TokenRepository) CreateSession(userId int64, sessionKey string, client *models.UserSessionClientDTO, refreshTokenHash string, refreshExpiresAt time.Time) (*UserSessionEntity, error) {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	session := &UserSessionEntity{}
+	sql := `INSERT INTO user_sessions (user_id, session_key, device, ip_address, user_agent, last_seen_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6)
+		RETURNING ` + userSessionColumns
+	err = tx.Get(session, sql, userId, sessionKey, client.Device, client.IPAddress, client.UserAgent, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	sql = `INSERT INTO user_refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)`
+	_, err = tx.Exec(sql, userId, sessionKey, refreshTokenHash, refreshExpiresAt.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *TokenRepository) GetSessionByKey(sessionKey string) (*UserSessionEntity, error) {
+	session := &UserSessionEntity{}
+	sql := `SELECT ` + userSessionColumns + `
+	FROM user_sessions
+	WHERE session_key = $1`
+	err := r.db.DB.Get(session, sql, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetActiveSessions returns the user's sessions that have not been logged out and were used since seenSince,
+// most recently used first.
+func (r *TokenRepository) GetActiveSessions(userId int64, seenSince time.Time) ([]*UserSessionEntity, error) {
+	sessions := []*UserSessionEntity{}
+	sql := `SELECT ` + userSessionColumns + `
+	FROM user_sessions
+	WHERE user_id = $1 AND revoked_at IS NULL AND is_archived = false AND last_seen_at >= $2
+	ORDER BY last_seen_at DESC`
+	err := r.db.DB.Select(&sessions, sql, userId, seenSince.UTC())
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchSession records that a session was just used, and from where. The time is set in UTC from here rather
+// than with NOW(), which would use the database's time zone, because it is compared with UTC times in Go.
+func (r *TokenRepository) TouchSession(id int64, client *models.UserSessionClientDTO) error {
+	sql := `UPDATE user_sessions
+		SET last_seen_at = $4,
+			ip_address = COALESCE(NULLIF($2, ''), ip_address),
+			user_agent = COALESCE(NULLIF($3, ''), user_agent)
+		WHERE id = $1`
+	_, err := r.db.DB.Exec(sql, id, client.IPAddress, client.UserAgent, time.Now().UTC())
+	return err
+}
+
+// RevokeSession logs a session out and revokes its refresh tokens.
+func (r *TokenRepository) RevokeSession(sessionKey string) error {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	sql := `UPDATE user_sessions SET revoked_at = NOW(), modified_at = NOW() WHERE session_key = $1 AND revoked_at IS NULL`
+	_, err = tx.Exec(sql, sessionKey)
+	if err != nil {
+		return err
+	}
+
+	sql = `UPDATE user_refresh_tokens SET revoked_at = NOW(), modified_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err = tx.Exec(sql, sessionKey)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RevokeUserSessions logs every one of the user's sessions out and revokes all of their refresh tokens.
+func (r *TokenRepository) RevokeUserSessions(userId int64) error {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	sql := `UPDATE user_sessions SET revoked_at = NOW(), modified_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	_, err = tx.Exec(sql, userId)
+	if err != nil {
+		return err
+	}
+
+	sql = `UPDATE user_refresh_tokens SET revoked_at = NOW(), modified_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	_, err = tx.Exec(sql, userId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Refresh token methods
+
+func (r *TokenRepository) GetRefreshTokenByHash(tokenHash string) (*RefreshTokenEntity, error) {
+	token := &RefreshTokenEntity{}
+	sql := `SELECT id, created_at, modified_at, is_archived, user_id, family_id, token_hash, expires_at, used_at, revoked_at
+	FROM user_refresh_tokens
+	WHERE token_hash = $1`
+	err := r.db.DB.Get(token, sql, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// RotateRefreshToken marks a refresh token as used and adds the next token in its family. It returns
+// sql.ErrNoRows and adds nothing when the token has already been used or revoked, which happens when
+// the same token is presented twice at once.
+func (r *TokenRepository) RotateRefreshToken(id int64, tokenHash string, expiresAt time.Time) (*RefreshTokenEntity, error) {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var previous struct {
+		UserID   int64  `db:"user_id"`
+		FamilyID string `db:"family_id"`
+	}
+	sql := `UPDATE user_refresh_tokens SET used_at = NOW(), modified_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+		RETURNING user_id, family_id`
+	err = tx.Get(&previous, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &RefreshTokenEntity{}
+	sql = `INSERT INTO user_refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, modified_at, is_archived, user_id, family_id, token_hash, expires_at, used_at, revoked_at`
+	err = tx.Get(token, sql, previous.UserID, previous.FamilyID, tokenHash, expiresAt.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// One-time token methods
+
+// ConsumeOneTimeToken records that a one-time token has been used. It returns sql.ErrNoRows when the
+// token was already used.
+func (r *TokenRepository) ConsumeOneTimeToken(tokenId string, userId int64, purpose string, expiresAt time.Time) error {
+	var id int64
+	sql := `INSERT INTO used_one_time_tokens (token_id, user_id, purpose, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (token_id) DO NOTHING
+		RETURNING id`
+	return r.db.DB.Get(&id, sql, tokenId, userId, purpose, expiresAt.UTC())
+}
@@ -3,14 +3,20 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/snowlynxsoftware/oto-api/server/database"
 	"github.com/snowlynxsoftware/oto-api/server/models"
 )
 
+// ErrQuestionNotPublishable is returned when an edit asks to publish a question that is not approved,
+// or to publish one while changing its content, which has to be reviewed first.
+var ErrQuestionNotPublishable = errors.New("only approved questions can be published, and edits have to be reviewed before they are published")
+
 type TriviaQuestionEntity struct {
 	ID            int64          `json:"id" db:"id"`
 	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
@@ -20,6 +26,16 @@ type TriviaQuestionEntity struct {
 	Question      string         `json:"question" db:"question"`
 	CorrectAnswer string         `json:"correct_answer" db:"correct_answer"`
 	Tags          pq.StringArray `json:"tags" db:"tags"`
+
+	// Only loaded for the admin question list
+	Rating        *float64 `json:"rating,omitempty" db:"rating"`
+	RatedAttempts *int     `json:"rated_attempts,omitempty" db:"rated_attempts"`
+	Difficulty    *string  `json:"difficulty,omitempty" db:"difficulty"` // easy, medium or hard
+
+	// Only loaded for the admin question views
+	ReviewStatus   string     `json:"review_status,omitempty" db:"review_status"`
+	ReviewerUserID *int64     `json:"reviewer_user_id,omitempty" db:"reviewer_user_id"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
 }
 
 type WrongAnswerPoolEntity struct {
@@ -29,17 +45,56 @@ type WrongAnswerPoolEntity struct {
 	IsArchived bool           `json:"is_archived" db:"is_archived"`
 	AnswerText string         `json:"answer_text" db:"answer_text"`
 	Tags       pq.StringArray `json:"tags" db:"tags"`
+	QuestionID *int64         `json:"question_id" db:"question_id"` // Set when the answer was written for one question
 }
 
 type TriviaDeckEntity struct {
-	ID           int64      `json:"id" db:"id"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	ModifiedAt   *time.Time `json:"modified_at" db:"modified_at"`
-	IsArchived   bool       `json:"is_archived" db:"is_archived"`
-	Name         string     `json:"name" db:"name"`
-	Description  *string    `json:"description" db:"description"`
-	IsApproved   bool       `json:"is_approved" db:"is_approved"`
-	IsSystemDeck bool       `json:"is_system_deck" db:"is_system_deck"`
+	ID           int64                   `json:"id" db:"id"`
+	CreatedAt    time.Time               `json:"created_at" db:"created_at"`
+	ModifiedAt   *time.Time              `json:"modified_at" db:"modified_at"`
+	IsArchived   bool                    `json:"is_archived" db:"is_archived"`
+	Name         string                  `json:"name" db:"name"`
+	Description  *string                 `json:"description" db:"description"`
+	IsApproved   bool                    `json:"is_approved" db:"is_approved"`
+	IsSystemDeck bool                    `json:"is_system_deck" db:"is_system_deck"`
+	Rules        *models.TriviaDeckRules `json:"rules" db:"rules"` // Set when this is a smart deck
+}
+
+// TriviaQuestionRevisionEntity is a version of a question as it was before an edit replaced it.
+type TriviaQuestionRevisionEntity struct {
+	ID             int64          `json:"id" db:"id"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"` // When the edit that replaced this version was made
+	ModifiedAt     *time.Time     `json:"modified_at" db:"modified_at"`
+	IsArchived     bool           `json:"is_archived" db:"is_archived"`
+	QuestionID     int64          `json:"question_id" db:"question_id"`
+	RevisionNumber int            `json:"revision_number" db:"revision_number"`
+	Question       string         `json:"question" db:"question"`
+	CorrectAnswer  string         `json:"correct_answer" db:"correct_answer"`
+	Tags           pq.StringArray `json:"tags" db:"tags"`
+	IsPublished    bool           `json:"is_published" db:"is_published"`
+	EditedByUserID *int64         `json:"edited_by_user_id" db:"edited_by_user_id"`
+	EditedByName   *string        `json:"edited_by_name" db:"edited_by_name"`
+}
+
+// TriviaQuestionReviewEntity is a review status change or a reviewer comment on a question.
+type TriviaQuestionReviewEntity struct {
+	ID         int64      `json:"id" db:"id"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived bool       `json:"is_archived" db:"is_archived"`
+	QuestionID int64      `json:"question_id" db:"question_id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	UserName   *string    `json:"user_name" db:"user_name"`
+	FromStatus string     `json:"from_status" db:"from_status"`
+	ToStatus   string     `json:"to_status" db:"to_status"` // The same as from_status for a comment
+	Comment    *string    `json:"comment" db:"comment"`
+}
+
+// TriviaDeckQuestionEntity is a question as it appears inside of a deck.
+type TriviaDeckQuestionEntity struct {
+	TriviaQuestionEntity
+	Position int       `json:"position" db:"position"`
+	AddedAt  time.Time `json:"added_at" db:"added_at"`
 }
 
 type ITriviaRepository interface {
@@ -47,20 +102,50 @@ type ITriviaRepository interface {
 	ImportTriviaQuestions(data []models.TriviaQuestionImportData) (*models.TriviaQuestionImportResults, error)
 	GetWrongAnswerByText(answer string) (*WrongAnswerPoolEntity, error)
 	ImportWrongAnswers(data []models.TriviaWrongAnswerImportData) (*models.TriviaWrongAnswerImportResults, error)
+	GetExistingQuestionTexts(questions []string) ([]string, error)
+	GetExistingWrongAnswerTexts(answers []string) ([]string, error)
 	GetTriviaDeckById(deckId int64) (*TriviaDeckEntity, error)
+	GetSystemDeckByName(name string) (*TriviaDeckEntity, error)
 	CreateNewTriviaDeck(name string, description string, isSystemDeck bool) (*TriviaDeckEntity, error)
+	CreateApprovedSystemSmartDeck(name string, description string, rules *models.TriviaDeckRules) (*TriviaDeckEntity, error)
 	UpdateTriviaDeckMetadata(deckId int64, name string, description string) (*TriviaDeckEntity, error)
 	UpdateTriviaDeckApprovalStatus(deckId int64, isApproved bool) (*TriviaDeckEntity, error)
 	UpdateTriviaDeckArchivalStatus(deckId int64, isArchived bool) (*TriviaDeckEntity, error)
+	GetTriviaDecksCount(searchString, statusFilter string) (*int, error)
+	GetTriviaDecks(pageSize, offset int, searchString, statusFilter string) ([]*TriviaDeckEntity, error)
+	UpdateTriviaDeckRules(deckId int64, rules *models.TriviaDeckRules) (*TriviaDeckEntity, error)
+
+	// Smart deck methods
+	GetQuestionsMatchingDeckRulesCount(rules *models.TriviaDeckRules, statusFilter string) (*int, error)
+	GetQuestionsMatchingDeckRules(rules *models.TriviaDeckRules, pageSize, offset int, statusFilter string) ([]*TriviaQuestionEntity, error)
+	GetDeckRuleQuestionCounts(rules *models.TriviaDeckRules) (*models.TriviaDeckQuestionCounts, error)
+
+	// Deck membership methods
+	AddQuestionsToDeck(deckId int64, questionIds []int64) (int64, error)
+	RemoveQuestionsFromDeck(deckId int64, questionIds []int64) (int64, error)
+	ReorderDeckQuestions(deckId int64, questionIds []int64) error
+	GetDeckQuestionsCount(deckId int64, statusFilter string) (*int, error)
+	GetDeckQuestions(deckId int64, pageSize, offset int, statusFilter string) ([]*TriviaDeckQuestionEntity, error)
+	GetDeckQuestionCounts(deckId int64) (*models.TriviaDeckQuestionCounts, error)
 
 	// New CRUD methods for questions
-	GetQuestionsCount(searchString, statusFilter, tagFilter string) (*int, error)
-	GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter string) ([]*TriviaQuestionEntity, error)
+	GetQuestionsCount(searchString, statusFilter, tagFilter, difficultyFilter string) (*int, error)
+	GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter, difficultyFilter, sortBy string) ([]*TriviaQuestionEntity, error)
 	GetQuestionById(id int64) (*TriviaQuestionEntity, error)
 	CreateQuestion(dto *models.TriviaQuestionCreateDTO) (*TriviaQuestionEntity, error)
-	UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64) (*TriviaQuestionEntity, error)
+	UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64, editorUserId int64) (*TriviaQuestionEntity, error)
 	ToggleQuestionArchived(id int64) error
-	ToggleQuestionPublished(id int64) error
+
+	// Question review methods
+	UpdateQuestionReviewStatus(id int64, fromStatuses []string, toStatus string, reviewerUserId *int64, userId int64, comment *string) (*TriviaQuestionEntity, error)
+	CreateQuestionReviewComment(id int64, userId int64, comment string) (*TriviaQuestionReviewEntity, error)
+	GetQuestionReviews(questionId int64) ([]*TriviaQuestionReviewEntity, error)
+	GetReviewQueueCount(statuses []string, reviewerUserId *int64) (*int, error)
+	GetReviewQueue(pageSize, offset int, statuses []string, reviewerUserId *int64) ([]*TriviaQuestionEntity, error)
+
+	// Question revision methods
+	GetQuestionRevisions(questionId int64) ([]*TriviaQuestionRevisionEntity, error)
+	GetQuestionRevisionById(questionId int64, revisionId int64) (*TriviaQuestionRevisionEntity, error)
 
 	// New CRUD methods for wrong answers
 	GetWrongAnswersCount(searchString, statusFilter, tagFilter string) (*int, error)
@@ -69,6 +154,10 @@ type ITriviaRepository interface {
 	CreateWrongAnswer(dto *models.WrongAnswerCreateDTO) (*WrongAnswerPoolEntity, error)
 	UpdateWrongAnswer(dto *models.WrongAnswerUpdateDTO, id int64) (*WrongAnswerPoolEntity, error)
 	ToggleWrongAnswerArchived(id int64) error
+
+	// Multiple choice methods
+	GetRandomWrongAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error)
+	GetRandomCorrectAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error)
 }
 
 type TriviaRepository struct {
@@ -114,14 +203,26 @@ func (r *TriviaRepository) ImportTriviaQuestions(data []models.TriviaQuestionImp
 
 		if existingQuestion == nil || existingQuestion.ID == 0 {
 
-			sql := `INSERT INTO trivia_questions (question, correct_answer, tags)
-				VALUES ($1, $2, $3) RETURNING id`
-			err := r.db.DB.QueryRow(sql, question.Question, question.CorrectAnswer, pq.Array(question.Tags)).Scan(&question.ID)
+			// The question and its own wrong answers are added in one statement so neither is saved without the other
+			var wrongAnswersAdded int64
+			sql := `WITH question AS (
+					INSERT INTO trivia_questions (question, correct_answer, tags)
+					VALUES ($1, $2, $3) RETURNING id
+				), wrong_answers AS (
+					INSERT INTO wrong_answer_pool (answer_text, tags, question_id)
+					SELECT answers.answer_text, $3, question.id
+					FROM question, unnest($4::text[]) AS answers(answer_text)
+					ON CONFLICT (question_id, answer_text) WHERE question_id IS NOT NULL DO NOTHING
+					RETURNING id
+				)
+				SELECT question.id, (SELECT COUNT(*) FROM wrong_answers) FROM question`
+			err := r.db.DB.QueryRow(sql, question.Question, question.CorrectAnswer, pq.Array(question.Tags), pq.Array(questionData.WrongAnswers)).Scan(&question.ID, &wrongAnswersAdded)
 			if err != nil {
 				return nil, err
 			}
 
 			results.QuestionsAdded++
+			results.WrongAnswersAdded += wrongAnswersAdded
 		}
 	}
 
@@ -131,9 +232,9 @@ func (r *TriviaRepository) ImportTriviaQuestions(data []models.TriviaQuestionImp
 func (r *TriviaRepository) GetWrongAnswerByText(answer string) (*WrongAnswerPoolEntity, error) {
 	entity := WrongAnswerPoolEntity{}
 	sql := `SELECT
-		id, created_at, modified_at, is_archived, answer_text, tags
+		id, created_at, modified_at, is_archived, answer_text, tags, question_id
 	FROM wrong_answer_pool
-	WHERE answer_text = $1`
+	WHERE answer_text = $1 AND question_id IS NULL`
 	err := r.db.DB.Get(&entity, sql, answer)
 	if err != nil {
 		return nil, err
@@ -155,9 +256,9 @@ func (r *TriviaRepository) ImportWrongAnswers(data []models.TriviaWrongAnswerImp
 			Tags:       answerData.Tags,
 		}
 
-		existingQuestion, _ := r.GetTriviaQuestionByText(answer.AnswerText)
+		existingAnswer, _ := r.GetWrongAnswerByText(answer.AnswerText)
 
-		if existingQuestion == nil || existingQuestion.ID == 0 {
+		if existingAnswer == nil || existingAnswer.ID == 0 {
 
 			sql := `INSERT INTO wrong_answer_pool (answer_text, tags)
 				VALUES ($1, $2) RETURNING id`
@@ -173,10 +274,32 @@ func (r *TriviaRepository) ImportWrongAnswers(data []models.TriviaWrongAnswerImp
 	return results, nil
 }
 
+// GetExistingQuestionTexts returns which of the given question texts are already in the database.
+func (r *TriviaRepository) GetExistingQuestionTexts(questions []string) ([]string, error) {
+	existing := []string{}
+	sql := `SELECT DISTINCT question FROM trivia_questions WHERE question = ANY($1)`
+	err := r.db.DB.Select(&existing, sql, pq.Array(questions))
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// GetExistingWrongAnswerTexts returns which of the given answer texts are already shared in the wrong answer pool.
+func (r *TriviaRepository) GetExistingWrongAnswerTexts(answers []string) ([]string, error) {
+	existing := []string{}
+	sql := `SELECT DISTINCT answer_text FROM wrong_answer_pool WHERE answer_text = ANY($1) AND question_id IS NULL`
+	err := r.db.DB.Select(&existing, sql, pq.Array(answers))
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
 func (r *TriviaRepository) GetTriviaDeckById(deckId int64) (*TriviaDeckEntity, error) {
 	deckEntity := TriviaDeckEntity{}
 	sql := `SELECT
-		id, created_at, modified_at, is_archived, name, description, is_approved, is_system_deck
+		id, created_at, modified_at, is_archived, name, description, is_approved, is_system_deck, rules
 	FROM trivia_decks
 	WHERE id = $1`
 	err := r.db.DB.Get(&deckEntity, sql, deckId)
@@ -186,6 +309,22 @@ func (r *TriviaRepository) GetTriviaDeckById(deckId int64) (*TriviaDeckEntity, e
 	return &deckEntity, nil
 }
 
+// GetSystemDeckByName returns the oldest live system deck with the given name.
+func (r *TriviaRepository) GetSystemDeckByName(name string) (*TriviaDeckEntity, error) {
+	deckEntity := TriviaDeckEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, name, description, is_approved, is_system_deck, rules
+	FROM trivia_decks
+	WHERE name = $1 AND is_system_deck = true AND is_archived = false
+	ORDER BY id ASC
+	LIMIT 1`
+	err := r.db.DB.Get(&deckEntity, sql, name)
+	if err != nil {
+		return nil, err
+	}
+	return &deckEntity, nil
+}
+
 func (r *TriviaRepository) CreateNewTriviaDeck(name string, description string, isSystemDeck bool) (*TriviaDeckEntity, error) {
 	deck := &TriviaDeckEntity{
 		CreatedAt:    time.Now(),
@@ -206,6 +345,20 @@ func (r *TriviaRepository) CreateNewTriviaDeck(name string, description string,
 	return deck, nil
 }
 
+// CreateApprovedSystemSmartDeck creates an approved system smart deck in a single statement,
+// so the deck is never visible without its rules or approval.
+func (r *TriviaRepository) CreateApprovedSystemSmartDeck(name string, description string, rules *models.TriviaDeckRules) (*TriviaDeckEntity, error) {
+	deckEntity := TriviaDeckEntity{}
+	sql := `INSERT INTO trivia_decks (name, description, is_system_deck, is_approved, rules)
+			VALUES ($1, $2, true, true, $3)
+			RETURNING id, created_at, modified_at, is_archived, name, description, is_approved, is_system_deck, rules`
+	err := r.db.DB.Get(&deckEntity, sql, name, description, rules)
+	if err != nil {
+		return nil, err
+	}
+	return &deckEntity, nil
+}
+
 func (r *TriviaRepository) UpdateTriviaDeckMetadata(deckId int64, name string, description string) (*TriviaDeckEntity, error) {
 	deck, err := r.GetTriviaDeckById(deckId)
 	if err != nil {
@@ -264,10 +417,353 @@ func (r *TriviaRepository) UpdateTriviaDeckArchivalStatus(deckId int64, isArchiv
 	return deck, nil
 }
 
+func (r *TriviaRepository) GetTriviaDecksCount(searchString, statusFilter string) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count FROM trivia_decks WHERE 1=1`
+
+	// Build dynamic WHERE clause
+	args := []interface{}{}
+	argIndex := 1
+
+	// Search filter
+	if searchString != "" {
+		sql += ` AND (name ILIKE '%' || $` + fmt.Sprintf("%d", argIndex) + ` || '%' OR description ILIKE '%' || $` + fmt.Sprintf("%d", argIndex) + ` || '%')`
+		args = append(args, searchString)
+		argIndex++
+	}
+
+	// Status filter
+	sql += deckStatusFilterClause(statusFilter)
+
+	err := r.db.DB.Get(&count, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+func (r *TriviaRepository) GetTriviaDecks(pageSize, offset int, searchString, statusFilter string) ([]*TriviaDeckEntity, error) {
+	decks := []*TriviaDeckEntity{}
+	sql := `SELECT id, created_at, modified_at, is_archived, name, description, is_approved, is_system_deck, rules FROM trivia_decks WHERE 1=1`
+
+	// Build dynamic WHERE clause
+	args := []interface{}{pageSize, offset}
+	argIndex := 3
+
+	// Search filter
+	if searchString != "" {
+		sql += ` AND (name ILIKE '%' || $` + fmt.Sprintf("%d", argIndex) + ` || '%' OR description ILIKE '%' || $` + fmt.Sprintf("%d", argIndex) + ` || '%')`
+		args = append(args, searchString)
+		argIndex++
+	}
+
+	// Status filter
+	sql += deckStatusFilterClause(statusFilter)
+
+	sql += ` ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	err := r.db.DB.Select(&decks, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return decks, nil
+}
+
+func (r *TriviaRepository) UpdateTriviaDeckRules(deckId int64, rules *models.TriviaDeckRules) (*TriviaDeckEntity, error) {
+	deck, err := r.GetTriviaDeckById(deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	deck.Rules = rules
+
+	sql := `UPDATE trivia_decks SET rules = $1, modified_at = NOW()
+			WHERE id = $2 RETURNING modified_at`
+	err = r.db.DB.QueryRow(sql, deck.Rules, deckId).Scan(&deck.ModifiedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// Smart deck methods
+func (r *TriviaRepository) GetQuestionsMatchingDeckRulesCount(rules *models.TriviaDeckRules, statusFilter string) (*int, error) {
+	count := new(int)
+	where, args := deckRulesWhereClause(rules, 1)
+	sql := `SELECT COUNT(*) as count FROM trivia_questions q WHERE 1=1` + where
+	sql += deckQuestionStatusFilterClause(statusFilter)
+
+	err := r.db.DB.Get(&count, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+func (r *TriviaRepository) GetQuestionsMatchingDeckRules(rules *models.TriviaDeckRules, pageSize, offset int, statusFilter string) ([]*TriviaQuestionEntity, error) {
+	questions := []*TriviaQuestionEntity{}
+	where, ruleArgs := deckRulesWhereClause(rules, 3)
+	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags FROM trivia_questions q WHERE 1=1` + where
+	sql += deckQuestionStatusFilterClause(statusFilter)
+	sql += ` ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	args := append([]interface{}{pageSize, offset}, ruleArgs...)
+	err := r.db.DB.Select(&questions, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (r *TriviaRepository) GetDeckRuleQuestionCounts(rules *models.TriviaDeckRules) (*models.TriviaDeckQuestionCounts, error) {
+	counts := &models.TriviaDeckQuestionCounts{}
+	where, args := deckRulesWhereClause(rules, 1)
+	sql := `SELECT
+		COUNT(*) AS total,
+		COUNT(*) FILTER (WHERE is_published = true) AS published,
+		COUNT(*) FILTER (WHERE is_published = false) AS unpublished,
+		0 AS archived
+	FROM trivia_questions
+	WHERE 1=1` + where
+	err := r.db.DB.Get(counts, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// deckRulesWhereClause builds the conditions a question must meet to match a smart deck.
+// Placeholders are numbered from argIndex and the matching arguments are returned in order.
+func deckRulesWhereClause(rules *models.TriviaDeckRules, argIndex int) (string, []interface{}) {
+	// Archived questions never match a deck
+	sql := ` AND is_archived = false`
+	args := []interface{}{}
+
+	if len(rules.IncludeTags) > 0 {
+		sql += ` AND tags && $` + fmt.Sprintf("%d", argIndex)
+		args = append(args, pq.Array(rules.IncludeTags))
+		argIndex++
+	}
+
+	if len(rules.ExcludeTags) > 0 {
+		sql += ` AND NOT (tags && $` + fmt.Sprintf("%d", argIndex) + `)`
+		args = append(args, pq.Array(rules.ExcludeTags))
+		argIndex++
+	}
+
+	if rules.PublishedOnly {
+		sql += ` AND is_published = true`
+	}
+
+	if rules.MinAgeDays != nil {
+		sql += ` AND created_at <= NOW() - make_interval(days => $` + fmt.Sprintf("%d", argIndex) + `)`
+		args = append(args, *rules.MinAgeDays)
+		argIndex++
+	}
+
+	if rules.MaxAgeDays != nil {
+		sql += ` AND created_at >= NOW() - make_interval(days => $` + fmt.Sprintf("%d", argIndex) + `)`
+		args = append(args, *rules.MaxAgeDays)
+		argIndex++
+	}
+
+	return sql, args
+}
+
+// deckStatusFilterClause maps a deck status filter onto its SQL condition.
+// Unknown filters are ignored so that every deck is returned.
+func deckStatusFilterClause(statusFilter string) string {
+	switch statusFilter {
+	case "active":
+		return ` AND is_archived = false AND is_approved = true`
+	case "archived":
+		return ` AND is_archived = true`
+	case "approved":
+		return ` AND is_approved = true`
+	case "unapproved":
+		return ` AND is_approved = false`
+	case "system":
+		return ` AND is_system_deck = true`
+	}
+	return ""
+}
+
+// Deck membership methods
+func (r *TriviaRepository) AddQuestionsToDeck(deckId int64, questionIds []int64) (int64, error) {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	err = lockTriviaDeck(tx, deckId)
+	if err != nil {
+		return 0, err
+	}
+
+	// New questions are appended to the end of the deck in the order they were given.
+	// Unknown question IDs are ignored and questions already in the deck keep their position.
+	sql := `INSERT INTO trivia_deck_questions (deck_id, question_id, position)
+		SELECT $1, q.id,
+			COALESCE((SELECT MAX(position) FROM trivia_deck_questions WHERE deck_id = $1), 0) + ROW_NUMBER() OVER (ORDER BY ids.ord)
+		FROM unnest($2::int[]) WITH ORDINALITY AS ids(question_id, ord)
+		JOIN trivia_questions q ON q.id = ids.question_id
+		ON CONFLICT (deck_id, question_id) DO NOTHING`
+	result, err := tx.Exec(sql, deckId, pq.Array(questionIds))
+	if err != nil {
+		return 0, err
+	}
+	added, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return added, tx.Commit()
+}
+
+func (r *TriviaRepository) RemoveQuestionsFromDeck(deckId int64, questionIds []int64) (int64, error) {
+	sql := `DELETE FROM trivia_deck_questions WHERE deck_id = $1 AND question_id = ANY($2)`
+	result, err := r.db.DB.Exec(sql, deckId, pq.Array(questionIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ReorderDeckQuestions sets the position of every question in a deck to its place in questionIds.
+// It returns sql.ErrNoRows and changes nothing when questionIds is not every question in the deck
+// exactly once.
+func (r *TriviaRepository) ReorderDeckQuestions(deckId int64, questionIds []int64) error {
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the deck so its questions cannot change between the check and the update
+	err = lockTriviaDeck(tx, deckId)
+	if err != nil {
+		return err
+	}
+
+	// The check only returns a row when the new order holds every question in the deck exactly once
+	var isComplete bool
+	sql := `SELECT true
+		WHERE (SELECT array_agg(question_id ORDER BY question_id) FROM trivia_deck_questions WHERE deck_id = $1)
+			IS NOT DISTINCT FROM (SELECT array_agg(id ORDER BY id) FROM unnest($2::int[]) AS ids(id))`
+	err = tx.Get(&isComplete, sql, deckId, pq.Array(questionIds))
+	if err != nil {
+		return err
+	}
+
+	sql = `UPDATE trivia_deck_questions dq
+		SET position = ids.ord, modified_at = NOW()
+		FROM unnest($2::int[]) WITH ORDINALITY AS ids(question_id, ord)
+		WHERE dq.deck_id = $1 AND dq.question_id = ids.question_id`
+	_, err = tx.Exec(sql, deckId, pq.Array(questionIds))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockTriviaDeck locks a deck row until the transaction ends, so changes to the deck's questions
+// made under the lock are applied one at a time. It returns sql.ErrNoRows when the deck does not exist.
+func lockTriviaDeck(tx *sqlx.Tx, deckId int64) error {
+	var id int64
+	return tx.Get(&id, `SELECT id FROM trivia_decks WHERE id = $1 FOR UPDATE`, deckId)
+}
+
+func (r *TriviaRepository) GetDeckQuestionsCount(deckId int64, statusFilter string) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count
+		FROM trivia_deck_questions dq
+		JOIN trivia_questions q ON q.id = dq.question_id
+		WHERE dq.deck_id = $1`
+
+	sql += deckQuestionStatusFilterClause(statusFilter)
+
+	err := r.db.DB.Get(&count, sql, deckId)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+func (r *TriviaRepository) GetDeckQuestions(deckId int64, pageSize, offset int, statusFilter string) ([]*TriviaDeckQuestionEntity, error) {
+	questions := []*TriviaDeckQuestionEntity{}
+	sql := `SELECT
+		q.id, q.created_at, q.modified_at, q.is_archived, q.is_published, q.question, q.correct_answer, q.tags,
+		dq.position, dq.created_at AS added_at
+	FROM trivia_deck_questions dq
+	JOIN trivia_questions q ON q.id = dq.question_id
+	WHERE dq.deck_id = $3`
+
+	sql += deckQuestionStatusFilterClause(statusFilter)
+
+	sql += ` ORDER BY dq.position ASC, dq.id ASC LIMIT $1 OFFSET $2`
+
+	err := r.db.DB.Select(&questions, sql, pageSize, offset, deckId)
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (r *TriviaRepository) GetDeckQuestionCounts(deckId int64) (*models.TriviaDeckQuestionCounts, error) {
+	counts := &models.TriviaDeckQuestionCounts{DeckID: deckId}
+	sql := `SELECT
+		COUNT(*) AS total,
+		COUNT(*) FILTER (WHERE q.is_published = true AND q.is_archived = false) AS published,
+		COUNT(*) FILTER (WHERE q.is_published = false AND q.is_archived = false) AS unpublished,
+		COUNT(*) FILTER (WHERE q.is_archived = true) AS archived
+	FROM trivia_deck_questions dq
+	JOIN trivia_questions q ON q.id = dq.question_id
+	WHERE dq.deck_id = $1`
+	err := r.db.DB.Get(counts, sql, deckId)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// deckQuestionStatusFilterClause maps a question status filter onto its SQL
+// condition for queries that alias trivia_questions as q.
+func deckQuestionStatusFilterClause(statusFilter string) string {
+	switch statusFilter {
+	case "active":
+		return ` AND q.is_archived = false AND q.is_published = true`
+	case "archived":
+		return ` AND q.is_archived = true`
+	case "published":
+		return ` AND q.is_published = true`
+	case "unpublished":
+		return ` AND q.is_published = false`
+	}
+	return ""
+}
+
 // Question CRUD methods
-func (r *TriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilter string) (*int, error) {
+// ratedQuestionsSQL is every question together with its skill rating and the difficulty band that rating
+// falls in. Questions that have never been answered are rated at the starting rating.
+var ratedQuestionsSQL = fmt.Sprintf(`(SELECT
+		q.id, q.created_at, q.modified_at, q.is_archived, q.is_published, q.question, q.correct_answer, q.tags,
+		q.review_status, q.reviewer_user_id, q.submitted_at,
+		COALESCE(qr.rating, %[1]v) AS rating,
+		COALESCE(qr.rated_attempts, 0) AS rated_attempts,
+		CASE
+			WHEN COALESCE(qr.rating, %[1]v) < %[2]v THEN '%[4]v'
+			WHEN COALESCE(qr.rating, %[1]v) >= %[3]v THEN '%[6]v'
+			ELSE '%[5]v'
+		END AS difficulty
+	FROM trivia_questions q
+	LEFT JOIN question_ratings qr ON qr.question_id = q.id) rated_questions`,
+	models.DefaultSkillRating, models.EasyQuestionMaxRating, models.HardQuestionMinRating,
+	models.QuestionDifficultyEasy, models.QuestionDifficultyMedium, models.QuestionDifficultyHard)
+
+func (r *TriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilter, difficultyFilter string) (*int, error) {
 	count := new(int)
-	sql := `SELECT COUNT(*) as count FROM trivia_questions WHERE 1=1`
+	sql := `SELECT COUNT(*) as count FROM ` + ratedQuestionsSQL + ` WHERE 1=1`
 
 	// Build dynamic WHERE clause
 	args := []interface{}{}
@@ -306,6 +802,14 @@ func (r *TriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilt
 		argIndex++
 	}
 
+	// Difficulty filter
+	switch difficultyFilter {
+	case models.QuestionDifficultyEasy, models.QuestionDifficultyMedium, models.QuestionDifficultyHard:
+		sql += ` AND difficulty = $` + fmt.Sprintf("%d", argIndex)
+		args = append(args, difficultyFilter)
+		argIndex++
+	}
+
 	err := r.db.DB.Get(&count, sql, args...)
 	if err != nil {
 		return nil, err
@@ -313,9 +817,11 @@ func (r *TriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilt
 	return count, nil
 }
 
-func (r *TriviaRepository) GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter string) ([]*TriviaQuestionEntity, error) {
+func (r *TriviaRepository) GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter, difficultyFilter, sortBy string) ([]*TriviaQuestionEntity, error) {
 	questions := []*TriviaQuestionEntity{}
-	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags FROM trivia_questions WHERE 1=1`
+	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags, rating, rated_attempts, difficulty,
+		review_status, reviewer_user_id, submitted_at
+	FROM ` + ratedQuestionsSQL + ` WHERE 1=1`
 
 	// Build dynamic WHERE clause
 	args := []interface{}{pageSize, offset}
@@ -354,7 +860,23 @@ func (r *TriviaRepository) GetQuestions(pageSize, offset int, searchString, stat
 		argIndex++
 	}
 
-	sql += ` ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	// Difficulty filter
+	switch difficultyFilter {
+	case models.QuestionDifficultyEasy, models.QuestionDifficultyMedium, models.QuestionDifficultyHard:
+		sql += ` AND difficulty = $` + fmt.Sprintf("%d", argIndex)
+		args = append(args, difficultyFilter)
+		argIndex++
+	}
+
+	switch sortBy {
+	case "difficulty_asc":
+		sql += ` ORDER BY rating ASC, created_at DESC`
+	case "difficulty_desc":
+		sql += ` ORDER BY rating DESC, created_at DESC`
+	default:
+		sql += ` ORDER BY created_at DESC`
+	}
+	sql += ` LIMIT $1 OFFSET $2`
 
 	err := r.db.DB.Select(&questions, sql, args...)
 	if err != nil {
@@ -365,7 +887,8 @@ func (r *TriviaRepository) GetQuestions(pageSize, offset int, searchString, stat
 
 func (r *TriviaRepository) GetQuestionById(id int64) (*TriviaQuestionEntity, error) {
 	question := &TriviaQuestionEntity{}
-	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags FROM trivia_questions WHERE id = $1`
+	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags, review_status, reviewer_user_id, submitted_at
+	FROM trivia_questions WHERE id = $1`
 	err := r.db.DB.Get(question, sql, id)
 	if err != nil {
 		return nil, err
@@ -404,7 +927,13 @@ func (r *TriviaRepository) CreateQuestion(dto *models.TriviaQuestionCreateDTO) (
 	return question, nil
 }
 
-func (r *TriviaRepository) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64) (*TriviaQuestionEntity, error) {
+// UpdateQuestion saves an edit to a question. The version being replaced is kept as a revision
+// credited to the editor, unless the edit does not change anything. Changing is_published moves
+// an approved question to published or a published one back to approved, and any other change
+// returns ErrQuestionNotPublishable. Changing the question text, correct answer or tags of an
+// approved or published question unpublishes it and sends it back to draft, so it has to be
+// reviewed again. Status changes are recorded in the question's review history.
+func (r *TriviaRepository) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64, editorUserId int64) (*TriviaQuestionEntity, error) {
 	// Check if question exists
 	existingQuestion, err := r.GetQuestionById(id)
 	if err != nil {
@@ -425,8 +954,75 @@ func (r *TriviaRepository) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, i
 		tags[i] = strings.ToLower(strings.TrimSpace(tag))
 	}
 
-	sql := `UPDATE trivia_questions SET question = $1, correct_answer = $2, tags = $3, is_published = $4, modified_at = NOW() WHERE id = $5`
-	_, err = r.db.DB.Exec(sql, dto.Question, dto.CorrectAnswer, pq.Array(tags), dto.IsPublished, id)
+	tx, err := r.db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Lock the question so concurrent edits each keep the version they replaced
+	current := &TriviaQuestionEntity{}
+	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags, review_status FROM trivia_questions WHERE id = $1 FOR UPDATE`
+	err = tx.Get(current, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contentChanged := current.Question != dto.Question || current.CorrectAnswer != dto.CorrectAnswer || !slices.Equal(current.Tags, tags)
+	reviewStatus := current.ReviewStatus
+	var reviewComment *string
+	if dto.IsPublished != (current.ReviewStatus == models.QuestionReviewPublished) {
+		switch {
+		case dto.IsPublished && current.ReviewStatus == models.QuestionReviewApproved && !current.IsArchived && !contentChanged:
+			reviewStatus = models.QuestionReviewPublished
+		case !dto.IsPublished && current.ReviewStatus == models.QuestionReviewPublished:
+			reviewStatus = models.QuestionReviewApproved
+		default:
+			return nil, ErrQuestionNotPublishable
+		}
+	}
+	if contentChanged && (current.ReviewStatus == models.QuestionReviewApproved || current.ReviewStatus == models.QuestionReviewPublished) {
+		reviewStatus = models.QuestionReviewDraft
+		comment := "The question was edited after it was approved, so it needs to be reviewed again"
+		reviewComment = &comment
+	}
+	isPublished := reviewStatus == models.QuestionReviewPublished
+
+	if contentChanged || current.IsPublished != isPublished {
+		sql = `INSERT INTO trivia_question_revisions
+				(question_id, revision_number, question, correct_answer, tags, is_published, edited_by_user_id)
+			VALUES ($1, COALESCE((SELECT MAX(revision_number) FROM trivia_question_revisions WHERE question_id = $1), 0) + 1,
+				$2, $3, $4, $5, $6)`
+		_, err = tx.Exec(sql, id, current.Question, current.CorrectAnswer, pq.Array(current.Tags), current.IsPublished, editorUserId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reviewStatus != current.ReviewStatus {
+		sql = `INSERT INTO trivia_question_reviews (question_id, user_id, from_status, to_status, comment) VALUES ($1, $2, $3, $4, $5)`
+		_, err = tx.Exec(sql, id, editorUserId, current.ReviewStatus, reviewStatus, reviewComment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sql = `UPDATE trivia_questions SET
+		question = $1,
+		correct_answer = $2,
+		tags = $3,
+		is_published = $4,
+		review_status = $5,
+		reviewer_user_id = CASE WHEN $6 THEN NULL ELSE reviewer_user_id END,
+		modified_at = NOW()
+	WHERE id = $7`
+	clearReviewer := reviewStatus == models.QuestionReviewDraft && current.ReviewStatus != models.QuestionReviewDraft
+	_, err = tx.Exec(sql, dto.Question, dto.CorrectAnswer, pq.Array(tags), isPublished, reviewStatus, clearReviewer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -441,9 +1037,14 @@ func (r *TriviaRepository) ToggleQuestionArchived(id int64) error {
 		return err
 	}
 
-	// If archiving, also unpublish
+	// If archiving, also unpublish, which leaves a published question approved
 	if !question.IsArchived {
-		sql := `UPDATE trivia_questions SET is_archived = NOT is_archived, is_published = false, modified_at = NOW() WHERE id = $1`
+		sql := `UPDATE trivia_questions SET
+			is_archived = NOT is_archived,
+			is_published = false,
+			review_status = CASE WHEN review_status = 'published' THEN 'approved' ELSE review_status END,
+			modified_at = NOW()
+		WHERE id = $1`
 		_, err = r.db.DB.Exec(sql, id)
 	} else {
 		// If unarchiving, keep unpublished (manual publish required)
@@ -454,21 +1055,143 @@ func (r *TriviaRepository) ToggleQuestionArchived(id int64) error {
 	return err
 }
 
-func (r *TriviaRepository) ToggleQuestionPublished(id int64) error {
-	// Get current question to check if archived
-	question, err := r.GetQuestionById(id)
+// Question review methods
+
+// UpdateQuestionReviewStatus moves a question to a new review status, as long as it is still in one of
+// fromStatuses, and records the change. The reviewer is set to reviewerUserId, and is_published follows the
+// published status. It returns sql.ErrNoRows when the question is archived or no longer in fromStatuses.
+func (r *TriviaRepository) UpdateQuestionReviewStatus(id int64, fromStatuses []string, toStatus string, reviewerUserId *int64, userId int64, comment *string) (*TriviaQuestionEntity, error) {
+	tx, err := r.db.DB.Beginx()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var fromStatus string
+	sql := `UPDATE trivia_questions q SET
+		review_status = $3,
+		is_published = ($3 = 'published'),
+		reviewer_user_id = $4,
+		submitted_at = CASE WHEN $3 = 'submitted' THEN NOW() ELSE q.submitted_at END,
+		modified_at = NOW()
+	FROM (SELECT id, review_status FROM trivia_questions WHERE id = $1 FOR UPDATE) previous
+	WHERE q.id = previous.id AND q.is_archived = false AND previous.review_status = ANY($2)
+	RETURNING previous.review_status`
+	err = tx.Get(&fromStatus, sql, id, pq.Array(fromStatuses), toStatus, reviewerUserId)
+	if err != nil {
+		return nil, err
 	}
 
-	// Cannot publish/unpublish archived questions
-	if question.IsArchived {
-		return errors.New("cannot change published status of archived question")
+	sql = `INSERT INTO trivia_question_reviews (question_id, user_id, from_status, to_status, comment) VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.Exec(sql, id, userId, fromStatus, toStatus, comment)
+	if err != nil {
+		return nil, err
 	}
 
-	sql := `UPDATE trivia_questions SET is_published = NOT is_published, modified_at = NOW() WHERE id = $1`
-	_, err = r.db.DB.Exec(sql, id)
-	return err
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetQuestionById(id)
+}
+
+func (r *TriviaRepository) CreateQuestionReviewComment(id int64, userId int64, comment string) (*TriviaQuestionReviewEntity, error) {
+	review := &TriviaQuestionReviewEntity{}
+	sql := `INSERT INTO trivia_question_reviews (question_id, user_id, from_status, to_status, comment)
+		SELECT id, $2, review_status, review_status, $3 FROM trivia_questions WHERE id = $1
+		RETURNING id, created_at, modified_at, is_archived, question_id, user_id, from_status, to_status, comment`
+	err := r.db.DB.Get(review, sql, id, userId, comment)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// GetQuestionReviews returns a question's review history, oldest first.
+func (r *TriviaRepository) GetQuestionReviews(questionId int64) ([]*TriviaQuestionReviewEntity, error) {
+	reviews := []*TriviaQuestionReviewEntity{}
+	sql := `SELECT
+		rv.id, rv.created_at, rv.modified_at, rv.is_archived, rv.question_id, rv.user_id, u.display_name AS user_name,
+		rv.from_status, rv.to_status, rv.comment
+	FROM trivia_question_reviews rv
+	LEFT JOIN users u ON u.id = rv.user_id
+	WHERE rv.question_id = $1 AND rv.is_archived = false
+	ORDER BY rv.created_at ASC, rv.id ASC`
+	err := r.db.DB.Select(&reviews, sql, questionId)
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// reviewQueueWhereClause limits questions to the given review statuses and, when one is given, to a reviewer.
+func reviewQueueWhereClause(statuses []string, reviewerUserId *int64) (string, []interface{}) {
+	where := ` WHERE is_archived = false AND review_status = ANY($1)`
+	args := []interface{}{pq.Array(statuses)}
+	if reviewerUserId != nil {
+		where += ` AND reviewer_user_id = $2`
+		args = append(args, *reviewerUserId)
+	}
+	return where, args
+}
+
+func (r *TriviaRepository) GetReviewQueueCount(statuses []string, reviewerUserId *int64) (*int, error) {
+	count := new(int)
+	where, args := reviewQueueWhereClause(statuses, reviewerUserId)
+	sql := `SELECT COUNT(*) as count FROM trivia_questions` + where
+	err := r.db.DB.Get(count, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// GetReviewQueue returns questions waiting on a review, longest waiting first.
+func (r *TriviaRepository) GetReviewQueue(pageSize, offset int, statuses []string, reviewerUserId *int64) ([]*TriviaQuestionEntity, error) {
+	questions := []*TriviaQuestionEntity{}
+	where, args := reviewQueueWhereClause(statuses, reviewerUserId)
+	sql := `SELECT id, created_at, modified_at, is_archived, is_published, question, correct_answer, tags, review_status, reviewer_user_id, submitted_at
+	FROM trivia_questions` + where + fmt.Sprintf(`
+	ORDER BY submitted_at ASC NULLS LAST, id ASC
+	LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, pageSize, offset)
+	err := r.db.DB.Select(&questions, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// Question revision methods
+func (r *TriviaRepository) GetQuestionRevisions(questionId int64) ([]*TriviaQuestionRevisionEntity, error) {
+	revisions := []*TriviaQuestionRevisionEntity{}
+	sql := `SELECT
+		rv.id, rv.created_at, rv.modified_at, rv.is_archived, rv.question_id, rv.revision_number, rv.question, rv.correct_answer,
+		rv.tags, rv.is_published, rv.edited_by_user_id, u.display_name AS edited_by_name
+	FROM trivia_question_revisions rv
+	LEFT JOIN users u ON u.id = rv.edited_by_user_id
+	WHERE rv.question_id = $1 AND rv.is_archived = false
+	ORDER BY rv.revision_number DESC`
+	err := r.db.DB.Select(&revisions, sql, questionId)
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *TriviaRepository) GetQuestionRevisionById(questionId int64, revisionId int64) (*TriviaQuestionRevisionEntity, error) {
+	revision := &TriviaQuestionRevisionEntity{}
+	sql := `SELECT
+		rv.id, rv.created_at, rv.modified_at, rv.is_archived, rv.question_id, rv.revision_number, rv.question, rv.correct_answer,
+		rv.tags, rv.is_published, rv.edited_by_user_id, u.display_name AS edited_by_name
+	FROM trivia_question_revisions rv
+	LEFT JOIN users u ON u.id = rv.edited_by_user_id
+	WHERE rv.question_id = $1 AND rv.id = $2 AND rv.is_archived = false`
+	err := r.db.DB.Get(revision, sql, questionId, revisionId)
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
 }
 
 // Wrong Answer CRUD methods
@@ -518,7 +1241,7 @@ func (r *TriviaRepository) GetWrongAnswersCount(searchString, statusFilter, tagF
 
 func (r *TriviaRepository) GetWrongAnswers(pageSize, offset int, searchString, statusFilter, tagFilter string) ([]*WrongAnswerPoolEntity, error) {
 	answers := []*WrongAnswerPoolEntity{}
-	sql := `SELECT id, created_at, modified_at, is_archived, answer_text, tags FROM wrong_answer_pool WHERE 1=1`
+	sql := `SELECT id, created_at, modified_at, is_archived, answer_text, tags, question_id FROM wrong_answer_pool WHERE 1=1`
 
 	// Build dynamic WHERE clause
 	args := []interface{}{pageSize, offset}
@@ -564,7 +1287,7 @@ func (r *TriviaRepository) GetWrongAnswers(pageSize, offset int, searchString, s
 
 func (r *TriviaRepository) GetWrongAnswerById(id int64) (*WrongAnswerPoolEntity, error) {
 	answer := &WrongAnswerPoolEntity{}
-	sql := `SELECT id, created_at, modified_at, is_archived, answer_text, tags FROM wrong_answer_pool WHERE id = $1`
+	sql := `SELECT id, created_at, modified_at, is_archived, answer_text, tags, question_id FROM wrong_answer_pool WHERE id = $1`
 	err := r.db.DB.Get(answer, sql, id)
 	if err != nil {
 		return nil, err
@@ -636,3 +1359,43 @@ func (r *TriviaRepository) ToggleWrongAnswerArchived(id int64) error {
 	_, err := r.db.DB.Exec(sql, id)
 	return err
 }
+
+// Multiple choice methods
+
+// GetRandomWrongAnswersByTags picks distinct wrong answers written for a question, followed by shared
+// wrong answers that have at least one tag in common with it.
+func (r *TriviaRepository) GetRandomWrongAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error) {
+	answers := []string{}
+	sql := `SELECT answer_text FROM (
+			SELECT DISTINCT ON (LOWER(TRIM(answer_text))) answer_text, question_id IS NOT NULL AS is_own
+			FROM wrong_answer_pool
+			WHERE is_archived = false AND LOWER(TRIM(answer_text)) <> LOWER(TRIM($3))
+				AND (question_id = $2 OR (question_id IS NULL AND tags && $1))
+			ORDER BY LOWER(TRIM(answer_text)), question_id IS NOT NULL DESC
+		) candidates
+		ORDER BY is_own DESC, RANDOM()
+		LIMIT $4`
+	err := r.db.DB.Select(&answers, sql, pq.Array(tags), questionId, correctAnswer, limit)
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// GetRandomCorrectAnswersByTags picks distinct correct answers from other published questions that
+// share at least one tag with a question. They are used as distractors when the wrong answer pool is thin.
+func (r *TriviaRepository) GetRandomCorrectAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error) {
+	answers := []string{}
+	sql := `SELECT correct_answer FROM (
+			SELECT DISTINCT ON (LOWER(TRIM(correct_answer))) correct_answer
+			FROM trivia_questions
+			WHERE is_archived = false AND is_published = true AND id <> $2 AND tags && $1 AND LOWER(TRIM(correct_answer)) <> LOWER(TRIM($3))
+		) candidates
+		ORDER BY RANDOM()
+		LIMIT $4`
+	err := r.db.DB.Select(&answers, sql, pq.Array(tags), questionId, correctAnswer, limit)
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
@@ -23,6 +23,8 @@ type UserEntity struct {
 	LastLogin    *time.Time `json:"last_login" db:"last_login"`
 	IsBanned     bool       `json:"is_banned" db:"is_banned"`
 	BanReason    *string    `json:"ban_reason" db:"ban_reason"`
+
+	PasswordChangedAt *time.Time `json:"-" db:"password_changed_at"`
 }
 
 var (
@@ -31,6 +33,29 @@ var (
 	UserTypePlayer  = "player"
 )
 
+type UserLoginHistoryEntity struct {
+	ID            int64      `json:"id" db:"id"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ModifiedAt    *time.Time `json:"modified_at" db:"modified_at"`
+	IsArchived    bool       `json:"is_archived" db:"is_archived"`
+	UserID        *int64     `json:"user_id" db:"user_id"`
+	Email         *string    `json:"email" db:"email"`
+	Method        *string    `json:"method" db:"method"`
+	Outcome       *string    `json:"outcome" db:"outcome"`
+	FailureReason *string    `json:"failure_reason" db:"failure_reason"`
+	IPAddress     *string    `json:"ip_address" db:"ip_address"`
+	UserAgent     *string    `json:"user_agent" db:"user_agent"`
+}
+
+var (
+	LoginMethodPassword  = "password"
+	LoginMethodEmailLink = "email_link"
+	LoginMethodRefresh   = "refresh"
+
+	LoginOutcomeSuccess = "success"
+	LoginOutcomeFailure = "failure"
+)
+
 type IUserRepository interface {
 	GetUsersCount(searchString string, statusFilter string, userTypeFilter string) (*int, error)
 	GetUsers(pageSize int, offset int, searchString string, statusFilter string, userTypeFilter string) ([]*UserEntity, error)
@@ -45,6 +70,11 @@ type IUserRepository interface {
 	UnbanUserById(userId *int) (bool, error)
 	SetUserTypeKey(userId *int, key string) (bool, error)
 	ToggleUserArchived(userId *int) error
+
+	// Login history methods
+	CreateLoginHistory(dto *models.UserLoginHistoryCreateDTO) error
+	GetLoginHistoryCount(userId int64) (*int, error)
+	GetLoginHistory(userId int64, pageSize int, offset int) ([]*UserLoginHistoryEntity, error)
 }
 
 type UserRepository struct {
@@ -225,9 +255,12 @@ func (r *UserRepository) UpdateUserLastLogin(userId *int) (bool, error) {
 	return true, nil
 }
 
+// UpdateUserPassword also records when the password changed, so access tokens issued before then stop working.
+// The time is set in UTC from here rather than with NOW(), which would use the database's time zone, because
+// it is compared with the UTC issue times of tokens.
 func (r *UserRepository) UpdateUserPassword(userId *int, password string) (bool, error) {
-	sql := `UPDATE users SET password_hash = $1 WHERE id = $2;`
-	_, err := r.db.DB.Exec(sql, password, &userId)
+	sql := `UPDATE users SET password_hash = $1, password_changed_at = $3 WHERE id = $2;`
+	_, err := r.db.DB.Exec(sql, password, &userId, time.Now().UTC())
 	if err != nil {
 		return false, err
 	}
@@ -275,3 +308,38 @@ func (r *UserRepository) SetUserTypeKey(userId *int, key string) (bool, error) {
 
 	return true, nil
 }
+
+// Login history methods
+
+func (r *UserRepository) CreateLoginHistory(dto *models.UserLoginHistoryCreateDTO) error {
+	sql := `INSERT INTO user_login_history (user_id, email, method, outcome, failure_reason, ip_address, user_agent)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))`
+	_, err := r.db.DB.Exec(sql, dto.UserID, dto.Email, dto.Method, dto.Outcome, dto.FailureReason, dto.IPAddress, dto.UserAgent)
+	return err
+}
+
+func (r *UserRepository) GetLoginHistoryCount(userId int64) (*int, error) {
+	count := new(int)
+	sql := `SELECT COUNT(*) as count FROM user_login_history WHERE user_id = $1 AND is_archived = false`
+	err := r.db.DB.Get(count, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// GetLoginHistory returns a page of the user's login attempts, newest first.
+func (r *UserRepository) GetLoginHistory(userId int64, pageSize int, offset int) ([]*UserLoginHistoryEntity, error) {
+	history := []*UserLoginHistoryEntity{}
+	sql := `SELECT
+		id, created_at, modified_at, is_archived, user_id, email, method, outcome, failure_reason, ip_address, user_agent
+	FROM user_login_history
+	WHERE user_id = $1 AND is_archived = false
+	ORDER BY created_at DESC, id DESC
+	LIMIT $2 OFFSET $3`
+	err := r.db.DB.Select(&history, sql, userId, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -2,26 +2,38 @@ package middleware
 
 import (
 	"errors"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
 	"github.com/snowlynxsoftware/oto-api/server/services"
 	"github.com/snowlynxsoftware/oto-api/server/util"
 )
 
+// A session's last seen time is only updated when it is at least this old, so that every request does not write to it
+const sessionTouchInterval = 5 * time.Minute
+
 type IAuthMiddleware interface {
 	Authorize(r *http.Request, requiredUserTypeKeys []string) (*AuthorizedUserContext, error)
+	GetSessionClient(r *http.Request) *models.UserSessionClientDTO
 }
 
 type AuthMiddleware struct {
-	userRepository repositories.IUserRepository
-	tokenService   services.ITokenService
+	userRepository  repositories.IUserRepository
+	tokenRepository repositories.ITokenRepository
+	tokenService    services.ITokenService
+	trustedProxies  []*net.IPNet
 }
 
-func NewAuthMiddleware(userRepository repositories.IUserRepository, tokenService services.ITokenService) IAuthMiddleware {
+func NewAuthMiddleware(userRepository repositories.IUserRepository, tokenRepository repositories.ITokenRepository, tokenService services.ITokenService, trustedProxies []*net.IPNet) IAuthMiddleware {
 	return &AuthMiddleware{
-		userRepository: userRepository,
-		tokenService:   tokenService,
+		userRepository:  userRepository,
+		tokenRepository: tokenRepository,
+		tokenService:    tokenService,
+		trustedProxies:  trustedProxies,
 	}
 }
 
@@ -33,6 +45,7 @@ type AuthorizedUserContext struct {
 	Username  string `json:"username"`
 	IsAdmin   bool   `json:"is_admin,omitempty"`   // Optional, only set if the user is an admin
 	IsSupport bool   `json:"is_support,omitempty"` // Optional, only set if the user is a support agent
+	SessionID string `json:"-"`
 }
 
 func (m *AuthMiddleware) Authorize(r *http.Request, requiredUserTypeKeys []string) (*AuthorizedUserContext, error) {
@@ -43,18 +56,34 @@ func (m *AuthMiddleware) Authorize(r *http.Request, requiredUserTypeKeys []strin
 		return nil, errors.New("access token not found in request")
 	}
 
-	userId, err := m.tokenService.ValidateToken(&cookie.Value)
+	claims, err := m.tokenService.ValidateToken(&cookie.Value, services.TokenPurposeAccess)
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		return nil, err
 	}
 
-	userEntity, err := m.userRepository.GetUserById(*userId)
+	session, err := m.tokenRepository.GetSessionByKey(claims.SessionID)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return nil, errors.New("session not found")
+	}
+
+	if session.RevokedAt != nil || session.UserID != int64(claims.UserID) {
+		return nil, errors.New("session has been logged out")
+	}
+
+	userEntity, err := m.userRepository.GetUserById(claims.UserID)
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		return nil, err
 	}
 
+	// Tokens only record the second they were issued at, so one issued in the same second as the change is allowed.
+	// Both times are UTC, since the password change time is written in UTC rather than the database's time zone.
+	if userEntity.PasswordChangedAt != nil && claims.IssuedAt.Before(userEntity.PasswordChangedAt.Truncate(time.Second)) {
+		return nil, errors.New("access token was issued before the password was changed")
+	}
+
 	if userEntity.IsArchived {
 		return nil, errors.New("user is archived")
 	}
@@ -80,12 +109,73 @@ func (m *AuthMiddleware) Authorize(r *http.Request, requiredUserTypeKeys []strin
 		}
 	}
 
+	if time.Since(session.LastSeenAt) >= sessionTouchInterval {
+		err = m.tokenRepository.TouchSession(session.ID, m.GetSessionClient(r))
+		if err != nil {
+			// Not being able to record the last seen time should not fail the request
+			util.LogErrorWithStackTrace(err)
+		}
+	}
+
 	return &AuthorizedUserContext{
 		Id:        int(userEntity.ID),
 		Email:     userEntity.Email,
 		Username:  userEntity.DisplayName,
 		IsAdmin:   userEntity.UserTypeKey == repositories.UserTypeAdmin,
 		IsSupport: userEntity.UserTypeKey == repositories.UserTypeSupport,
+		SessionID: session.SessionKey,
 	}, nil
 
 }
+
+// GetSessionClient describes the client making a request, for recording against its session. The proxy
+// headers can be set by anyone, so they are only read when the request came from a trusted proxy, and
+// X-Forwarded-For is read from the right, skipping the hops added by trusted proxies.
+func (m *AuthMiddleware) GetSessionClient(r *http.Request) *models.UserSessionClientDTO {
+	ipAddress := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ipAddress = host
+	}
+
+	if m.isTrustedProxy(ipAddress) {
+		hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+		forwardedFor := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+			forwardedFor = hop
+			if !m.isTrustedProxy(hop) {
+				break
+			}
+		}
+
+		if forwardedFor != "" {
+			ipAddress = forwardedFor
+		} else if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			ipAddress = realIP
+		}
+	}
+	if len(ipAddress) > 64 {
+		ipAddress = ipAddress[:64]
+	}
+
+	return &models.UserSessionClientDTO{
+		IPAddress: ipAddress,
+		UserAgent: r.UserAgent(),
+	}
+}
+
+func (m *AuthMiddleware) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range m.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	AchievementGamesPlayed  = "games_played"
+	AchievementTotalCorrect = "total_correct"
+	AchievementPerfectGame  = "perfect_game"
+	AchievementAnswerStreak = "answer_streak"
+	AchievementGameScore    = "game_score"
+	AchievementDailyStreak  = "daily_streak"
+)
+
+// AchievementTotals are the bests and totals across a player's games that achievements are checked against.
+type AchievementTotals struct {
+	GamesPlayed      int `json:"games_played" db:"games_played"` // Finished games
+	TotalCorrect     int `json:"total_correct" db:"total_correct"`
+	BestPerfectGame  int `json:"best_perfect_game" db:"best_perfect_game"`
+	BestAnswerStreak int `json:"best_answer_streak" db:"best_answer_streak"`
+	BestGameScore    int `json:"best_game_score" db:"best_game_score"`
+}
+
+// TagCorrectCount is how many questions with a tag a player has answered correctly.
+type TagCorrectCount struct {
+	Tag     string `json:"tag" db:"tag"`
+	Correct int    `json:"correct" db:"correct"`
+}
+
+// AchievementProgress is an achievement together with how close a player is to unlocking it.
+// Progress stops counting at the threshold.
+type AchievementProgress struct {
+	ID           int64      `json:"id"`
+	Key          string     `json:"key"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Icon         *string    `json:"icon"`
+	CriteriaType string     `json:"criteria_type"`
+	Tag          *string    `json:"tag"`
+	Threshold    int        `json:"threshold"`
+	Progress     int        `json:"progress"`
+	IsUnlocked   bool       `json:"is_unlocked"`
+	UnlockedAt   *time.Time `json:"unlocked_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DailyChallengeBoard is the board name reported on daily challenge leaderboards.
+const DailyChallengeBoard = "daily_challenge"
+
+// DailyChallengeStatus is a day's challenge as seen by one player.
+type DailyChallengeStatus struct {
+	Date          time.Time         `json:"date"`
+	DeckID        int64             `json:"deck_id"`
+	QuestionCount int               `json:"question_count"`
+	ResetsAt      time.Time         `json:"resets_at"` // When the next challenge becomes available
+	GameID        *int64            `json:"game_id"`   // Set once the player has started the challenge
+	IsFinished    bool              `json:"is_finished"`
+	Entry         *LeaderboardEntry `json:"entry"`          // The player's place on the day's board, set once they have answered
+	CurrentStreak int               `json:"current_streak"` // Daily challenges played in a row, ending today or yesterday
+}
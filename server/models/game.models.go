@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+type GameStartDTO struct {
+	DeckID           int64 `json:"deck_id"`
+	NumWrongChoices  *int  `json:"num_wrong_choices"`  // Optional, defaults to 3
+	TimeLimitSeconds *int  `json:"time_limit_seconds"` // Optional, defaults to 20
+}
+
+// GameChoice is a single answer option for a question. The ID is derived from the answer
+// text so the same choice keeps its ID every time the question is fetched.
+type GameChoice struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+type GameQuestionDTO struct {
+	GameID          int64        `json:"game_id"`
+	QuestionID      int64        `json:"question_id"`
+	Question        string       `json:"question"`
+	Choices         []GameChoice `json:"choices"`
+	QuestionNumber  int          `json:"question_number"`
+	TotalQuestions  int          `json:"total_questions"`
+	ServedAt        time.Time    `json:"served_at"`
+	DeadlineAt      time.Time    `json:"deadline_at"`
+	TimeRemainingMs int64        `json:"time_remaining_ms"` // Lets clients run the timer without trusting their own clock
+}
+
+type GameAnswerDTO struct {
+	QuestionID int64  `json:"question_id"`
+	ChoiceID   string `json:"choice_id"`
+	Answer     string `json:"answer"` // Optional, used when no choice_id is sent
+}
+
+type GameAnswerResult struct {
+	GameID          int64  `json:"game_id"`
+	QuestionID      int64  `json:"question_id"`
+	IsCorrect       bool   `json:"is_correct"`
+	CorrectChoiceID string `json:"correct_choice_id"`
+	CorrectAnswer   string `json:"correct_answer"`
+	IsTimedOut      bool   `json:"is_timed_out"`
+	ResponseTimeMs  int    `json:"response_time_ms"`
+	Score           int    `json:"score"`
+	Streak          int    `json:"streak"`
+	TotalCorrect    int    `json:"total_correct"`
+	TotalIncorrect  int    `json:"total_incorrect"`
+	TotalScore      int    `json:"total_score"`
+}
+
+type GameSummary struct {
+	GameID                int64                 `json:"game_id"`
+	DeckID                int64                 `json:"deck_id"`
+	StartedAt             time.Time             `json:"started_at"`
+	EndedAt               time.Time             `json:"ended_at"`
+	TotalQuestions        int                   `json:"total_questions"`
+	TotalCorrect          int                   `json:"total_correct"`
+	TotalIncorrect        int                   `json:"total_incorrect"`
+	TotalTimedOut         int                   `json:"total_timed_out"`
+	TotalScore            int                   `json:"total_score"`
+	Accuracy              float64               `json:"accuracy"` // Percentage of questions answered correctly
+	LongestStreak         int                   `json:"longest_streak"`
+	AverageResponseTimeMs int                   `json:"average_response_time_ms"`
+	BestTag               *string               `json:"best_tag"`
+	WorstTag              *string               `json:"worst_tag"`
+	Tags                  []GameTagPerformance  `json:"tags"`
+	Questions             []GameSummaryQuestion `json:"questions"`
+	PlayerComparison      GameComparison        `json:"player_comparison"` // Against the player's other finished games
+	DeckComparison        GameComparison        `json:"deck_comparison"`   // Against every other finished game on the deck
+}
+
+type GameSummaryQuestion struct {
+	QuestionID     int64    `json:"question_id"`
+	Question       string   `json:"question"`
+	PickedAnswer   string   `json:"picked_answer"`
+	CorrectAnswer  string   `json:"correct_answer"`
+	IsCorrect      bool     `json:"is_correct"`
+	IsTimedOut     bool     `json:"is_timed_out"`
+	ResponseTimeMs int      `json:"response_time_ms"`
+	Score          int      `json:"score"`
+	Tags           []string `json:"tags"`
+}
+
+type GameTagPerformance struct {
+	Tag      string  `json:"tag"`
+	Correct  int     `json:"correct"`
+	Total    int     `json:"total"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// GameAverages are the averages over a set of finished games.
+type GameAverages struct {
+	GamesPlayed     int     `json:"games_played" db:"games_played"`
+	AverageScore    float64 `json:"average_score" db:"average_score"`
+	AverageAccuracy float64 `json:"average_accuracy" db:"average_accuracy"`
+}
+
+// GameComparison compares a game with the averages of other games. Differences are
+// positive when the game did better than the average.
+type GameComparison struct {
+	GameAverages
+	ScoreDifference    float64 `json:"score_difference"`
+	AccuracyDifference float64 `json:"accuracy_difference"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	LeaderboardPeriodAllTime = "all_time"
+	LeaderboardPeriodMonthly = "monthly"
+	LeaderboardPeriodWeekly  = "weekly"
+	LeaderboardPeriodDaily   = "daily"
+
+	LeaderboardGlobal     = "global"
+	LeaderboardDeckPrefix = "deck:"
+	LeaderboardTagPrefix  = "tag:"
+)
+
+// LeaderboardPeriods are every period a score is counted in.
+var LeaderboardPeriods = []string{LeaderboardPeriodAllTime, LeaderboardPeriodMonthly, LeaderboardPeriodWeekly, LeaderboardPeriodDaily}
+
+// LeaderboardPeriodStart returns the UTC date the period containing at started on. Weeks
+// start on Monday and the all time period starts on 1970-01-01.
+func LeaderboardPeriodStart(period string, at time.Time) (time.Time, error) {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case LeaderboardPeriodAllTime:
+		return time.Unix(0, 0).UTC(), nil
+	case LeaderboardPeriodMonthly:
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	case LeaderboardPeriodWeekly:
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday), nil
+	case LeaderboardPeriodDaily:
+		return day, nil
+	}
+	return time.Time{}, errors.New("period must be one of all_time, monthly, weekly or daily")
+}
+
+// DeckLeaderboardKey is the board for every game played on a deck.
+func DeckLeaderboardKey(deckId int64) string {
+	return LeaderboardDeckPrefix + strconv.FormatInt(deckId, 10)
+}
+
+// TagLeaderboardKey is the board for every answer to a question with a tag. The tag must already be normalized.
+func TagLeaderboardKey(tag string) string {
+	return LeaderboardTagPrefix + tag
+}
+
+type LeaderboardEntry struct {
+	Rank          int     `json:"rank" db:"rank"` // Players with the same score share a rank
+	UserID        int64   `json:"user_id" db:"user_id"`
+	DisplayName   string  `json:"display_name" db:"display_name"`
+	TotalScore    int64   `json:"total_score" db:"total_score"`
+	TotalCorrect  int     `json:"total_correct" db:"total_correct"`
+	TotalAnswered int     `json:"total_answered" db:"total_answered"`
+	Accuracy      float64 `json:"accuracy" db:"-"` // Percentage of answers that were correct
+}
+
+// Leaderboard is one page of a board for the current period.
+type Leaderboard struct {
+	Board       string             `json:"board"` // global, deck:<id>, tag:<tag> or daily_challenge
+	Period      string             `json:"period"`
+	PeriodStart time.Time          `json:"period_start"`
+	PageSize    int                `json:"page_size"`
+	Page        int                `json:"page"`
+	Total       int                `json:"total"`
+	Results     []LeaderboardEntry `json:"results"`
+}
+
+// LeaderboardStanding is where a player sits on a board, along with the players ranked just
+// above and below them. Entry is nil and Nearby is empty until the player has scored in the period.
+type LeaderboardStanding struct {
+	Board       string             `json:"board"`
+	Period      string             `json:"period"`
+	PeriodStart time.Time          `json:"period_start"`
+	Total       int                `json:"total"`
+	Entry       *LeaderboardEntry  `json:"entry"`
+	Nearby      []LeaderboardEntry `json:"nearby"` // In rank order, including the player
+}
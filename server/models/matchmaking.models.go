@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	MatchmakingStatusQueued  = "queued"
+	MatchmakingStatusMatched = "matched"
+	MatchmakingStatusFailed  = "failed"
+)
+
+// MatchmakingQueueDTO picks what to play. Exactly one of DeckID or Tag is set.
+type MatchmakingQueueDTO struct {
+	DeckID *int64  `json:"deck_id"`
+	Tag    *string `json:"tag"` // Plays the tag's quick play deck
+}
+
+type MatchmakingStatus struct {
+	Status    string    `json:"status"`
+	DeckID    int64     `json:"deck_id"`
+	QueuedAt  time.Time `json:"queued_at"`
+	Position  int       `json:"position,omitempty"`   // Only set while queued, starting at 1
+	QueueSize int       `json:"queue_size,omitempty"` // Only set while queued
+	RoomCode  *string   `json:"room_code"`            // Set once matched
+	Error     *string   `json:"error"`                // Set when a room could not be created for the match
+}
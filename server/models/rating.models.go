@@ -0,0 +1,55 @@
+package models
+
+import "math"
+
+const (
+	DefaultSkillRating = 1200.0 // Every player and question starts here
+
+	// Players and questions move quickly until they have enough answers to be trusted
+	provisionalRatedAttempts = 30
+	provisionalRatingK       = 40.0
+	establishedRatingK       = 16.0
+
+	QuestionDifficultyEasy   = "easy"
+	QuestionDifficultyMedium = "medium"
+	QuestionDifficultyHard   = "hard"
+
+	// Questions rated below EasyQuestionMaxRating are easy and those rated at or above
+	// HardQuestionMinRating are hard. Everything in between, including unrated questions, is medium.
+	EasyQuestionMaxRating = 1100.0
+	HardQuestionMinRating = 1300.0
+)
+
+// SkillRating is an Elo rating together with how many answers it has been built from.
+type SkillRating struct {
+	Rating        float64 `json:"rating" db:"rating"`
+	RatedAttempts int     `json:"rated_attempts" db:"rated_attempts"`
+}
+
+// CalculateEloRatings treats an answer as a match between a player and a question. The player
+// wins when they answer correctly and the question wins otherwise, so questions that beat
+// strong players climb towards hard and questions that weak players get right fall towards easy.
+func CalculateEloRatings(player SkillRating, question SkillRating, isCorrect bool) (SkillRating, SkillRating) {
+	expected := 1 / (1 + math.Pow(10, (question.Rating-player.Rating)/400))
+	actual := 0.0
+	if isCorrect {
+		actual = 1
+	}
+
+	player = SkillRating{
+		Rating:        player.Rating + ratingK(player)*(actual-expected),
+		RatedAttempts: player.RatedAttempts + 1,
+	}
+	question = SkillRating{
+		Rating:        question.Rating - ratingK(question)*(actual-expected),
+		RatedAttempts: question.RatedAttempts + 1,
+	}
+	return player, question
+}
+
+func ratingK(rating SkillRating) float64 {
+	if rating.RatedAttempts < provisionalRatedAttempts {
+		return provisionalRatingK
+	}
+	return establishedRatingK
+}
@@ -0,0 +1,109 @@
+package models
+
+import "time"
+
+// Room events pushed to every participant over the room's WebSocket
+const (
+	RoomEventState        = "room_state"
+	RoomEventCountdown    = "countdown"
+	RoomEventQuestion     = "question"
+	RoomEventAnswerResult = "answer_result" // Only sent to the player who answered
+	RoomEventAnswerReveal = "answer_reveal"
+	RoomEventScoreboard   = "scoreboard"
+	RoomEventGameOver     = "game_over"
+	RoomEventClosed       = "room_closed"
+	RoomEventKicked       = "kicked" // Only sent to the player who was removed
+	RoomEventError        = "error"
+)
+
+// Messages sent by participants over the room's WebSocket
+const (
+	RoomMessageAnswer = "answer"
+	RoomMessageReady  = "ready"
+)
+
+type RoomCreateDTO struct {
+	DeckID           int64 `json:"deck_id"`
+	NumWrongChoices  *int  `json:"num_wrong_choices"`  // Optional, defaults to 3
+	TimeLimitSeconds *int  `json:"time_limit_seconds"` // Optional, defaults to 20
+	QuestionCount    *int  `json:"question_count"`     // Optional, defaults to 10
+	MaxPlayers       *int  `json:"max_players"`        // Optional, defaults to 8
+	AutoStartSeconds *int  `json:"auto_start_seconds"` // Optional, the room starts on its own this long after a second player joins
+}
+
+type RoomReadyDTO struct {
+	IsReady bool `json:"is_ready"`
+}
+
+type RoomKickDTO struct {
+	UserID int64 `json:"user_id"`
+	Ban    bool  `json:"ban"` // Banned players cannot join the room again
+}
+
+type RoomEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+type RoomClientMessage struct {
+	Type       string `json:"type"`
+	QuestionID int64  `json:"question_id"`
+	ChoiceID   string `json:"choice_id"`
+	IsReady    bool   `json:"is_ready"`
+}
+
+type RoomState struct {
+	Code             string            `json:"code"`
+	DeckID           int64             `json:"deck_id"`
+	HostUserID       int64             `json:"host_user_id"`
+	Status           string            `json:"status"`
+	NumWrongChoices  int               `json:"num_wrong_choices"`
+	TimeLimitSeconds int               `json:"time_limit_seconds"`
+	QuestionCount    int               `json:"question_count"`
+	MaxPlayers       int               `json:"max_players"`
+	AutoStartSeconds *int              `json:"auto_start_seconds"`
+	StartsAt         *time.Time        `json:"starts_at"` // Set while the lobby is counting down to an automatic start
+	Participants     []RoomParticipant `json:"participants"`
+}
+
+type RoomParticipant struct {
+	UserID      int64  `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	IsHost      bool   `json:"is_host"`
+	IsReady     bool   `json:"is_ready"`
+	IsConnected bool   `json:"is_connected"`
+}
+
+type RoomCountdown struct {
+	QuestionNumber   int `json:"question_number"`
+	SecondsRemaining int `json:"seconds_remaining"`
+}
+
+type RoomAnswerReveal struct {
+	QuestionID    int64               `json:"question_id"`
+	CorrectAnswer string              `json:"correct_answer"`
+	Results       []RoomAnswerOutcome `json:"results"`
+}
+
+type RoomAnswerOutcome struct {
+	UserID      int64  `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	IsCorrect   bool   `json:"is_correct"`
+	IsTimedOut  bool   `json:"is_timed_out"`
+	Score       int    `json:"score"`
+}
+
+type RoomScoreboard struct {
+	QuestionNumber int                   `json:"question_number"`
+	TotalQuestions int                   `json:"total_questions"`
+	Entries        []RoomScoreboardEntry `json:"entries"`
+}
+
+type RoomScoreboardEntry struct {
+	Rank         int    `json:"rank"`
+	UserID       int64  `json:"user_id"`
+	DisplayName  string `json:"display_name"`
+	GameID       int64  `json:"game_id"`
+	TotalScore   int    `json:"total_score"`
+	TotalCorrect int    `json:"total_correct"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// PlayerStatTotals are a player's totals across every game they have answered a question in.
+type PlayerStatTotals struct {
+	GamesPlayed           int     `json:"games_played" db:"games_played"`
+	GamesFinished         int     `json:"games_finished" db:"games_finished"`
+	TotalAnswered         int     `json:"total_answered" db:"total_answered"`
+	TotalCorrect          int     `json:"total_correct" db:"total_correct"`
+	TotalTimedOut         int     `json:"total_timed_out" db:"total_timed_out"`
+	TotalScore            int64   `json:"total_score" db:"total_score"`
+	BestGameScore         int     `json:"best_game_score" db:"best_game_score"`
+	BestStreak            int     `json:"best_streak" db:"best_streak"`
+	AverageResponseTimeMs float64 `json:"average_response_time_ms" db:"average_response_time_ms"` // Timed out answers are left out
+}
+
+type PlayerTagStats struct {
+	Tag      string  `json:"tag" db:"tag"`
+	Answered int     `json:"answered" db:"answered"`
+	Correct  int     `json:"correct" db:"correct"`
+	Accuracy float64 `json:"accuracy" db:"-"`
+}
+
+type PlayerDeckStats struct {
+	DeckID       int64     `json:"deck_id" db:"deck_id"`
+	DeckName     string    `json:"deck_name" db:"deck_name"`
+	GamesPlayed  int       `json:"games_played" db:"games_played"`
+	BestScore    int       `json:"best_score" db:"best_score"`
+	LastPlayedAt time.Time `json:"last_played_at" db:"last_played_at"`
+}
+
+// PlayerStats is a player's lifetime stats. Tags are ordered by how often the player has
+// answered them and favorite decks by how often they have been played.
+type PlayerStats struct {
+	UserID int64 `json:"user_id"`
+	PlayerStatTotals
+	Rating        SkillRating       `json:"rating"`
+	Accuracy      float64           `json:"accuracy"`
+	AverageScore  float64           `json:"average_score"`
+	Tags          []PlayerTagStats  `json:"tags"`
+	FavoriteDecks []PlayerDeckStats `json:"favorite_decks"`
+}
+
+// PlayerGameHistoryEntry is one game in a player's history, newest first.
+type PlayerGameHistoryEntry struct {
+	GameID         int64      `json:"game_id" db:"game_id"`
+	DeckID         int64      `json:"deck_id" db:"deck_id"`
+	DeckName       string     `json:"deck_name" db:"deck_name"`
+	RoomID         *int64     `json:"room_id" db:"room_id"`
+	StartedAt      time.Time  `json:"started_at" db:"started_at"`
+	EndedAt        *time.Time `json:"ended_at" db:"ended_at"`
+	TotalScore     int        `json:"total_score" db:"total_score"`
+	TotalCorrect   int        `json:"total_correct" db:"total_correct"`
+	TotalIncorrect int        `json:"total_incorrect" db:"total_incorrect"`
+	BestStreak     int        `json:"best_streak" db:"best_streak"`
+	Accuracy       float64    `json:"accuracy" db:"-"`
+}
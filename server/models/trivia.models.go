@@ -1,9 +1,16 @@
 package models
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
 type TriviaQuestionImportData struct {
 	Question      string   `json:"question"`
 	CorrectAnswer string   `json:"correct_answer"`
 	Tags          []string `json:"tags"`
+	WrongAnswers  []string `json:"wrong_answers,omitempty"` // Added to the wrong answer pool for this question only
 }
 
 type TriviaWrongAnswerImportData struct {
@@ -15,6 +22,47 @@ type TriviaWrongAnswerImportData struct {
 type TriviaQuestionImportResults struct {
 	TotalQuestionsProcessed int64 `json:"total_questions_processed"`
 	QuestionsAdded          int64 `json:"questions_added"`
+	WrongAnswersAdded       int64 `json:"wrong_answers_added"`
+}
+
+// OpenTDBQuestion is a question in the Open Trivia DB format. The text fields are usually HTML-entity encoded.
+type OpenTDBQuestion struct {
+	Type             string   `json:"type"` // multiple or boolean
+	Difficulty       string   `json:"difficulty"`
+	Category         string   `json:"category"`
+	Question         string   `json:"question"`
+	CorrectAnswer    string   `json:"correct_answer"`
+	IncorrectAnswers []string `json:"incorrect_answers"`
+}
+
+// OpenTDBImportRequest accepts either a whole Open Trivia DB API response or just its array of results.
+type OpenTDBImportRequest struct {
+	Results []OpenTDBQuestion `json:"results"`
+}
+
+func (r *OpenTDBImportRequest) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.Results); err == nil {
+		return nil
+	}
+
+	var response struct {
+		Results []OpenTDBQuestion `json:"results"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	r.Results = response.Results
+	return nil
+}
+
+// OpenTDBImportResults sums up an Open Trivia DB import. Skipped questions could not be used, and
+// duplicates were already in the database or earlier in the same import.
+type OpenTDBImportResults struct {
+	TotalQuestionsProcessed int64 `json:"total_questions_processed"`
+	QuestionsAdded          int64 `json:"questions_added"`
+	QuestionsSkipped        int64 `json:"questions_skipped"`
+	DuplicateQuestions      int64 `json:"duplicate_questions"`
+	WrongAnswersAdded       int64 `json:"wrong_answers_added"`
 }
 
 type TriviaWrongAnswerImportResults struct {
@@ -22,12 +70,80 @@ type TriviaWrongAnswerImportResults struct {
 	AnswersAdded          int64 `json:"answers_added"`
 }
 
+// TriviaCSVImportOptions controls how a CSV file is read. Columns maps a field (question, correct_answer,
+// answer_text or tags) to the header of the column it is in, for files whose headers are not recognised.
+type TriviaCSVImportOptions struct {
+	DryRun       bool
+	TagDelimiter string
+	Columns      map[string]string
+}
+
+// TriviaCSVImportRowError is a problem with one row of a CSV file. Line is the line of the file the row
+// starts on, counting the header as line 1.
+type TriviaCSVImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// TriviaCSVImportResults sums up a CSV import. Rows with errors are left out and the rest are imported,
+// unless it was a dry run. Rows that are already in the database are skipped.
+type TriviaCSVImportResults struct {
+	DryRun      bool                      `json:"dry_run"`
+	TotalRows   int64                     `json:"total_rows"`
+	ValidRows   int64                     `json:"valid_rows"`
+	RowsAdded   int64                     `json:"rows_added"`
+	RowsSkipped int64                     `json:"rows_skipped"`
+	Errors      []TriviaCSVImportRowError `json:"errors"`
+}
+
+// Formats questions and wrong answers can be exported in.
+const (
+	TriviaExportFormatCSV  = "csv"
+	TriviaExportFormatJSON = "json"
+)
+
 type TriviaDeckMetadataUpdateRequest struct {
 	DeckID      int64  `json:"deck_id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
+type TriviaDeckCreateDTO struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	IsSystemDeck bool   `json:"is_system_deck"`
+}
+
+type TriviaDeckApprovalUpdateDTO struct {
+	IsApproved bool `json:"is_approved"`
+}
+
+type TriviaDeckArchivalUpdateDTO struct {
+	IsArchived bool `json:"is_archived"`
+}
+
+type TriviaDeckQuestionsDTO struct {
+	QuestionIDs []int64 `json:"question_ids"`
+}
+
+type TriviaDeckQuestionsAddResults struct {
+	TotalQuestionsProcessed int64 `json:"total_questions_processed"`
+	QuestionsAdded          int64 `json:"questions_added"`
+}
+
+type TriviaDeckQuestionsRemoveResults struct {
+	TotalQuestionsProcessed int64 `json:"total_questions_processed"`
+	QuestionsRemoved        int64 `json:"questions_removed"`
+}
+
+type TriviaDeckQuestionCounts struct {
+	DeckID      int64 `json:"deck_id" db:"deck_id"`
+	Total       int64 `json:"total" db:"total"`
+	Published   int64 `json:"published" db:"published"`
+	Unpublished int64 `json:"unpublished" db:"unpublished"`
+	Archived    int64 `json:"archived" db:"archived"`
+}
+
 // DTOs for CRUD operations
 type TriviaQuestionCreateDTO struct {
 	Question      string   `json:"question"`
@@ -52,3 +168,73 @@ type WrongAnswerUpdateDTO struct {
 	AnswerText string   `json:"answer_text"`
 	Tags       []string `json:"tags"`
 }
+
+// Question review statuses, in the order a question normally moves through them.
+const (
+	QuestionReviewDraft     = "draft"
+	QuestionReviewSubmitted = "submitted"
+	QuestionReviewInReview  = "in_review"
+	QuestionReviewApproved  = "approved"
+	QuestionReviewRejected  = "rejected"
+	QuestionReviewPublished = "published"
+)
+
+// TriviaQuestionReviewDTO is the body of every review action. ReviewerUserID is only used when
+// assigning a reviewer, and Comment is required when rejecting a question.
+type TriviaQuestionReviewDTO struct {
+	ReviewerUserID *int64 `json:"reviewer_user_id"`
+	Comment        string `json:"comment"`
+}
+
+// TriviaQuestionVersion is the content of a question at one point in its history.
+type TriviaQuestionVersion struct {
+	RevisionID     *int64   `json:"revision_id"`     // Nil for the question as it is now
+	RevisionNumber *int     `json:"revision_number"` // Nil for the question as it is now
+	Question       string   `json:"question"`
+	CorrectAnswer  string   `json:"correct_answer"`
+	Tags           []string `json:"tags"`
+	IsPublished    bool     `json:"is_published"`
+}
+
+type TriviaQuestionFieldChange struct {
+	Field  string `json:"field"` // question, correct_answer, tags or is_published
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// TriviaQuestionRevisionDiff lists what changed going from one version of a question to another.
+type TriviaQuestionRevisionDiff struct {
+	QuestionID  int64                       `json:"question_id"`
+	From        TriviaQuestionVersion       `json:"from"`
+	To          TriviaQuestionVersion       `json:"to"`
+	Changes     []TriviaQuestionFieldChange `json:"changes"`
+	TagsAdded   []string                    `json:"tags_added"`
+	TagsRemoved []string                    `json:"tags_removed"`
+}
+
+// TriviaDeckRules is the saved query behind a smart deck. The questions in a smart deck
+// are resolved against trivia_questions.tags every time the deck is used.
+type TriviaDeckRules struct {
+	IncludeTags   []string `json:"include_tags"`   // A question must have at least one of these tags. Empty matches every tag.
+	ExcludeTags   []string `json:"exclude_tags"`   // A question with any of these tags never matches.
+	PublishedOnly bool     `json:"published_only"` // Only published questions match.
+	MinAgeDays    *int     `json:"min_age_days"`   // A question must be at least this many days old.
+	MaxAgeDays    *int     `json:"max_age_days"`   // A question must be at most this many days old.
+}
+
+func (r TriviaDeckRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *TriviaDeckRules) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("cannot scan %T into TriviaDeckRules", src)
+	}
+	return json.Unmarshal(data, r)
+}
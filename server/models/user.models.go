@@ -8,13 +8,45 @@ type UserCreateDTO struct {
 
 type UserLoginResponseDTO struct {
 	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"` // Only returned to clients that do not keep cookies
+}
+
+// UserRefreshDTO is the optional body of a refresh request, for clients that do not keep the refresh token cookie.
+type UserRefreshDTO struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// UserSessionClientDTO describes the client a session was started or last used from.
+type UserSessionClientDTO struct {
+	Device    string `json:"device"`
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+}
+
+// UserLoginHistoryCreateDTO is a login attempt to record. UserID is nil when the user is not known.
+type UserLoginHistoryCreateDTO struct {
+	UserID        *int64
+	Email         string
+	Method        string
+	Outcome       string
+	FailureReason string
+	IPAddress     string
+	UserAgent     string
+}
+
 type UserUpdatePasswordDTO struct {
 	Password string `json:"password"`
 }
 
+type UserPasswordResetRequestDTO struct {
+	Email string `json:"email"`
+}
+
+type UserPasswordResetConfirmDTO struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
 type UserUpdateDTO struct {
 	Email       string  `json:"email" db:"email"`
 	DisplayName string  `json:"display_name" db:"display_name"`
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+type IAchievementService interface {
+	GetPlayerAchievements(userId int64) ([]models.AchievementProgress, error)
+	EvaluateAchievements(userId int64) ([]models.AchievementProgress, error)
+	HandleGameEnded(game *repositories.TriviaGameInstanceEntity)
+}
+
+type AchievementService struct {
+	achievementRepository repositories.IAchievementRepository
+}
+
+func NewAchievementService(achievementRepository repositories.IAchievementRepository) IAchievementService {
+	return &AchievementService{
+		achievementRepository: achievementRepository,
+	}
+}
+
+// GetPlayerAchievements lists every achievement with the player's progress towards it.
+func (s *AchievementService) GetPlayerAchievements(userId int64) ([]models.AchievementProgress, error) {
+	return s.getProgress(userId)
+}
+
+// EvaluateAchievements unlocks every achievement the player has reached the threshold of
+// and returns the ones that were unlocked by this call.
+func (s *AchievementService) EvaluateAchievements(userId int64) ([]models.AchievementProgress, error) {
+	progress, err := s.getProgress(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := []models.AchievementProgress{}
+	for _, achievement := range progress {
+		if achievement.IsUnlocked || achievement.Progress < achievement.Threshold {
+			continue
+		}
+		userAchievement, err := s.achievementRepository.UnlockAchievement(userId, achievement.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // Unlocked by another game that ended at the same time
+		}
+		if err != nil {
+			return nil, err
+		}
+		achievement.IsUnlocked = true
+		achievement.UnlockedAt = &userAchievement.UnlockedAt
+		unlocked = append(unlocked, achievement)
+	}
+	return unlocked, nil
+}
+
+// HandleGameEnded is registered as a game ended hook so achievements are checked after every game.
+func (s *AchievementService) HandleGameEnded(game *repositories.TriviaGameInstanceEntity) {
+	unlocked, err := s.EvaluateAchievements(game.UserID)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return
+	}
+	for _, achievement := range unlocked {
+		util.LogInfo(fmt.Sprintf("user %d unlocked achievement %v", game.UserID, achievement.Key))
+	}
+}
+
+// getProgress works out the player's progress on every achievement. Only the data the
+// defined achievements need is loaded.
+func (s *AchievementService) getProgress(userId int64) ([]models.AchievementProgress, error) {
+	achievements, err := s.achievementRepository.GetAchievements()
+	if err != nil {
+		return nil, err
+	}
+
+	userAchievements, err := s.achievementRepository.GetUserAchievements(userId)
+	if err != nil {
+		return nil, err
+	}
+	unlockedAt := make(map[int64]time.Time, len(userAchievements))
+	for _, userAchievement := range userAchievements {
+		unlockedAt[userAchievement.AchievementID] = userAchievement.UnlockedAt
+	}
+
+	totals, err := s.achievementRepository.GetAchievementTotals(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	longestDailyStreak := 0
+	for _, achievement := range achievements {
+		if achievement.CriteriaType == models.AchievementTotalCorrect && achievement.Tag != nil {
+			tags = append(tags, normalizeTags([]string{*achievement.Tag})...)
+		}
+		if achievement.CriteriaType == models.AchievementDailyStreak {
+			longestDailyStreak = max(longestDailyStreak, achievement.Threshold)
+		}
+	}
+
+	tagCorrect := map[string]int{}
+	if len(tags) > 0 {
+		counts, err := s.achievementRepository.GetTagCorrectCounts(userId, tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			tagCorrect[count.Tag] = count.Correct
+		}
+	}
+
+	dailyStreak := 0
+	if longestDailyStreak > 0 {
+		days, err := s.achievementRepository.GetRecentPlayDays(userId, longestDailyStreak)
+		if err != nil {
+			return nil, err
+		}
+		dailyStreak = currentDailyStreak(days, time.Now())
+	}
+
+	progress := make([]models.AchievementProgress, len(achievements))
+	for i, achievement := range achievements {
+		value := 0
+		switch achievement.CriteriaType {
+		case models.AchievementGamesPlayed:
+			value = totals.GamesPlayed
+		case models.AchievementTotalCorrect:
+			value = totals.TotalCorrect
+			if achievement.Tag != nil {
+				value = 0
+				if tags := normalizeTags([]string{*achievement.Tag}); len(tags) > 0 {
+					value = tagCorrect[tags[0]]
+				}
+			}
+		case models.AchievementPerfectGame:
+			value = totals.BestPerfectGame
+		case models.AchievementAnswerStreak:
+			value = totals.BestAnswerStreak
+		case models.AchievementGameScore:
+			value = totals.BestGameScore
+		case models.AchievementDailyStreak:
+			value = dailyStreak
+		}
+
+		progress[i] = models.AchievementProgress{
+			ID:           achievement.ID,
+			Key:          achievement.AchievementKey,
+			Name:         achievement.Name,
+			Description:  achievement.Description,
+			Icon:         achievement.Icon,
+			CriteriaType: achievement.CriteriaType,
+			Tag:          achievement.Tag,
+			Threshold:    achievement.Threshold,
+			Progress:     min(value, achievement.Threshold),
+		}
+		if at, ok := unlockedAt[achievement.ID]; ok {
+			progress[i].IsUnlocked = true
+			progress[i].UnlockedAt = &at
+			progress[i].Progress = achievement.Threshold
+		}
+	}
+	return progress, nil
+}
+
+// currentDailyStreak counts the days in a row the player has played, newest first, as long as
+// the run reaches today or yesterday so a streak is not lost before the day is over.
+func currentDailyStreak(days []time.Time, now time.Time) int {
+	today, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, now)
+	expected := today
+	if len(days) > 0 && days[0].UTC().Before(today) {
+		expected = today.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for _, day := range days {
+		if !day.UTC().Equal(expected) {
+			break
+		}
+		streak++
+		expected = expected.AddDate(0, 0, -1)
+	}
+	return streak
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAchievementRepository is a mock implementation of IAchievementRepository
+type MockAchievementRepository struct {
+	mock.Mock
+}
+
+func (m *MockAchievementRepository) GetAchievements() ([]*repositories.AchievementEntity, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.AchievementEntity), args.Error(1)
+}
+
+func (m *MockAchievementRepository) GetUserAchievements(userId int64) ([]*repositories.UserAchievementEntity, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.UserAchievementEntity), args.Error(1)
+}
+
+func (m *MockAchievementRepository) UnlockAchievement(userId int64, achievementId int64) (*repositories.UserAchievementEntity, error) {
+	args := m.Called(userId, achievementId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.UserAchievementEntity), args.Error(1)
+}
+
+func (m *MockAchievementRepository) GetAchievementTotals(userId int64) (*models.AchievementTotals, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AchievementTotals), args.Error(1)
+}
+
+func (m *MockAchievementRepository) GetTagCorrectCounts(userId int64, tags []string) ([]*models.TagCorrectCount, error) {
+	args := m.Called(userId, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TagCorrectCount), args.Error(1)
+}
+
+func (m *MockAchievementRepository) GetRecentPlayDays(userId int64, limit int) ([]time.Time, error) {
+	args := m.Called(userId, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+// utcDaysAgo returns the UTC midnight the given number of days before today.
+func utcDaysAgo(days int) time.Time {
+	today, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, time.Now())
+	return today.AddDate(0, 0, -days)
+}
+
+// ===========================================
+// ACHIEVEMENT PROGRESS TESTS
+// ===========================================
+
+// Test GetPlayerAchievements - Progress For Each Criteria Type
+func TestAchievementService_GetPlayerAchievements_Progress(t *testing.T) {
+	// Arrange
+	mockAchievementRepo := new(MockAchievementRepository)
+	achievementService := NewAchievementService(mockAchievementRepo)
+
+	science := "Science"
+	achievements := []*repositories.AchievementEntity{
+		{ID: 1, AchievementKey: "first_game", CriteriaType: models.AchievementGamesPlayed, Threshold: 1},
+		{ID: 2, AchievementKey: "science_100", CriteriaType: models.AchievementTotalCorrect, Tag: &science, Threshold: 100},
+		{ID: 3, AchievementKey: "perfect_10", CriteriaType: models.AchievementPerfectGame, Threshold: 10},
+		{ID: 4, AchievementKey: "streak_10", CriteriaType: models.AchievementAnswerStreak, Threshold: 10},
+		{ID: 5, AchievementKey: "score_2000", CriteriaType: models.AchievementGameScore, Threshold: 2000},
+		{ID: 6, AchievementKey: "daily_7", CriteriaType: models.AchievementDailyStreak, Threshold: 7},
+	}
+	unlockedAt := time.Now().Add(-time.Hour)
+	mockAchievementRepo.On("GetAchievements").Return(achievements, nil)
+	mockAchievementRepo.On("GetUserAchievements", int64(1)).Return([]*repositories.UserAchievementEntity{{AchievementID: 1, UnlockedAt: unlockedAt}}, nil)
+	mockAchievementRepo.On("GetAchievementTotals", int64(1)).Return(&models.AchievementTotals{
+		GamesPlayed:      3,
+		TotalCorrect:     250,
+		BestPerfectGame:  6,
+		BestAnswerStreak: 12,
+		BestGameScore:    1500,
+	}, nil)
+	mockAchievementRepo.On("GetTagCorrectCounts", int64(1), []string{"science"}).Return([]*models.TagCorrectCount{{Tag: "science", Correct: 40}}, nil)
+	mockAchievementRepo.On("GetRecentPlayDays", int64(1), 7).Return([]time.Time{utcDaysAgo(0), utcDaysAgo(1), utcDaysAgo(3)}, nil)
+
+	// Act
+	result, err := achievementService.GetPlayerAchievements(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 6)
+	assert.True(t, result[0].IsUnlocked)
+	assert.Equal(t, unlockedAt, *result[0].UnlockedAt)
+	assert.Equal(t, 1, result[0].Progress)
+	assert.Equal(t, 40, result[1].Progress)
+	assert.Equal(t, 6, result[2].Progress)
+	assert.Equal(t, 10, result[3].Progress) // Capped at the threshold
+	assert.False(t, result[3].IsUnlocked)
+	assert.Equal(t, 1500, result[4].Progress)
+	assert.Equal(t, 2, result[5].Progress)
+}
+
+// Test EvaluateAchievements - Unlocks Reached Achievements Once
+func TestAchievementService_EvaluateAchievements_Unlocks(t *testing.T) {
+	// Arrange
+	mockAchievementRepo := new(MockAchievementRepository)
+	achievementService := NewAchievementService(mockAchievementRepo)
+
+	science := "Science"
+	achievements := []*repositories.AchievementEntity{
+		{ID: 1, AchievementKey: "first_game", CriteriaType: models.AchievementGamesPlayed, Threshold: 1},
+		{ID: 2, AchievementKey: "science_100", CriteriaType: models.AchievementTotalCorrect, Tag: &science, Threshold: 100},
+		{ID: 3, AchievementKey: "perfect_10", CriteriaType: models.AchievementPerfectGame, Threshold: 10},
+		{ID: 4, AchievementKey: "streak_10", CriteriaType: models.AchievementAnswerStreak, Threshold: 10},
+		{ID: 5, AchievementKey: "score_2000", CriteriaType: models.AchievementGameScore, Threshold: 2000},
+		{ID: 6, AchievementKey: "daily_7", CriteriaType: models.AchievementDailyStreak, Threshold: 7},
+	}
+	mockAchievementRepo.On("GetAchievements").Return(achievements, nil)
+	mockAchievementRepo.On("GetUserAchievements", int64(1)).Return([]*repositories.UserAchievementEntity{{AchievementID: 1}}, nil)
+	mockAchievementRepo.On("GetAchievementTotals", int64(1)).Return(&models.AchievementTotals{
+		GamesPlayed:      1,
+		BestPerfectGame:  10,
+		BestAnswerStreak: 10,
+	}, nil)
+	mockAchievementRepo.On("GetTagCorrectCounts", int64(1), []string{"science"}).Return([]*models.TagCorrectCount{}, nil)
+	mockAchievementRepo.On("GetRecentPlayDays", int64(1), 7).Return([]time.Time{}, nil)
+	mockAchievementRepo.On("UnlockAchievement", int64(1), int64(3)).Return(&repositories.UserAchievementEntity{AchievementID: 3, UnlockedAt: time.Now()}, nil)
+	mockAchievementRepo.On("UnlockAchievement", int64(1), int64(4)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := achievementService.EvaluateAchievements(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "perfect_10", result[0].Key)
+	assert.True(t, result[0].IsUnlocked)
+	mockAchievementRepo.AssertNotCalled(t, "UnlockAchievement", int64(1), int64(1))
+	mockAchievementRepo.AssertNumberOfCalls(t, "UnlockAchievement", 2)
+}
+
+// Test GetPlayerAchievements - Optional Lookups Are Skipped When No Achievement Needs Them
+func TestAchievementService_GetPlayerAchievements_SkipsUnusedLookups(t *testing.T) {
+	// Arrange
+	mockAchievementRepo := new(MockAchievementRepository)
+	achievementService := NewAchievementService(mockAchievementRepo)
+
+	mockAchievementRepo.On("GetAchievements").Return([]*repositories.AchievementEntity{
+		{ID: 1, AchievementKey: "first_game", CriteriaType: models.AchievementGamesPlayed, Threshold: 1},
+	}, nil)
+	mockAchievementRepo.On("GetUserAchievements", int64(1)).Return([]*repositories.UserAchievementEntity{}, nil)
+	mockAchievementRepo.On("GetAchievementTotals", int64(1)).Return(&models.AchievementTotals{}, nil)
+
+	// Act
+	result, err := achievementService.GetPlayerAchievements(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result[0].Progress)
+	mockAchievementRepo.AssertNotCalled(t, "GetTagCorrectCounts", mock.Anything, mock.Anything)
+	mockAchievementRepo.AssertNotCalled(t, "GetRecentPlayDays", mock.Anything, mock.Anything)
+}
+
+// ===========================================
+// DAILY STREAK TESTS
+// ===========================================
+
+// Test currentDailyStreak - Streak Ending Today Or Yesterday Counts
+func TestCurrentDailyStreak(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, time.October, 16, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC) }
+
+	// Act & Assert
+	assert.Equal(t, 3, currentDailyStreak([]time.Time{day(16), day(15), day(14), day(12)}, now))
+	assert.Equal(t, 2, currentDailyStreak([]time.Time{day(15), day(14), day(10)}, now))
+	assert.Equal(t, 0, currentDailyStreak([]time.Time{day(14), day(13)}, now))
+	assert.Equal(t, 0, currentDailyStreak([]time.Time{}, now))
+}
@@ -1,9 +1,14 @@
 package services
 
 import (
+	"crypto/sha256"
+	"database/sql"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
 	"github.com/snowlynxsoftware/oto-api/server/models"
@@ -12,27 +17,74 @@ import (
 
 type IAuthService interface {
 	RegisterNewUser(dto *models.UserCreateDTO) (*repositories.UserEntity, error)
-	Login(authHeaderStr *string) (*models.UserLoginResponseDTO, error)
+	Login(authHeaderStr *string, client *models.UserSessionClientDTO) (*models.UserLoginResponseDTO, error)
 	VerifyNewUser(verificationToken *string) (*int, error)
 	SendLoginEmail(email string) (*repositories.UserEntity, error)
-	LoginWithEmailLink(userId *int) (*models.UserLoginResponseDTO, error)
+	LoginWithEmailLink(loginToken *string, client *models.UserSessionClientDTO) (*models.UserLoginResponseDTO, error)
 	UpdateUserPassword(userId *int, password string) (*int, error)
+
+	// Password reset methods
+	RequestPasswordReset(email string) error
+	ConfirmPasswordReset(resetToken *string, password string) error
+
+	// RefreshLogin exchanges a refresh token for a new access token and refresh token
+	RefreshLogin(refreshToken string, client *models.UserSessionClientDTO) (*models.UserLoginResponseDTO, error)
+
+	// Session methods
+	GetSessions(userId int, currentSessionId string) ([]*repositories.UserSessionEntity, error)
+	Logout(sessionId string) error
+	LogoutWithRefreshToken(refreshToken string) error
+	LogoutEverywhere(userId int) error
+}
+
+var (
+	ErrInvalidRefreshToken = errors.New("the refresh token is not valid")
+	ErrRefreshTokenReused  = errors.New("the refresh token has already been used, so every session from that login was logged out")
+	ErrTokenAlreadyUsed    = errors.New("the link has already been used")
+	ErrInvalidResetToken   = errors.New("the password reset link is not valid or has expired")
+)
+
+// RefreshTokenLifetime is how long a refresh token can be used for. Each refresh issues a new one.
+const RefreshTokenLifetime = refreshTokenExpirationInHours * time.Hour
+
+const maxSessionDeviceLength = 64
+
+// Why a login failed, as recorded in the login history
+const (
+	loginFailureInvalidRequest   = "invalid_request"
+	loginFailureUnknownUser      = "unknown_user"
+	loginFailureInvalidPassword  = "invalid_password"
+	loginFailureInvalidToken     = "invalid_token"
+	loginFailureTokenAlreadyUsed = "token_already_used"
+	loginFailureTokenReused      = "token_reused"
+	loginFailureUserNotAllowed   = "user_not_allowed"
+	loginFailureError            = "error"
+)
+
+// loginAttempt is filled in as a login goes along, and recorded in the login history once it is done.
+type loginAttempt struct {
+	method        string
+	userId        *int64
+	email         string
+	failureReason string
 }
 
 type AuthService struct {
-	userRepository repositories.IUserRepository
-	tokenService   ITokenService
-	cryptoService  ICryptoService
-	emailService   IEmailService
+	userRepository  repositories.IUserRepository
+	tokenRepository repositories.ITokenRepository
+	tokenService    ITokenService
+	cryptoService   ICryptoService
+	emailService    IEmailService
 }
 
 func NewAuthService(
 	userRepository repositories.IUserRepository,
+	tokenRepository repositories.ITokenRepository,
 	tokenService ITokenService,
 	cryptoService ICryptoService,
 	emailService IEmailService,
 ) IAuthService {
-	return &AuthService{userRepository: userRepository, tokenService: tokenService, cryptoService: cryptoService, emailService: emailService}
+	return &AuthService{userRepository: userRepository, tokenRepository: tokenRepository, tokenService: tokenService, cryptoService: cryptoService, emailService: emailService}
 }
 
 func (s *AuthService) RegisterNewUser(dto *models.UserCreateDTO) (*repositories.UserEntity, error) {
@@ -103,9 +155,40 @@ func (s *AuthService) SendLoginEmail(email string) (*repositories.UserEntity, er
 	}
 }
 
-func (s *AuthService) LoginWithEmailLink(userId *int) (*models.UserLoginResponseDTO, error) {
+// LoginWithEmailLink logs in with the token from a login email. The link was sent to the user's email
+// address, so following it also verifies the address. Each link can only be used once.
+func (s *AuthService) LoginWithEmailLink(loginToken *string, client *models.UserSessionClientDTO) (response *models.UserLoginResponseDTO, err error) {
+
+	attempt := &loginAttempt{method: repositories.LoginMethodEmailLink}
+	defer func() { s.recordLogin(attempt, client, err) }()
 
-	accessToken, err := s.tokenService.GenerateAccessToken(*userId)
+	claims, err := s.validateOneTimeToken(loginToken, TokenPurposeLoginWithEmail)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		if errors.Is(err, ErrTokenAlreadyUsed) {
+			attempt.failureReason = loginFailureTokenAlreadyUsed
+			return nil, err
+		}
+		attempt.failureReason = loginFailureInvalidToken
+		return nil, errors.New("the token could not be verified")
+	}
+	userId := &claims.UserID
+	loginUserId := int64(claims.UserID)
+	attempt.userId = &loginUserId
+
+	_, err = s.userRepository.MarkUserVerified(userId)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
+
+	sessionId, err := newTokenID()
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
+
+	accessToken, err := s.tokenService.GenerateAccessToken(*userId, sessionId)
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
 		return nil, errors.New("there was an issue trying to log this user in")
@@ -118,19 +201,31 @@ func (s *AuthService) LoginWithEmailLink(userId *int) (*models.UserLoginResponse
 		return nil, errors.New("there was an issue trying to log this user in")
 	}
 
+	refreshToken, err := s.startSession(*userId, sessionId, client)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
+
 	return &models.UserLoginResponseDTO{
 		AccessToken:  *accessToken,
-		RefreshToken: "",
+		RefreshToken: *refreshToken,
 	}, nil
 }
 
+// VerifyNewUser verifies a user's email address with the token from their verification email. Each
+// verification link can only be used once.
 func (s *AuthService) VerifyNewUser(verificationToken *string) (*int, error) {
 
-	var userId, err = s.tokenService.ValidateToken(verificationToken)
+	claims, err := s.validateOneTimeToken(verificationToken, TokenPurposeVerification)
 	if err != nil {
 		util.LogErrorWithStackTrace(err)
+		if errors.Is(err, ErrTokenAlreadyUsed) {
+			return nil, err
+		}
 		return nil, errors.New("the token could not be verified")
 	}
+	userId := &claims.UserID
 
 	_, err = s.userRepository.MarkUserVerified(userId)
 	if err != nil {
@@ -153,36 +248,152 @@ func (s *AuthService) UpdateUserPassword(userId *int, password string) (*int, er
 		return nil, err
 	}
 
+	// Whoever knew the old password may still be logged in, so every session has to log in again
+	err = s.tokenRepository.RevokeUserSessions(int64(*userId))
+	if err != nil {
+		return nil, err
+	}
+
 	return userId, nil
 }
 
-func (s *AuthService) Login(authHeaderStr *string) (*models.UserLoginResponseDTO, error) {
+// RequestPasswordReset emails a password reset link to the user with the email address. Nothing is sent
+// when there is no such user, or they cannot log in, but no error is returned either, so that the response
+// does not show whether the email address is registered. The email is sent in the background so the
+// response takes as long either way.
+func (s *AuthService) RequestPasswordReset(email string) error {
+
+	user, err := s.userRepository.GetUserByEmail(email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsBanned || user.IsArchived {
+		return nil
+	}
+
+	go s.sendPasswordResetEmail(user)
+	return nil
+}
+
+// sendPasswordResetEmail creates a password reset token for the user and emails them the link. Failures
+// are only logged, since returning them would show that the email address is registered.
+func (s *AuthService) sendPasswordResetEmail(user *repositories.UserEntity) {
+	resetToken, err := s.tokenService.GeneratePasswordResetToken(int(user.ID))
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return
+	}
+
+	var emailOptions = &EmailSendOptions{}
+	emailOptions.FromEmail = "do-not-reply@opentriviaonline.com"
+	emailOptions.ToEmail = user.Email
+	emailOptions.Subject = "Open Trivia Online - Reset Your Password"
+	// TODO: Update this to use the correct URL
+	emailOptions.HTMLContent = s.emailService.GetTemplates().GetPasswordResetEmailTemplate("http://localhost:3000", *resetToken)
+	if !s.emailService.SendEmail(emailOptions) {
+		util.LogErrorWithStackTrace(fmt.Errorf("the password reset email to user (%v) failed to send", user.ID))
+	}
+}
+
+// ConfirmPasswordReset sets a new password with the token from a password reset email. Each link can only
+// be used once, and stops working once the password has been changed. Every session is logged out and the
+// user is emailed to say that their password changed.
+func (s *AuthService) ConfirmPasswordReset(resetToken *string, password string) error {
+
+	claims, err := s.tokenService.ValidateToken(resetToken, TokenPurposePasswordReset)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepository.GetUserById(claims.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsBanned || user.IsArchived {
+		return ErrInvalidResetToken
+	}
+	if user.PasswordChangedAt != nil && claims.IssuedAt.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return ErrInvalidResetToken
+	}
+
+	err = s.consumeOneTimeToken(claims)
+	if err != nil {
+		return err
+	}
+
+	userId := int(user.ID)
+	_, err = s.UpdateUserPassword(&userId, password)
+	if err != nil {
+		return err
+	}
+
+	var emailOptions = &EmailSendOptions{}
+	emailOptions.FromEmail = "do-not-reply@opentriviaonline.com"
+	emailOptions.ToEmail = user.Email
+	emailOptions.Subject = "Open Trivia Online - Your Password Was Changed"
+	emailOptions.HTMLContent = s.emailService.GetTemplates().GetPasswordChangedEmailTemplate()
+	if !s.emailService.SendEmail(emailOptions) {
+		// The password has already been changed, so this is not a reason to fail
+		util.LogErrorWithStackTrace(fmt.Errorf("the password changed email to user (%v) failed to send", user.ID))
+	}
+	return nil
+}
+
+func (s *AuthService) Login(authHeaderStr *string, client *models.UserSessionClientDTO) (response *models.UserLoginResponseDTO, err error) {
+
+	attempt := &loginAttempt{method: repositories.LoginMethodPassword}
+	defer func() { s.recordLogin(attempt, client, err) }()
 
 	encodedCredentials := strings.TrimPrefix(*authHeaderStr, "Basic ")
 	decodedCredentials, err := base64.StdEncoding.DecodeString(encodedCredentials)
 	if err != nil {
+		attempt.failureReason = loginFailureInvalidRequest
 		return nil, errors.New("failed to decode authorization header")
 	}
 
 	credentials := strings.SplitN(string(decodedCredentials), ":", 2)
 	if len(credentials) != 2 {
+		attempt.failureReason = loginFailureInvalidRequest
 		return nil, errors.New("invalid authorization header format")
 	}
 
 	email := credentials[0]
 	password := credentials[1]
+	attempt.email = email
 
 	user, err := s.userRepository.GetUserByEmail(email)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			attempt.failureReason = loginFailureUnknownUser
+		}
 		return nil, errors.New("there was an issue trying to log this user in")
 	}
+	attempt.userId = &user.ID
 
+	if user.PasswordHash == nil {
+		attempt.failureReason = loginFailureInvalidPassword
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
 	isValid, err := s.cryptoService.ValidatePassword(password, *user.PasswordHash)
 	if err != nil || !isValid {
+		attempt.failureReason = loginFailureInvalidPassword
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
+
+	sessionId, err := newTokenID()
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
 		return nil, errors.New("there was an issue trying to log this user in")
 	}
 
-	accessToken, err := s.tokenService.GenerateAccessToken(int(user.ID))
+	accessToken, err := s.tokenService.GenerateAccessToken(int(user.ID), sessionId)
 	if err != nil {
 		return nil, errors.New("there was an issue trying to log this user in")
 	}
@@ -195,8 +406,270 @@ func (s *AuthService) Login(authHeaderStr *string) (*models.UserLoginResponseDTO
 		return nil, errors.New("there was an issue trying to log this user in")
 	}
 
+	refreshToken, err := s.startSession(userId, sessionId, client)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return nil, errors.New("there was an issue trying to log this user in")
+	}
+
 	return &models.UserLoginResponseDTO{
 		AccessToken:  *accessToken,
-		RefreshToken: "",
+		RefreshToken: *refreshToken,
 	}, nil
 }
+
+// RefreshLogin exchanges a refresh token for a new access token and a new refresh token, and the old
+// refresh token cannot be used again. A refresh token that has already been used must have been copied,
+// so the session it belongs to is logged out and the user has to log in again.
+func (s *AuthService) RefreshLogin(refreshToken string, client *models.UserSessionClientDTO) (response *models.UserLoginResponseDTO, err error) {
+
+	attempt := &loginAttempt{method: repositories.LoginMethodRefresh}
+	defer func() { s.recordLogin(attempt, client, err) }()
+
+	_, err = s.tokenService.ValidateToken(&refreshToken, TokenPurposeRefresh)
+	if err != nil {
+		attempt.failureReason = loginFailureInvalidToken
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := s.tokenRepository.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		attempt.failureReason = loginFailureInvalidToken
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	attempt.userId = &stored.UserID
+
+	if stored.RevokedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+		attempt.failureReason = loginFailureInvalidToken
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.UsedAt != nil {
+		attempt.failureReason = loginFailureTokenReused
+		return nil, s.revokeReusedRefreshToken(stored)
+	}
+
+	user, err := s.userRepository.GetUserById(int(stored.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if user.IsArchived || user.IsBanned {
+		attempt.failureReason = loginFailureUserNotAllowed
+		err = s.tokenRepository.RevokeSession(stored.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	userId := int(user.ID)
+	nextRefreshToken, err := s.tokenService.GenerateRefreshToken(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.tokenRepository.RotateRefreshToken(stored.ID, hashRefreshToken(*nextRefreshToken), time.Now().Add(RefreshTokenLifetime))
+	if errors.Is(err, sql.ErrNoRows) {
+		// Another request used the same token first
+		attempt.failureReason = loginFailureTokenReused
+		return nil, s.revokeReusedRefreshToken(stored)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.tokenService.GenerateAccessToken(userId, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.tokenRepository.GetSessionByKey(stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.tokenRepository.TouchSession(session.ID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserLoginResponseDTO{
+		AccessToken:  *accessToken,
+		RefreshToken: *nextRefreshToken,
+	}, nil
+}
+
+// GetSessions returns the user's sessions that are still logged in, flagging the one making the request.
+func (s *AuthService) GetSessions(userId int, currentSessionId string) ([]*repositories.UserSessionEntity, error) {
+	sessions, err := s.tokenRepository.GetActiveSessions(int64(userId), time.Now().Add(-RefreshTokenLifetime))
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.IsCurrent = session.SessionKey == currentSessionId
+	}
+	return sessions, nil
+}
+
+// Logout logs a session out. Its access token stops working straight away and its refresh token cannot be used.
+func (s *AuthService) Logout(sessionId string) error {
+	return s.tokenRepository.RevokeSession(sessionId)
+}
+
+// LogoutWithRefreshToken logs out the session a refresh token belongs to, for when the access token has
+// already expired. Unknown refresh tokens are ignored, as there is nothing to log out.
+func (s *AuthService) LogoutWithRefreshToken(refreshToken string) error {
+	stored, err := s.tokenRepository.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.tokenRepository.RevokeSession(stored.FamilyID)
+}
+
+// LogoutEverywhere logs out every one of the user's sessions, including the one making the request.
+func (s *AuthService) LogoutEverywhere(userId int) error {
+	return s.tokenRepository.RevokeUserSessions(int64(userId))
+}
+
+// recordLogin adds a login attempt to the login history. err is the error the login returned, if it failed.
+// Not being able to record the attempt does not fail the login.
+func (s *AuthService) recordLogin(attempt *loginAttempt, client *models.UserSessionClientDTO, err error) {
+	dto := &models.UserLoginHistoryCreateDTO{
+		UserID:    attempt.userId,
+		Email:     attempt.email,
+		Method:    attempt.method,
+		Outcome:   repositories.LoginOutcomeSuccess,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+	}
+	if err != nil {
+		dto.Outcome = repositories.LoginOutcomeFailure
+		dto.FailureReason = attempt.failureReason
+		if dto.FailureReason == "" {
+			dto.FailureReason = loginFailureError
+		}
+	}
+
+	recordErr := s.userRepository.CreateLoginHistory(dto)
+	if recordErr != nil {
+		util.LogErrorWithStackTrace(recordErr)
+	}
+}
+
+// startSession stores the session for a login along with its first refresh token, which is returned.
+// The session ID is also the refresh token family.
+func (s *AuthService) startSession(userId int, sessionId string, client *models.UserSessionClientDTO) (*string, error) {
+	refreshToken, err := s.tokenService.GenerateRefreshToken(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionClient := *client
+	sessionClient.Device = describeDevice(client.UserAgent)
+	_, err = s.tokenRepository.CreateSession(int64(userId), sessionId, &sessionClient, hashRefreshToken(*refreshToken), time.Now().Add(RefreshTokenLifetime))
+	if err != nil {
+		return nil, err
+	}
+	return refreshToken, nil
+}
+
+func (s *AuthService) revokeReusedRefreshToken(stored *repositories.RefreshTokenEntity) error {
+	util.LogInfo(fmt.Sprintf("Refresh token reuse detected for user (%v), logging out session (%v)", stored.UserID, stored.FamilyID))
+	err := s.tokenRepository.RevokeSession(stored.FamilyID)
+	if err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// validateOneTimeToken validates a token for the purpose and records it as used. It returns
+// ErrTokenAlreadyUsed when the token has been used before.
+func (s *AuthService) validateOneTimeToken(token *string, purpose TokenPurpose) (*TokenClaims, error) {
+	claims, err := s.tokenService.ValidateToken(token, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.consumeOneTimeToken(claims)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// consumeOneTimeToken records a validated one-time token as used. It returns ErrTokenAlreadyUsed when the
+// token has been used before.
+func (s *AuthService) consumeOneTimeToken(claims *TokenClaims) error {
+	err := s.tokenRepository.ConsumeOneTimeToken(claims.TokenID, int64(claims.UserID), string(claims.Purpose), claims.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTokenAlreadyUsed
+	}
+	return err
+}
+
+// hashRefreshToken is how refresh tokens are stored. They are long and random, so a fast hash is enough.
+func hashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(hash[:])
+}
+
+// describeDevice gives a short description of the device a user agent belongs to, such as "Firefox on Windows".
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+
+	platform := ""
+	for _, p := range []struct{ marker, name string }{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, p.marker) {
+			platform = p.name
+			break
+		}
+	}
+
+	// Order matters, as most browsers also claim to be Safari and Chrome based ones claim to be Chrome
+	browser := ""
+	for _, b := range []struct{ marker, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	} {
+		if strings.Contains(userAgent, b.marker) {
+			browser = b.name
+			break
+		}
+	}
+
+	var device string
+	switch {
+	case browser != "" && platform != "":
+		device = browser + " on " + platform
+	case browser != "":
+		device = browser
+	case platform != "":
+		device = platform
+	default:
+		// Not a browser, so use the client's name such as "curl/8.4.0" as it is
+		device = strings.Fields(userAgent)[0]
+	}
+	if len(device) > maxSessionDeviceLength {
+		device = device[:maxSessionDeviceLength]
+	}
+	return device
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"database/sql"
+	"encoding/base64"
 	"errors"
 	"testing"
 	"time"
@@ -16,20 +18,20 @@ type MockTokenService struct {
 	mock.Mock
 }
 
-func (m *MockTokenService) GenerateAccessToken(userID int) (*string, error) {
-	args := m.Called(userID)
+func (m *MockTokenService) GenerateAccessToken(userID int, sessionId string) (*string, error) {
+	args := m.Called(userID, sessionId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*string), args.Error(1)
 }
 
-func (m *MockTokenService) ValidateToken(token *string) (*int, error) {
-	args := m.Called(token)
+func (m *MockTokenService) ValidateToken(token *string, purpose TokenPurpose) (*TokenClaims, error) {
+	args := m.Called(token, purpose)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*int), args.Error(1)
+	return args.Get(0).(*TokenClaims), args.Error(1)
 }
 
 func (m *MockTokenService) GenerateVerificationToken(userID int) (*string, error) {
@@ -64,6 +66,14 @@ func (m *MockTokenService) ValidateLoginWithEmailToken(token *string) (*int, err
 	return args.Get(0).(*int), args.Error(1)
 }
 
+func (m *MockTokenService) GeneratePasswordResetToken(userID int) (*string, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*string), args.Error(1)
+}
+
 func (m *MockTokenService) GenerateRefreshToken(userID int) (*string, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
@@ -72,6 +82,73 @@ func (m *MockTokenService) GenerateRefreshToken(userID int) (*string, error) {
 	return args.Get(0).(*string), args.Error(1)
 }
 
+var testSessionClient = &models.UserSessionClientDTO{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:131.0) Gecko/20100101 Firefox/131.0"}
+
+// MockTokenRepository is a mock implementation of ITokenRepository
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateSession(userId int64, sessionKey string, client *models.UserSessionClientDTO, refreshTokenHash string, refreshExpiresAt time.Time) (*repositories.UserSessionEntity, error) {
+	args := m.Called(userId, sessionKey, client, refreshTokenHash, refreshExpiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.UserSessionEntity), args.Error(1)
+}
+
+func (m *MockTokenRepository) GetSessionByKey(sessionKey string) (*repositories.UserSessionEntity, error) {
+	args := m.Called(sessionKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.UserSessionEntity), args.Error(1)
+}
+
+func (m *MockTokenRepository) GetActiveSessions(userId int64, seenSince time.Time) ([]*repositories.UserSessionEntity, error) {
+	args := m.Called(userId, seenSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.UserSessionEntity), args.Error(1)
+}
+
+func (m *MockTokenRepository) TouchSession(id int64, client *models.UserSessionClientDTO) error {
+	args := m.Called(id, client)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeSession(sessionKey string) error {
+	args := m.Called(sessionKey)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeUserSessions(userId int64) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) ConsumeOneTimeToken(tokenId string, userId int64, purpose string, expiresAt time.Time) error {
+	args := m.Called(tokenId, userId, purpose, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshTokenByHash(tokenHash string) (*repositories.RefreshTokenEntity, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.RefreshTokenEntity), args.Error(1)
+}
+
+func (m *MockTokenRepository) RotateRefreshToken(id int64, tokenHash string, expiresAt time.Time) (*repositories.RefreshTokenEntity, error) {
+	args := m.Called(id, tokenHash, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.RefreshTokenEntity), args.Error(1)
+}
+
 // MockCryptoService is a mock implementation of ICryptoService
 type MockCryptoService struct {
 	mock.Mock
@@ -120,16 +197,27 @@ func (m *MockEmailTemplates) GetLoginEmailTemplate(baseURL string, loginToken st
 	return args.String(0)
 }
 
+func (m *MockEmailTemplates) GetPasswordResetEmailTemplate(baseURL string, resetToken string) string {
+	args := m.Called(baseURL, resetToken)
+	return args.String(0)
+}
+
+func (m *MockEmailTemplates) GetPasswordChangedEmailTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 // Test RegisterNewUser - Success
 func TestAuthService_RegisterNewUser_Success(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 	mockEmailTemplates := new(MockEmailTemplates)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userDTO := &models.UserCreateDTO{
 		Email:       "test@example.com",
@@ -180,11 +268,12 @@ func TestAuthService_RegisterNewUser_Success(t *testing.T) {
 func TestAuthService_RegisterNewUser_UserAlreadyExists(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userDTO := &models.UserCreateDTO{
 		Email:       "existing@example.com",
@@ -213,11 +302,12 @@ func TestAuthService_RegisterNewUser_UserAlreadyExists(t *testing.T) {
 func TestAuthService_RegisterNewUser_PasswordHashingError(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userDTO := &models.UserCreateDTO{
 		Email:       "test@example.com",
@@ -243,12 +333,13 @@ func TestAuthService_RegisterNewUser_PasswordHashingError(t *testing.T) {
 func TestAuthService_RegisterNewUser_EmailSendFailure(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 	mockEmailTemplates := new(MockEmailTemplates)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userDTO := &models.UserCreateDTO{
 		Email:       "test@example.com",
@@ -292,12 +383,13 @@ func TestAuthService_RegisterNewUser_EmailSendFailure(t *testing.T) {
 func TestAuthService_SendLoginEmail_Success(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 	mockEmailTemplates := new(MockEmailTemplates)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	email := "test@example.com"
 	loginToken := "login_token_123"
@@ -334,11 +426,12 @@ func TestAuthService_SendLoginEmail_Success(t *testing.T) {
 func TestAuthService_SendLoginEmail_UserBanned(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	email := "banned@example.com"
 	user := &repositories.UserEntity{
@@ -359,76 +452,113 @@ func TestAuthService_SendLoginEmail_UserBanned(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+// expectLoginHistory expects one login attempt to be recorded with the method, outcome and failure reason.
+func expectLoginHistory(mockUserRepo *MockUserRepository, method string, outcome string, failureReason string) {
+	mockUserRepo.On("CreateLoginHistory", mock.MatchedBy(func(dto *models.UserLoginHistoryCreateDTO) bool {
+		return dto.Method == method && dto.Outcome == outcome && dto.FailureReason == failureReason && dto.IPAddress == testSessionClient.IPAddress
+	})).Return(nil).Once()
+}
+
+// expectLoginWithEmailToken sets up a valid, unused login-with-email token for the user.
+func expectLoginWithEmailToken(mockTokenService *MockTokenService, mockTokenRepo *MockTokenRepository, mockUserRepo *MockUserRepository, loginToken string, userId int) {
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposeLoginWithEmail, TokenID: "jti_123", ExpiresAt: time.Now().Add(10 * time.Minute)}
+	mockTokenService.On("ValidateToken", &loginToken, TokenPurposeLoginWithEmail).Return(claims, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_123", int64(userId), string(TokenPurposeLoginWithEmail), claims.ExpiresAt).Return(nil)
+	mockUserRepo.On("MarkUserVerified", &userId).Return(true, nil)
+}
+
 // Test LoginWithEmailLink - Success
 func TestAuthService_LoginWithEmailLink_Success(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userId := 123
+	loginToken := "login_token_123"
 	accessToken := "access_token_123"
 
-	mockTokenService.On("GenerateAccessToken", userId).Return(&accessToken, nil)
+	refreshToken := "refresh_token_123"
+
+	expectLoginWithEmailToken(mockTokenService, mockTokenRepo, mockUserRepo, loginToken, userId)
+	mockTokenService.On("GenerateAccessToken", userId, mock.AnythingOfType("string")).Return(&accessToken, nil)
 	mockUserRepo.On("UpdateUserLastLogin", &userId).Return(true, nil)
+	mockTokenService.On("GenerateRefreshToken", userId).Return(&refreshToken, nil)
+	isTestClient := mock.MatchedBy(func(client *models.UserSessionClientDTO) bool {
+		return client.Device == "Firefox on Windows" && client.IPAddress == testSessionClient.IPAddress
+	})
+	mockTokenRepo.On("CreateSession", int64(userId), mock.AnythingOfType("string"), isTestClient, hashRefreshToken(refreshToken), mock.AnythingOfType("time.Time")).
+		Return(&repositories.UserSessionEntity{ID: 1, UserID: int64(userId)}, nil)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodEmailLink, repositories.LoginOutcomeSuccess, "")
 
 	// Act
-	result, err := authService.LoginWithEmailLink(&userId)
+	result, err := authService.LoginWithEmailLink(&loginToken, testSessionClient)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, accessToken, result.AccessToken)
-	assert.Equal(t, "", result.RefreshToken)
+	assert.Equal(t, refreshToken, result.RefreshToken)
 	mockTokenService.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 }
 
 // Test LoginWithEmailLink - Token Generation Error
 func TestAuthService_LoginWithEmailLink_TokenGenerationError(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userId := 123
+	loginToken := "login_token_123"
 
-	mockTokenService.On("GenerateAccessToken", userId).Return(nil, errors.New("token generation failed"))
+	expectLoginWithEmailToken(mockTokenService, mockTokenRepo, mockUserRepo, loginToken, userId)
+	mockTokenService.On("GenerateAccessToken", userId, mock.AnythingOfType("string")).Return(nil, errors.New("token generation failed"))
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodEmailLink, repositories.LoginOutcomeFailure, loginFailureError)
 
 	// Act
-	result, err := authService.LoginWithEmailLink(&userId)
+	result, err := authService.LoginWithEmailLink(&loginToken, testSessionClient)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "there was an issue trying to log this user in")
 	mockTokenService.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
 }
 
 // Test LoginWithEmailLink - Last Login Update Error
 func TestAuthService_LoginWithEmailLink_LastLoginUpdateError(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
 	mockTokenService := new(MockTokenService)
 	mockCryptoService := new(MockCryptoService)
 	mockEmailService := new(MockEmailService)
 
-	authService := NewAuthService(mockUserRepo, mockTokenService, mockCryptoService, mockEmailService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
 
 	userId := 123
+	loginToken := "login_token_123"
 	accessToken := "access_token_123"
 
-	mockTokenService.On("GenerateAccessToken", userId).Return(&accessToken, nil)
+	expectLoginWithEmailToken(mockTokenService, mockTokenRepo, mockUserRepo, loginToken, userId)
+	mockTokenService.On("GenerateAccessToken", userId, mock.AnythingOfType("string")).Return(&accessToken, nil)
 	mockUserRepo.On("UpdateUserLastLogin", &userId).Return(false, errors.New("database error"))
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodEmailLink, repositories.LoginOutcomeFailure, loginFailureError)
 
 	// Act
-	result, err := authService.LoginWithEmailLink(&userId)
+	result, err := authService.LoginWithEmailLink(&loginToken, testSessionClient)
 
 	// Assert
 	assert.Error(t, err)
@@ -437,3 +567,670 @@ func TestAuthService_LoginWithEmailLink_LastLoginUpdateError(t *testing.T) {
 	mockTokenService.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 }
+
+// Test LoginWithEmailLink - Link Already Used
+func TestAuthService_LoginWithEmailLink_TokenAlreadyUsed(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	loginToken := "login_token_123"
+	claims := &TokenClaims{UserID: 123, Purpose: TokenPurposeLoginWithEmail, TokenID: "jti_123", ExpiresAt: time.Now().Add(10 * time.Minute)}
+	mockTokenService.On("ValidateToken", &loginToken, TokenPurposeLoginWithEmail).Return(claims, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_123", int64(123), string(TokenPurposeLoginWithEmail), claims.ExpiresAt).Return(sql.ErrNoRows)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodEmailLink, repositories.LoginOutcomeFailure, loginFailureTokenAlreadyUsed)
+
+	// Act
+	result, err := authService.LoginWithEmailLink(&loginToken, testSessionClient)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTokenAlreadyUsed)
+	assert.Nil(t, result)
+	mockUserRepo.AssertNotCalled(t, "MarkUserVerified", mock.Anything)
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test LoginWithEmailLink - Token For Another Purpose
+func TestAuthService_LoginWithEmailLink_InvalidToken(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	loginToken := "verification_token_123"
+	mockTokenService.On("ValidateToken", &loginToken, TokenPurposeLoginWithEmail).Return(nil, errors.New("JWT could not be validated"))
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodEmailLink, repositories.LoginOutcomeFailure, loginFailureInvalidToken)
+
+	// Act
+	result, err := authService.LoginWithEmailLink(&loginToken, testSessionClient)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "the token could not be verified")
+	mockTokenRepo.AssertNotCalled(t, "ConsumeOneTimeToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test VerifyNewUser - Success
+func TestAuthService_VerifyNewUser_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	verificationToken := "verification_token_123"
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposeVerification, TokenID: "jti_456", ExpiresAt: time.Now().Add(3 * time.Hour)}
+	mockTokenService.On("ValidateToken", &verificationToken, TokenPurposeVerification).Return(claims, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_456", int64(userId), string(TokenPurposeVerification), claims.ExpiresAt).Return(nil)
+	mockUserRepo.On("MarkUserVerified", &userId).Return(true, nil)
+
+	// Act
+	result, err := authService.VerifyNewUser(&verificationToken)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, userId, *result)
+	mockTokenRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test VerifyNewUser - Link Already Used
+func TestAuthService_VerifyNewUser_TokenAlreadyUsed(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	verificationToken := "verification_token_123"
+	claims := &TokenClaims{UserID: 123, Purpose: TokenPurposeVerification, TokenID: "jti_456", ExpiresAt: time.Now().Add(3 * time.Hour)}
+	mockTokenService.On("ValidateToken", &verificationToken, TokenPurposeVerification).Return(claims, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_456", int64(123), string(TokenPurposeVerification), claims.ExpiresAt).Return(sql.ErrNoRows)
+
+	// Act
+	result, err := authService.VerifyNewUser(&verificationToken)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTokenAlreadyUsed)
+	assert.Nil(t, result)
+	mockUserRepo.AssertNotCalled(t, "MarkUserVerified", mock.Anything)
+}
+
+// Test Login - Success
+func TestAuthService_Login_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	mockCryptoService := new(MockCryptoService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, new(MockEmailService))
+
+	userId := 123
+	email := "test@example.com"
+	passwordHash := "hashed_password"
+	accessToken := "access_token_123"
+	refreshToken := "refresh_token_123"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(email+":password"))
+
+	mockUserRepo.On("GetUserByEmail", email).Return(&repositories.UserEntity{ID: int64(userId), Email: email, PasswordHash: &passwordHash}, nil)
+	mockCryptoService.On("ValidatePassword", "password", passwordHash).Return(true, nil)
+	mockTokenService.On("GenerateAccessToken", userId, mock.AnythingOfType("string")).Return(&accessToken, nil)
+	mockUserRepo.On("UpdateUserLastLogin", &userId).Return(true, nil)
+	mockTokenService.On("GenerateRefreshToken", userId).Return(&refreshToken, nil)
+	mockTokenRepo.On("CreateSession", int64(userId), mock.AnythingOfType("string"), mock.Anything, hashRefreshToken(refreshToken), mock.AnythingOfType("time.Time")).
+		Return(&repositories.UserSessionEntity{ID: 1, UserID: int64(userId)}, nil)
+	mockUserRepo.On("CreateLoginHistory", mock.MatchedBy(func(dto *models.UserLoginHistoryCreateDTO) bool {
+		return dto.Method == repositories.LoginMethodPassword && dto.Outcome == repositories.LoginOutcomeSuccess &&
+			dto.UserID != nil && *dto.UserID == int64(userId) && dto.Email == email && dto.UserAgent == testSessionClient.UserAgent
+	})).Return(nil).Once()
+
+	// Act
+	result, err := authService.Login(&authHeader, testSessionClient)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, accessToken, result.AccessToken)
+	assert.Equal(t, refreshToken, result.RefreshToken)
+	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+// Test Login - Unregistered Email
+func TestAuthService_Login_UnknownUser(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), new(MockTokenService), new(MockCryptoService), new(MockEmailService))
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("nobody@example.com:password"))
+	mockUserRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, sql.ErrNoRows)
+	mockUserRepo.On("CreateLoginHistory", mock.MatchedBy(func(dto *models.UserLoginHistoryCreateDTO) bool {
+		return dto.UserID == nil && dto.Email == "nobody@example.com" && dto.Outcome == repositories.LoginOutcomeFailure && dto.FailureReason == loginFailureUnknownUser
+	})).Return(nil).Once()
+
+	// Act
+	result, err := authService.Login(&authHeader, testSessionClient)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test Login - Wrong Password
+func TestAuthService_Login_InvalidPassword(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockCryptoService := new(MockCryptoService)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), mockTokenService, mockCryptoService, new(MockEmailService))
+
+	email := "test@example.com"
+	passwordHash := "hashed_password"
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(email+":wrong_password"))
+
+	mockUserRepo.On("GetUserByEmail", email).Return(&repositories.UserEntity{ID: 123, Email: email, PasswordHash: &passwordHash}, nil)
+	mockCryptoService.On("ValidatePassword", "wrong_password", passwordHash).Return(false, nil)
+	mockUserRepo.On("CreateLoginHistory", mock.MatchedBy(func(dto *models.UserLoginHistoryCreateDTO) bool {
+		return dto.UserID != nil && *dto.UserID == 123 && dto.Outcome == repositories.LoginOutcomeFailure && dto.FailureReason == loginFailureInvalidPassword
+	})).Return(nil).Once()
+
+	// Act
+	result, err := authService.Login(&authHeader, testSessionClient)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockUserRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything)
+}
+
+// Test Login - Recording The Attempt Fails
+func TestAuthService_Login_HistoryErrorIgnored(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), new(MockTokenService), new(MockCryptoService), new(MockEmailService))
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("nobody@example.com:password"))
+	mockUserRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, sql.ErrNoRows)
+	mockUserRepo.On("CreateLoginHistory", mock.Anything).Return(errors.New("database error"))
+
+	// Act
+	_, err := authService.Login(&authHeader, testSessionClient)
+
+	// Assert
+	// The login's own error is returned, not the history error
+	assert.EqualError(t, err, "there was an issue trying to log this user in")
+}
+
+// ===========================================
+// REFRESH TOKEN TESTS
+// ===========================================
+
+// Test RefreshLogin - Success
+func TestAuthService_RefreshLogin_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	refreshToken := "refresh_token_123"
+	nextRefreshToken := "refresh_token_456"
+	accessToken := "access_token_123"
+	stored := &repositories.RefreshTokenEntity{ID: 7, UserID: int64(userId), FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockTokenService.On("ValidateToken", &refreshToken, TokenPurposeRefresh).Return(&TokenClaims{UserID: userId, Purpose: TokenPurposeRefresh}, nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(stored, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId)}, nil)
+	mockTokenService.On("GenerateRefreshToken", userId).Return(&nextRefreshToken, nil)
+	mockTokenRepo.On("RotateRefreshToken", int64(7), hashRefreshToken(nextRefreshToken), mock.AnythingOfType("time.Time")).
+		Return(&repositories.RefreshTokenEntity{ID: 8, UserID: int64(userId), FamilyID: "family"}, nil)
+	mockTokenService.On("GenerateAccessToken", userId, "family").Return(&accessToken, nil)
+	mockTokenRepo.On("GetSessionByKey", "family").Return(&repositories.UserSessionEntity{ID: 3, UserID: int64(userId), SessionKey: "family"}, nil)
+	mockTokenRepo.On("TouchSession", int64(3), testSessionClient).Return(nil)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodRefresh, repositories.LoginOutcomeSuccess, "")
+
+	// Act
+	result, err := authService.RefreshLogin(refreshToken, testSessionClient)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, accessToken, result.AccessToken)
+	assert.Equal(t, nextRefreshToken, result.RefreshToken)
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "RevokeSession", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test RefreshLogin - Used Token Revokes The Family
+func TestAuthService_RefreshLogin_ReusedToken(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	refreshToken := "refresh_token_123"
+	usedAt := time.Now().Add(-time.Minute)
+	stored := &repositories.RefreshTokenEntity{ID: 7, UserID: int64(userId), FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+
+	mockTokenService.On("ValidateToken", &refreshToken, TokenPurposeRefresh).Return(&TokenClaims{UserID: userId, Purpose: TokenPurposeRefresh}, nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(stored, nil)
+	mockTokenRepo.On("RevokeSession", "family").Return(nil)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodRefresh, repositories.LoginOutcomeFailure, loginFailureTokenReused)
+
+	// Act
+	result, err := authService.RefreshLogin(refreshToken, testSessionClient)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, result)
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test RefreshLogin - Token Used By A Concurrent Request
+func TestAuthService_RefreshLogin_ConcurrentRotation(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	refreshToken := "refresh_token_123"
+	nextRefreshToken := "refresh_token_456"
+	stored := &repositories.RefreshTokenEntity{ID: 7, UserID: int64(userId), FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockTokenService.On("ValidateToken", &refreshToken, TokenPurposeRefresh).Return(&TokenClaims{UserID: userId, Purpose: TokenPurposeRefresh}, nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(stored, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId)}, nil)
+	mockTokenService.On("GenerateRefreshToken", userId).Return(&nextRefreshToken, nil)
+	mockTokenRepo.On("RotateRefreshToken", int64(7), hashRefreshToken(nextRefreshToken), mock.AnythingOfType("time.Time")).Return(nil, sql.ErrNoRows)
+	mockTokenRepo.On("RevokeSession", "family").Return(nil)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodRefresh, repositories.LoginOutcomeFailure, loginFailureTokenReused)
+
+	// Act
+	result, err := authService.RefreshLogin(refreshToken, testSessionClient)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, result)
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test RefreshLogin - Unknown Token
+func TestAuthService_RefreshLogin_UnknownToken(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	refreshToken := "refresh_token_123"
+
+	mockTokenService.On("ValidateToken", &refreshToken, TokenPurposeRefresh).Return(&TokenClaims{UserID: userId, Purpose: TokenPurposeRefresh}, nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(nil, sql.ErrNoRows)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodRefresh, repositories.LoginOutcomeFailure, loginFailureInvalidToken)
+
+	// Act
+	result, err := authService.RefreshLogin(refreshToken, testSessionClient)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Nil(t, result)
+	mockTokenRepo.AssertNotCalled(t, "RevokeSession", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test RefreshLogin - Expired Token
+func TestAuthService_RefreshLogin_ExpiredToken(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	refreshToken := "refresh_token_123"
+	stored := &repositories.RefreshTokenEntity{ID: 7, UserID: int64(userId), FamilyID: "family", ExpiresAt: time.Now().Add(-time.Minute)}
+
+	mockTokenService.On("ValidateToken", &refreshToken, TokenPurposeRefresh).Return(&TokenClaims{UserID: userId, Purpose: TokenPurposeRefresh}, nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(stored, nil)
+	expectLoginHistory(mockUserRepo, repositories.LoginMethodRefresh, repositories.LoginOutcomeFailure, loginFailureInvalidToken)
+
+	// Act
+	result, err := authService.RefreshLogin(refreshToken, testSessionClient)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Nil(t, result)
+	mockTokenService.AssertNotCalled(t, "GenerateRefreshToken", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// ===========================================
+// SESSION TESTS
+// ===========================================
+
+// Test GetSessions - Flags The Current Session
+func TestAuthService_GetSessions_FlagsCurrent(t *testing.T) {
+	// Arrange
+	mockTokenRepo := new(MockTokenRepository)
+	authService := NewAuthService(new(MockUserRepository), mockTokenRepo, new(MockTokenService), new(MockCryptoService), new(MockEmailService))
+
+	sessions := []*repositories.UserSessionEntity{
+		{ID: 1, UserID: 123, SessionKey: "session_a"},
+		{ID: 2, UserID: 123, SessionKey: "session_b"},
+	}
+	mockTokenRepo.On("GetActiveSessions", int64(123), mock.AnythingOfType("time.Time")).Return(sessions, nil)
+
+	// Act
+	result, err := authService.GetSessions(123, "session_b")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.False(t, result[0].IsCurrent)
+	assert.True(t, result[1].IsCurrent)
+}
+
+// Test LogoutWithRefreshToken - Revokes The Token's Session
+func TestAuthService_LogoutWithRefreshToken_Success(t *testing.T) {
+	// Arrange
+	mockTokenRepo := new(MockTokenRepository)
+	authService := NewAuthService(new(MockUserRepository), mockTokenRepo, new(MockTokenService), new(MockCryptoService), new(MockEmailService))
+
+	refreshToken := "refresh_token_123"
+	stored := &repositories.RefreshTokenEntity{ID: 7, UserID: 123, FamilyID: "family"}
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(stored, nil)
+	mockTokenRepo.On("RevokeSession", "family").Return(nil)
+
+	// Act
+	err := authService.LogoutWithRefreshToken(refreshToken)
+
+	// Assert
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+// Test LogoutWithRefreshToken - Unknown Token
+func TestAuthService_LogoutWithRefreshToken_UnknownToken(t *testing.T) {
+	// Arrange
+	mockTokenRepo := new(MockTokenRepository)
+	authService := NewAuthService(new(MockUserRepository), mockTokenRepo, new(MockTokenService), new(MockCryptoService), new(MockEmailService))
+
+	refreshToken := "refresh_token_123"
+	mockTokenRepo.On("GetRefreshTokenByHash", hashRefreshToken(refreshToken)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	err := authService.LogoutWithRefreshToken(refreshToken)
+
+	// Assert
+	assert.NoError(t, err)
+	mockTokenRepo.AssertNotCalled(t, "RevokeSession", mock.Anything)
+}
+
+// Test UpdateUserPassword - Logs Out Every Session
+func TestAuthService_UpdateUserPassword_RevokesSessions(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockCryptoService := new(MockCryptoService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, new(MockTokenService), mockCryptoService, new(MockEmailService))
+
+	userId := 123
+	hashedPassword := "hashed_password"
+	mockCryptoService.On("HashPassword", "new_password").Return(&hashedPassword, nil)
+	mockUserRepo.On("UpdateUserPassword", &userId, hashedPassword).Return(true, nil)
+	mockTokenRepo.On("RevokeUserSessions", int64(userId)).Return(nil)
+
+	// Act
+	result, err := authService.UpdateUserPassword(&userId, "new_password")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, userId, *result)
+	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+// Test describeDevice - Common User Agents
+func TestDescribeDevice(t *testing.T) {
+	cases := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36 Edg/130.0.0.0":           "Edge on Windows",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Mobile/15E148 Safari/604.1": "Safari on iPhone",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Mobile Safari/537.36":                   "Chrome on Android",
+		"curl/8.4.0": "curl/8.4.0",
+		"":           "",
+	}
+
+	for userAgent, expected := range cases {
+		assert.Equal(t, expected, describeDevice(userAgent), userAgent)
+	}
+}
+
+// ===========================================
+// PASSWORD RESET TESTS
+// ===========================================
+
+// Test RequestPasswordReset - Success
+func TestAuthService_RequestPasswordReset_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenService := new(MockTokenService)
+	mockEmailService := new(MockEmailService)
+	mockEmailTemplates := new(MockEmailTemplates)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), mockTokenService, new(MockCryptoService), mockEmailService)
+
+	email := "test@example.com"
+	resetToken := "reset_token_123"
+	user := &repositories.UserEntity{ID: 123, Email: email}
+
+	mockUserRepo.On("GetUserByEmail", email).Return(user, nil)
+	mockTokenService.On("GeneratePasswordResetToken", 123).Return(&resetToken, nil)
+	mockEmailService.On("GetTemplates").Return(mockEmailTemplates)
+	mockEmailTemplates.On("GetPasswordResetEmailTemplate", "http://localhost:3000", resetToken).Return("<html>Reset email</html>")
+	sent := make(chan struct{})
+	mockEmailService.On("SendEmail", mock.MatchedBy(func(options *EmailSendOptions) bool {
+		return options.ToEmail == email && options.Subject == "Open Trivia Online - Reset Your Password"
+	})).Return(true).Run(func(args mock.Arguments) { close(sent) })
+
+	// Act
+	err := authService.RequestPasswordReset(email)
+
+	// Assert
+	assert.NoError(t, err)
+	waitForPasswordResetEmail(t, sent)
+	mockTokenService.AssertExpectations(t)
+	mockEmailService.AssertExpectations(t)
+	mockEmailTemplates.AssertExpectations(t)
+}
+
+// Test RequestPasswordReset - Unregistered Email
+func TestAuthService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenService := new(MockTokenService)
+	mockEmailService := new(MockEmailService)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), mockTokenService, new(MockCryptoService), mockEmailService)
+
+	mockUserRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, sql.ErrNoRows)
+
+	// Act
+	err := authService.RequestPasswordReset("nobody@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	mockTokenService.AssertNotCalled(t, "GeneratePasswordResetToken", mock.Anything)
+	mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+}
+
+// Test RequestPasswordReset - Email Send Failure
+func TestAuthService_RequestPasswordReset_EmailSendFailure(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenService := new(MockTokenService)
+	mockEmailService := new(MockEmailService)
+	mockEmailTemplates := new(MockEmailTemplates)
+	authService := NewAuthService(mockUserRepo, new(MockTokenRepository), mockTokenService, new(MockCryptoService), mockEmailService)
+
+	email := "test@example.com"
+	resetToken := "reset_token_123"
+
+	mockUserRepo.On("GetUserByEmail", email).Return(&repositories.UserEntity{ID: 123, Email: email}, nil)
+	mockTokenService.On("GeneratePasswordResetToken", 123).Return(&resetToken, nil)
+	mockEmailService.On("GetTemplates").Return(mockEmailTemplates)
+	mockEmailTemplates.On("GetPasswordResetEmailTemplate", "http://localhost:3000", resetToken).Return("<html>Reset email</html>")
+	sent := make(chan struct{})
+	mockEmailService.On("SendEmail", mock.Anything).Return(false).Run(func(args mock.Arguments) { close(sent) })
+
+	// Act
+	err := authService.RequestPasswordReset(email)
+
+	// Assert
+	// The response must look the same as for an unregistered email
+	assert.NoError(t, err)
+	waitForPasswordResetEmail(t, sent)
+	mockEmailService.AssertExpectations(t)
+}
+
+// waitForPasswordResetEmail waits for the password reset email, which is sent in the background.
+func waitForPasswordResetEmail(t *testing.T, sent chan struct{}) {
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("the password reset email was not sent")
+	}
+}
+
+// Test ConfirmPasswordReset - Success
+func TestAuthService_ConfirmPasswordReset_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	mockCryptoService := new(MockCryptoService)
+	mockEmailService := new(MockEmailService)
+	mockEmailTemplates := new(MockEmailTemplates)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, mockEmailService)
+
+	userId := 123
+	resetToken := "reset_token_123"
+	hashedPassword := "hashed_password"
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposePasswordReset, TokenID: "jti_789", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(30 * time.Minute)}
+
+	mockTokenService.On("ValidateToken", &resetToken, TokenPurposePasswordReset).Return(claims, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId), Email: "test@example.com"}, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_789", int64(userId), string(TokenPurposePasswordReset), claims.ExpiresAt).Return(nil)
+	mockCryptoService.On("HashPassword", "new_password").Return(&hashedPassword, nil)
+	mockUserRepo.On("UpdateUserPassword", &userId, hashedPassword).Return(true, nil)
+	mockTokenRepo.On("RevokeUserSessions", int64(userId)).Return(nil)
+	mockEmailService.On("GetTemplates").Return(mockEmailTemplates)
+	mockEmailTemplates.On("GetPasswordChangedEmailTemplate").Return("<html>Password changed</html>")
+	mockEmailService.On("SendEmail", mock.MatchedBy(func(options *EmailSendOptions) bool {
+		return options.ToEmail == "test@example.com" && options.Subject == "Open Trivia Online - Your Password Was Changed"
+	})).Return(true)
+
+	// Act
+	err := authService.ConfirmPasswordReset(&resetToken, "new_password")
+
+	// Assert
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
+	mockCryptoService.AssertExpectations(t)
+	mockEmailService.AssertExpectations(t)
+}
+
+// Test ConfirmPasswordReset - Link Already Used
+func TestAuthService_ConfirmPasswordReset_TokenAlreadyUsed(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	mockCryptoService := new(MockCryptoService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, mockCryptoService, new(MockEmailService))
+
+	userId := 123
+	resetToken := "reset_token_123"
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposePasswordReset, TokenID: "jti_789", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(30 * time.Minute)}
+
+	mockTokenService.On("ValidateToken", &resetToken, TokenPurposePasswordReset).Return(claims, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId)}, nil)
+	mockTokenRepo.On("ConsumeOneTimeToken", "jti_789", int64(userId), string(TokenPurposePasswordReset), claims.ExpiresAt).Return(sql.ErrNoRows)
+
+	// Act
+	err := authService.ConfirmPasswordReset(&resetToken, "new_password")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTokenAlreadyUsed)
+	mockCryptoService.AssertNotCalled(t, "HashPassword", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything)
+}
+
+// Test ConfirmPasswordReset - Link Issued Before The Password Last Changed
+func TestAuthService_ConfirmPasswordReset_IssuedBeforePasswordChange(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	resetToken := "reset_token_123"
+	passwordChangedAt := time.Now().Add(-time.Minute)
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposePasswordReset, TokenID: "jti_789", IssuedAt: time.Now().Add(-10 * time.Minute), ExpiresAt: time.Now().Add(20 * time.Minute)}
+
+	mockTokenService.On("ValidateToken", &resetToken, TokenPurposePasswordReset).Return(claims, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId), PasswordChangedAt: &passwordChangedAt}, nil)
+
+	// Act
+	err := authService.ConfirmPasswordReset(&resetToken, "new_password")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+	mockTokenRepo.AssertNotCalled(t, "ConsumeOneTimeToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything)
+}
+
+// Test ConfirmPasswordReset - Password Changed After The Link Was Issued, With The Times In Different Zones
+func TestAuthService_ConfirmPasswordReset_PasswordChangedAfterIssue(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockUserRepo, mockTokenRepo, mockTokenService, new(MockCryptoService), new(MockEmailService))
+
+	userId := 123
+	resetToken := "reset_token_123"
+	// Tokens record whole seconds, and the change is read back from the database as UTC
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second).In(time.FixedZone("UTC+10", 10*60*60))
+	passwordChangedAt := issuedAt.Add(30 * time.Second).UTC()
+	claims := &TokenClaims{UserID: userId, Purpose: TokenPurposePasswordReset, TokenID: "jti_789", IssuedAt: issuedAt, ExpiresAt: issuedAt.Add(30 * time.Minute)}
+
+	mockTokenService.On("ValidateToken", &resetToken, TokenPurposePasswordReset).Return(claims, nil)
+	mockUserRepo.On("GetUserById", userId).Return(&repositories.UserEntity{ID: int64(userId), PasswordChangedAt: &passwordChangedAt}, nil)
+
+	// Act
+	err := authService.ConfirmPasswordReset(&resetToken, "new_password")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+	mockTokenRepo.AssertNotCalled(t, "ConsumeOneTimeToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+var (
+	ErrDailyChallengeNotFound    = errors.New("there is no daily challenge for that day")
+	ErrDailyChallengePlayed      = errors.New("you have already played today's daily challenge")
+	ErrDailyChallengeNoQuestions = errors.New("there are no published questions for the daily challenge")
+)
+
+const (
+	dailyChallengeQuestionCount = 10
+	dailyChallengeRecentDays    = 30 // Questions used this many days before a challenge are not picked again
+	dailyChallengeDeckPrefix    = "Daily Challenge: "
+	dailyChallengeStreakLimit   = 366
+	dailyChallengeRetryInterval = time.Minute
+)
+
+type IDailyChallengeService interface {
+	GetOrCreateChallenge(date time.Time) (*repositories.TriviaDailyChallengeEntity, error)
+	GetTodaysChallenge(userId int64) (*models.DailyChallengeStatus, error)
+	StartTodaysChallenge(userId int64) (*repositories.TriviaGameInstanceEntity, error)
+	GetLeaderboard(date time.Time, pageSize int, offset int) (*models.Leaderboard, error)
+
+	// StartScheduler creates each day's challenge in the background as the day begins
+	StartScheduler()
+}
+
+type DailyChallengeService struct {
+	dailyChallengeRepository repositories.IDailyChallengeRepository
+	createMu                 sync.Mutex // Stops two requests on this instance creating the same day's challenge
+}
+
+func NewDailyChallengeService(dailyChallengeRepository repositories.IDailyChallengeRepository) IDailyChallengeService {
+	return &DailyChallengeService{
+		dailyChallengeRepository: dailyChallengeRepository,
+	}
+}
+
+// GetOrCreateChallenge returns the challenge for the UTC day containing date, picking its questions
+// the first time it is asked for. Another instance may create the same day's challenge at the same
+// time, in which case the one that was stored first is returned.
+func (s *DailyChallengeService) GetOrCreateChallenge(date time.Time) (*repositories.TriviaDailyChallengeEntity, error) {
+	day, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, date)
+
+	challenge, err := s.dailyChallengeRepository.GetDailyChallengeByDate(day)
+	if err == nil {
+		return challenge, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+
+	challenge, err = s.dailyChallengeRepository.GetDailyChallengeByDate(day)
+	if err == nil {
+		return challenge, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	candidates, err := s.dailyChallengeRepository.GetDailyChallengeCandidateIds(day, dailyChallengeRecentDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) < dailyChallengeQuestionCount {
+		// Not enough fresh questions, so allow repeats rather than shortening the challenge
+		candidates, err = s.dailyChallengeRepository.GetDailyChallengeCandidateIds(day, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrDailyChallengeNoQuestions
+	}
+
+	questionIds := pickDailyChallengeQuestions(candidates, day, dailyChallengeQuestionCount)
+	challenge, err = s.dailyChallengeRepository.CreateDailyChallenge(day, dailyChallengeDeckPrefix+day.Format(time.DateOnly), questionIds)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.dailyChallengeRepository.GetDailyChallengeByDate(day)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	util.LogInfo("Created the daily challenge for " + day.Format(time.DateOnly))
+	return challenge, nil
+}
+
+// GetTodaysChallenge returns today's challenge along with the player's game, rank and streak.
+func (s *DailyChallengeService) GetTodaysChallenge(userId int64) (*models.DailyChallengeStatus, error) {
+	now := time.Now().UTC()
+	challenge, err := s.GetOrCreateChallenge(now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.DailyChallengeStatus{
+		Date:          challenge.ChallengeDate,
+		DeckID:        challenge.DeckID,
+		QuestionCount: challenge.QuestionCount,
+		ResetsAt:      challenge.ChallengeDate.AddDate(0, 0, 1),
+	}
+
+	game, err := s.dailyChallengeRepository.GetDailyChallengeGame(challenge.ID, userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		status.GameID = &game.ID
+		status.IsFinished = game.EndedAt != nil
+	}
+
+	entry, err := s.dailyChallengeRepository.GetDailyChallengeEntry(challenge.ID, userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		status.Entry = &withAccuracy([]*models.LeaderboardEntry{entry})[0]
+	}
+
+	days, err := s.dailyChallengeRepository.GetDailyChallengeDays(userId, dailyChallengeStreakLimit)
+	if err != nil {
+		return nil, err
+	}
+	status.CurrentStreak = currentDailyStreak(days, now)
+
+	return status, nil
+}
+
+// StartTodaysChallenge starts the player's game for today's challenge. The game is then played
+// through the regular game endpoints, and each player only ever gets one game per challenge.
+func (s *DailyChallengeService) StartTodaysChallenge(userId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	challenge, err := s.GetOrCreateChallenge(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := s.dailyChallengeRepository.CreateDailyChallengeGame(userId, challenge, defaultNumWrongChoices, defaultTimeLimitSeconds)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDailyChallengePlayed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// GetLeaderboard returns a page of the board for the challenge on the UTC day containing date,
+// highest score first. Today's challenge is created if it does not exist yet.
+func (s *DailyChallengeService) GetLeaderboard(date time.Time, pageSize int, offset int) (*models.Leaderboard, error) {
+	day, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, date)
+	today, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, time.Now())
+	if day.After(today) {
+		return nil, ErrDailyChallengeNotFound
+	}
+
+	var challenge *repositories.TriviaDailyChallengeEntity
+	var err error
+	if day.Equal(today) {
+		challenge, err = s.GetOrCreateChallenge(day)
+	} else {
+		challenge, err = s.dailyChallengeRepository.GetDailyChallengeByDate(day)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDailyChallengeNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pageSize > maxLeaderboardPageSize {
+		pageSize = maxLeaderboardPageSize
+	}
+
+	entries, err := s.dailyChallengeRepository.GetDailyChallengeLeaderboard(challenge.ID, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.dailyChallengeRepository.GetDailyChallengeLeaderboardCount(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Leaderboard{
+		Board:       models.DailyChallengeBoard,
+		Period:      models.LeaderboardPeriodDaily,
+		PeriodStart: day,
+		PageSize:    pageSize,
+		Page:        (offset / pageSize) + 1,
+		Total:       *count,
+		Results:     withAccuracy(entries),
+	}, nil
+}
+
+// StartScheduler makes sure today's challenge exists, then creates each following day's challenge
+// as soon as the day begins at UTC midnight. Failures are retried every minute.
+func (s *DailyChallengeService) StartScheduler() {
+	go func() {
+		for {
+			wait := dailyChallengeRetryInterval
+			_, err := s.GetOrCreateChallenge(time.Now())
+			if err != nil {
+				util.LogErrorWithStackTrace(err)
+			} else {
+				now := time.Now().UTC()
+				today, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, now)
+				wait = today.AddDate(0, 0, 1).Sub(now)
+			}
+			time.Sleep(wait)
+		}
+	}()
+}
+
+// pickDailyChallengeQuestions shuffles the candidates with the day as the seed and takes the first count,
+// so the same day always picks the same questions from the same candidates.
+func pickDailyChallengeQuestions(candidates []int64, day time.Time, count int) []int64 {
+	questionIds := slices.Clone(candidates)
+	slices.Sort(questionIds)
+
+	seed := int64(day.Year()*10000 + int(day.Month())*100 + day.Day())
+	random := rand.New(rand.NewSource(seed))
+	random.Shuffle(len(questionIds), func(i, j int) {
+		questionIds[i], questionIds[j] = questionIds[j], questionIds[i]
+	})
+	return questionIds[:min(count, len(questionIds))]
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDailyChallengeRepository is a mock implementation of IDailyChallengeRepository
+type MockDailyChallengeRepository struct {
+	mock.Mock
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeByDate(date time.Time) (*repositories.TriviaDailyChallengeEntity, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaDailyChallengeEntity), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeCandidateIds(date time.Time, recentDays int) ([]int64, error) {
+	args := m.Called(date, recentDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) CreateDailyChallenge(date time.Time, deckName string, questionIds []int64) (*repositories.TriviaDailyChallengeEntity, error) {
+	args := m.Called(date, deckName, questionIds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaDailyChallengeEntity), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) CreateDailyChallengeGame(userId int64, challenge *repositories.TriviaDailyChallengeEntity, numWrongChoices int, timeLimitSeconds int) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, challenge, numWrongChoices, timeLimitSeconds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeGame(challengeId int64, userId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(challengeId, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeDays(userId int64, limit int) ([]time.Time, error) {
+	args := m.Called(userId, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeLeaderboardCount(challengeId int64) (*int, error) {
+	args := m.Called(challengeId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeLeaderboard(challengeId int64, pageSize int, offset int) ([]*models.LeaderboardEntry, error) {
+	args := m.Called(challengeId, pageSize, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockDailyChallengeRepository) GetDailyChallengeEntry(challengeId int64, userId int64) (*models.LeaderboardEntry, error) {
+	args := m.Called(challengeId, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LeaderboardEntry), args.Error(1)
+}
+
+func questionIdRange(from int64, to int64) []int64 {
+	ids := []int64{}
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ===========================================
+// DAILY CHALLENGE CREATION TESTS
+// ===========================================
+
+// Test pickDailyChallengeQuestions - Same Day Picks The Same Questions
+func TestPickDailyChallengeQuestions_Deterministic(t *testing.T) {
+	// Arrange
+	day := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	candidates := questionIdRange(1, 50)
+	reversed := questionIdRange(1, 50)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	// Act
+	first := pickDailyChallengeQuestions(candidates, day, 10)
+	second := pickDailyChallengeQuestions(reversed, day, 10)
+	nextDay := pickDailyChallengeQuestions(candidates, day.AddDate(0, 0, 1), 10)
+
+	// Assert
+	assert.Len(t, first, 10)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, nextDay)
+	assert.Equal(t, questionIdRange(1, 50), candidates) // Candidates are left untouched
+	assert.Len(t, pickDailyChallengeQuestions([]int64{3, 1}, day, 10), 2)
+}
+
+// Test GetOrCreateChallenge - Existing Challenge Is Returned
+func TestDailyChallengeService_GetOrCreateChallenge_Existing(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	challenge := &repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(0)).Return(challenge, nil)
+
+	// Act
+	result, err := challengeService.GetOrCreateChallenge(time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, challenge, result)
+	mockChallengeRepo.AssertNotCalled(t, "CreateDailyChallenge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test GetOrCreateChallenge - Recent Questions Are Allowed Back When Too Few Are Fresh
+func TestDailyChallengeService_GetOrCreateChallenge_FallsBackToRepeats(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	today := utcDaysAgo(0)
+	allQuestions := questionIdRange(1, 12)
+	expectedIds := pickDailyChallengeQuestions(allQuestions, today, dailyChallengeQuestionCount)
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", today).Return(nil, sql.ErrNoRows)
+	mockChallengeRepo.On("GetDailyChallengeCandidateIds", today, dailyChallengeRecentDays).Return([]int64{1, 2, 3}, nil)
+	mockChallengeRepo.On("GetDailyChallengeCandidateIds", today, 0).Return(allQuestions, nil)
+	mockChallengeRepo.On("CreateDailyChallenge", today, "Daily Challenge: "+today.Format(time.DateOnly), expectedIds).Return(&repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}, nil)
+
+	// Act
+	result, err := challengeService.GetOrCreateChallenge(time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), result.ID)
+	mockChallengeRepo.AssertExpectations(t)
+}
+
+// Test GetOrCreateChallenge - Challenge Created By Another Instance Is Returned
+func TestDailyChallengeService_GetOrCreateChallenge_LostRace(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	today := utcDaysAgo(0)
+	challenge := &repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", today).Return(nil, sql.ErrNoRows).Twice()
+	mockChallengeRepo.On("GetDailyChallengeCandidateIds", today, dailyChallengeRecentDays).Return(questionIdRange(1, 20), nil)
+	mockChallengeRepo.On("CreateDailyChallenge", today, mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+	mockChallengeRepo.On("GetDailyChallengeByDate", today).Return(challenge, nil).Once()
+
+	// Act
+	result, err := challengeService.GetOrCreateChallenge(time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, challenge, result)
+}
+
+// Test GetOrCreateChallenge - No Published Questions
+func TestDailyChallengeService_GetOrCreateChallenge_NoQuestions(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	today := utcDaysAgo(0)
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", today).Return(nil, sql.ErrNoRows)
+	mockChallengeRepo.On("GetDailyChallengeCandidateIds", today, mock.Anything).Return([]int64{}, nil)
+
+	// Act
+	result, err := challengeService.GetOrCreateChallenge(time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockChallengeRepo.AssertNotCalled(t, "CreateDailyChallenge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ===========================================
+// DAILY CHALLENGE PLAY TESTS
+// ===========================================
+
+// Test StartTodaysChallenge - Success
+func TestDailyChallengeService_StartTodaysChallenge_Success(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	challenge := &repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(0)).Return(challenge, nil)
+	mockChallengeRepo.On("CreateDailyChallengeGame", int64(1), challenge, defaultNumWrongChoices, defaultTimeLimitSeconds).Return(&repositories.TriviaGameInstanceEntity{ID: 30, UserID: 1, DeckID: 12}, nil)
+
+	// Act
+	result, err := challengeService.StartTodaysChallenge(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), result.ID)
+	assert.Equal(t, int64(12), result.DeckID)
+}
+
+// Test StartTodaysChallenge - Each Player Only Plays Once
+func TestDailyChallengeService_StartTodaysChallenge_AlreadyPlayed(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	challenge := &repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(0)).Return(challenge, nil)
+	mockChallengeRepo.On("CreateDailyChallengeGame", int64(1), challenge, mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := challengeService.StartTodaysChallenge(1)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDailyChallengePlayed)
+	assert.Nil(t, result)
+}
+
+// Test GetTodaysChallenge - Player's Game, Rank And Streak
+func TestDailyChallengeService_GetTodaysChallenge_Played(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	challenge := &repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}
+	endedAt := time.Now()
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(0)).Return(challenge, nil)
+	mockChallengeRepo.On("GetDailyChallengeGame", int64(4), int64(1)).Return(&repositories.TriviaGameInstanceEntity{ID: 30, EndedAt: &endedAt}, nil)
+	mockChallengeRepo.On("GetDailyChallengeEntry", int64(4), int64(1)).Return(&models.LeaderboardEntry{Rank: 2, UserID: 1, TotalCorrect: 3, TotalAnswered: 4}, nil)
+	mockChallengeRepo.On("GetDailyChallengeDays", int64(1), dailyChallengeStreakLimit).Return([]time.Time{utcDaysAgo(0), utcDaysAgo(1), utcDaysAgo(2), utcDaysAgo(4)}, nil)
+
+	// Act
+	result, err := challengeService.GetTodaysChallenge(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), *result.GameID)
+	assert.True(t, result.IsFinished)
+	assert.Equal(t, 2, result.Entry.Rank)
+	assert.Equal(t, 75.0, result.Entry.Accuracy)
+	assert.Equal(t, 3, result.CurrentStreak)
+	assert.Equal(t, utcDaysAgo(-1), result.ResetsAt)
+}
+
+// Test GetTodaysChallenge - Not Played Yet
+func TestDailyChallengeService_GetTodaysChallenge_NotPlayed(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(0)).Return(&repositories.TriviaDailyChallengeEntity{ID: 4, ChallengeDate: utcDaysAgo(0), DeckID: 12, QuestionCount: 10}, nil)
+	mockChallengeRepo.On("GetDailyChallengeGame", int64(4), int64(1)).Return(nil, sql.ErrNoRows)
+	mockChallengeRepo.On("GetDailyChallengeEntry", int64(4), int64(1)).Return(nil, sql.ErrNoRows)
+	mockChallengeRepo.On("GetDailyChallengeDays", int64(1), dailyChallengeStreakLimit).Return([]time.Time{utcDaysAgo(1)}, nil)
+
+	// Act
+	result, err := challengeService.GetTodaysChallenge(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.GameID)
+	assert.Nil(t, result.Entry)
+	assert.Equal(t, 1, result.CurrentStreak) // Yesterday's streak holds until today is over
+}
+
+// ===========================================
+// DAILY CHALLENGE LEADERBOARD TESTS
+// ===========================================
+
+// Test GetLeaderboard - Earlier Day's Board
+func TestDailyChallengeService_GetLeaderboard_PastDay(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	yesterday := utcDaysAgo(1)
+	count := 1
+
+	mockChallengeRepo.On("GetDailyChallengeByDate", yesterday).Return(&repositories.TriviaDailyChallengeEntity{ID: 3, ChallengeDate: yesterday}, nil)
+	mockChallengeRepo.On("GetDailyChallengeLeaderboard", int64(3), 100, 0).Return([]*models.LeaderboardEntry{{Rank: 1, UserID: 7, TotalCorrect: 1, TotalAnswered: 2}}, nil)
+	mockChallengeRepo.On("GetDailyChallengeLeaderboardCount", int64(3)).Return(&count, nil)
+
+	// Act
+	result, err := challengeService.GetLeaderboard(yesterday.Add(5*time.Hour), 500, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DailyChallengeBoard, result.Board)
+	assert.Equal(t, yesterday, result.PeriodStart)
+	assert.Equal(t, 100, result.PageSize)
+	assert.Equal(t, 50.0, result.Results[0].Accuracy)
+	mockChallengeRepo.AssertNotCalled(t, "CreateDailyChallenge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test GetLeaderboard - Days Without A Challenge
+func TestDailyChallengeService_GetLeaderboard_NotFound(t *testing.T) {
+	// Arrange
+	mockChallengeRepo := new(MockDailyChallengeRepository)
+	challengeService := NewDailyChallengeService(mockChallengeRepo)
+	mockChallengeRepo.On("GetDailyChallengeByDate", utcDaysAgo(10)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	_, pastErr := challengeService.GetLeaderboard(utcDaysAgo(10), 25, 0)
+	_, futureErr := challengeService.GetLeaderboard(utcDaysAgo(-1), 25, 0)
+
+	// Assert
+	assert.ErrorIs(t, pastErr, ErrDailyChallengeNotFound)
+	assert.ErrorIs(t, futureErr, ErrDailyChallengeNotFound)
+}
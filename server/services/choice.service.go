@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+type IChoiceService interface {
+	SelectChoices(question *repositories.TriviaQuestionEntity, numWrongChoices int) ([]string, error)
+	ToGameChoices(gameId int64, questionId int64, answers []string) []models.GameChoice
+	ChoiceID(gameId int64, questionId int64, answer string) string
+}
+
+type ChoiceService struct {
+	triviaRepository repositories.ITriviaRepository
+}
+
+func NewChoiceService(triviaRepository repositories.ITriviaRepository) IChoiceService {
+	return &ChoiceService{
+		triviaRepository: triviaRepository,
+	}
+}
+
+// SelectChoices picks up to numWrongChoices distinct distractors and returns them shuffled together
+// with the correct answer. Distractors written for the question itself come first, then wrong answers
+// from the pool whose tags overlap the question's tags, then the correct answers of other questions
+// with a shared tag. If all of them are thin the question is returned with fewer choices rather than
+// with unrelated answers.
+func (s *ChoiceService) SelectChoices(question *repositories.TriviaQuestionEntity, numWrongChoices int) ([]string, error) {
+	choices := []string{question.CorrectAnswer}
+	seen := map[string]bool{normalizeAnswer(question.CorrectAnswer): true}
+
+	addDistractors := func(answers []string) {
+		for _, answer := range answers {
+			key := normalizeAnswer(answer)
+			if key == "" || seen[key] || len(choices) > numWrongChoices {
+				continue
+			}
+			seen[key] = true
+			choices = append(choices, answer)
+		}
+	}
+
+	if numWrongChoices > 0 {
+		wrongAnswers, err := s.triviaRepository.GetRandomWrongAnswersByTags(question.Tags, question.ID, question.CorrectAnswer, numWrongChoices)
+		if err != nil {
+			return nil, err
+		}
+		addDistractors(wrongAnswers)
+
+		if missing := numWrongChoices + 1 - len(choices); missing > 0 && len(question.Tags) > 0 {
+			// Ask for extra answers since some may duplicate the distractors we already have
+			fallbackAnswers, err := s.triviaRepository.GetRandomCorrectAnswersByTags(question.Tags, question.ID, question.CorrectAnswer, missing+len(choices))
+			if err != nil {
+				return nil, err
+			}
+			addDistractors(fallbackAnswers)
+		}
+	}
+
+	rand.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	return choices, nil
+}
+
+// ToGameChoices gives each answer its choice ID, keeping the order of the answers.
+func (s *ChoiceService) ToGameChoices(gameId int64, questionId int64, answers []string) []models.GameChoice {
+	choices := make([]models.GameChoice, 0, len(answers))
+	for _, answer := range answers {
+		choices = append(choices, models.GameChoice{
+			ID:   s.ChoiceID(gameId, questionId, answer),
+			Text: answer,
+		})
+	}
+	return choices
+}
+
+// ChoiceID derives a stable ID for an answer within a game. Every choice is hashed the same way
+// so the ID never reveals which choice is correct.
+func (s *ChoiceService) ChoiceID(gameId int64, questionId int64, answer string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s", gameId, questionId, normalizeAnswer(answer))))
+	return hex.EncodeToString(hash[:8])
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test SelectChoices - Full Pool
+func TestChoiceService_SelectChoices_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	choiceService := NewChoiceService(mockTriviaRepo)
+
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string{"geography"}, int64(42), "Paris", 3).Return([]string{"London", "Berlin", "Madrid"}, nil)
+
+	// Act
+	choices, err := choiceService.SelectChoices(question, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Paris", "London", "Berlin", "Madrid"}, choices)
+	mockTriviaRepo.AssertNotCalled(t, "GetRandomCorrectAnswersByTags")
+}
+
+// Test SelectChoices - Thin Pool Falls Back To Other Correct Answers
+func TestChoiceService_SelectChoices_FallsBackToCorrectAnswers(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	choiceService := NewChoiceService(mockTriviaRepo)
+
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string{"geography"}, int64(42), "Paris", 3).Return([]string{"London"}, nil)
+	mockTriviaRepo.On("GetRandomCorrectAnswersByTags", []string{"geography"}, int64(42), "Paris", 4).Return([]string{" london ", "Rome", "PARIS", "Lisbon"}, nil)
+
+	// Act
+	choices, err := choiceService.SelectChoices(question, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Paris", "London", "Rome", "Lisbon"}, choices)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test SelectChoices - Empty Pool Returns Fewer Choices
+func TestChoiceService_SelectChoices_EmptyPool(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	choiceService := NewChoiceService(mockTriviaRepo)
+
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string{"geography"}, int64(42), "Paris", 3).Return([]string{}, nil)
+	mockTriviaRepo.On("GetRandomCorrectAnswersByTags", []string{"geography"}, int64(42), "Paris", 4).Return([]string{}, nil)
+
+	// Act
+	choices, err := choiceService.SelectChoices(question, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Paris"}, choices)
+}
+
+// Test SelectChoices - Question Without Tags Only Uses Its Own Distractors
+func TestChoiceService_SelectChoices_NoTags(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	choiceService := NewChoiceService(mockTriviaRepo)
+
+	question := &repositories.TriviaQuestionEntity{ID: 1, CorrectAnswer: "Paris", Tags: pq.StringArray{}}
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string{}, int64(1), "Paris", 3).Return([]string{"Lyon"}, nil)
+
+	// Act
+	choices, err := choiceService.SelectChoices(question, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Paris", "Lyon"}, choices)
+	mockTriviaRepo.AssertNotCalled(t, "GetRandomCorrectAnswersByTags")
+}
+
+// Test ChoiceID - Stable And Scoped To The Game
+func TestChoiceService_ChoiceID(t *testing.T) {
+	// Arrange
+	choiceService := NewChoiceService(new(MockTriviaRepository))
+
+	// Act
+	id := choiceService.ChoiceID(10, 42, "Paris")
+
+	// Assert
+	assert.Equal(t, id, choiceService.ChoiceID(10, 42, " paris "))
+	assert.NotEqual(t, id, choiceService.ChoiceID(11, 42, "Paris"))
+	assert.NotEqual(t, id, choiceService.ChoiceID(10, 42, "London"))
+	assert.NotContains(t, id, "Paris")
+}
@@ -5,6 +5,8 @@ import "fmt"
 type IEmailTemplates interface {
 	GetNewUserEmailTemplate(baseURL string, verificationToken string) string
 	GetLoginEmailTemplate(baseURL string, verificationToken string) string
+	GetPasswordResetEmailTemplate(baseURL string, resetToken string) string
+	GetPasswordChangedEmailTemplate() string
 }
 
 type EmailTemplates struct {
@@ -35,3 +37,29 @@ func (e *EmailTemplates) GetLoginEmailTemplate(baseURL string, verificationToken
 		</p>
 	`, baseURL, verificationToken)
 }
+
+func (e *EmailTemplates) GetPasswordResetEmailTemplate(baseURL string, resetToken string) string {
+	return fmt.Sprintf(`
+		<p>
+			Hello! You can choose a new password for your account by 
+			<a href="%v/reset-password?token=%v">Clicking Here!</a>
+			The link can only be used once and expires in 30 minutes.
+		</p>
+
+		<p>
+			If you did not request this email, please ignore it. Your password has not been changed.
+		</p>
+	`, baseURL, resetToken)
+}
+
+func (e *EmailTemplates) GetPasswordChangedEmailTemplate() string {
+	return `
+		<p>
+			Hello! The password for your account was just changed, and every device that was logged in has been logged out.
+		</p>
+
+		<p>
+			If you did not change your password, please reset it straight away and contact support.
+		</p>
+	`
+}
@@ -0,0 +1,668 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+var (
+	ErrGameNotFound   = errors.New("game not found")
+	ErrGameEnded      = errors.New("game has already ended")
+	ErrGameInProgress = errors.New("game has not ended yet")
+	ErrRoomGame       = errors.New("this game is played through its room")
+
+	ErrQuestionAlreadyAnswered = errors.New("question has already been answered")
+
+	ErrDeckNotPlayable     = errors.New("deck is not available for play")
+	ErrDeckHasNoQuestions  = errors.New("deck has no playable questions")
+	ErrInvalidGameSettings = errors.New("invalid game settings")
+	ErrInvalidAnswer       = errors.New("invalid answer")
+)
+
+const (
+	defaultNumWrongChoices  = 3
+	maxNumWrongChoices      = 5
+	defaultTimeLimitSeconds = 20
+	minTimeLimitSeconds     = 5
+	maxTimeLimitSeconds     = 120
+	answerGracePeriod       = time.Second
+	smartDeckGameQuestions  = 20 // Solo games on a smart deck end after this many questions
+)
+
+// Scoring - A correct answer is worth up to 200 points before the streak bonus is applied.
+const (
+	baseAnswerScore             = 100
+	maxSpeedBonus               = 100
+	streakBonusPercentPerAnswer = 10
+	maxStreakBonusPercent       = 50
+)
+
+type IGameService interface {
+	StartSoloGame(userId int64, dto *models.GameStartDTO) (*repositories.TriviaGameInstanceEntity, error)
+	GetGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error)
+	GetNextQuestion(userId int64, gameId int64) (*models.GameQuestionDTO, error)
+	SubmitAnswer(userId int64, gameId int64, dto *models.GameAnswerDTO) (*models.GameAnswerResult, error)
+	EndGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error)
+	GetGameSummary(userId int64, gameId int64) (*models.GameSummary, error)
+
+	// Room gameplay methods, driven by the room service rather than the player
+	ServeQuestionToGame(gameId int64, question *repositories.TriviaQuestionEntity, choices []string, servedAt time.Time) (*models.GameQuestionDTO, error)
+	AnswerQuestionInGame(gameId int64, questionId int64, choiceId string, answeredAt time.Time) (*models.GameAnswerResult, error)
+	FinishGame(gameId int64) error
+
+	// OnGameEnded registers a hook that runs after any solo or room game ends
+	OnGameEnded(hook GameEndedHook)
+}
+
+// GameEndedHook is called with a game right after it ends. Hooks run in the order they were
+// registered, on the goroutine that ended the game, and handle their own errors. They must all
+// be registered before the server starts handling requests.
+type GameEndedHook func(game *repositories.TriviaGameInstanceEntity)
+
+type GameService struct {
+	gameRepository   repositories.IGameRepository
+	triviaRepository repositories.ITriviaRepository
+	choiceService    IChoiceService
+	gameEndedHooks   []GameEndedHook
+}
+
+func NewGameService(gameRepository repositories.IGameRepository, triviaRepository repositories.ITriviaRepository, choiceService IChoiceService) IGameService {
+	return &GameService{
+		gameRepository:   gameRepository,
+		triviaRepository: triviaRepository,
+		choiceService:    choiceService,
+	}
+}
+
+func (s *GameService) StartSoloGame(userId int64, dto *models.GameStartDTO) (*repositories.TriviaGameInstanceEntity, error) {
+	numWrongChoices, timeLimitSeconds, err := resolveGameSettings(dto.NumWrongChoices, dto.TimeLimitSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	deck, err := getPlayableDeck(s.triviaRepository, s.gameRepository, dto.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gameRepository.CreateGameInstance(userId, deck.ID, numWrongChoices, timeLimitSeconds)
+}
+
+func (s *GameService) GetGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	game, err := s.gameRepository.GetGameInstanceById(gameId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, err
+	}
+
+	// Games are private to the player who started them
+	if game.UserID != userId || game.IsArchived {
+		return nil, ErrGameNotFound
+	}
+	return game, nil
+}
+
+// GetNextQuestion returns the next unanswered question with its choices and deadline. A question
+// whose deadline passed without an answer is recorded as timed out and the following one is served.
+// A nil question is returned once every question in the deck has been answered, or once a smart deck
+// game has reached smartDeckGameQuestions.
+func (s *GameService) GetNextQuestion(userId int64, gameId int64) (*models.GameQuestionDTO, error) {
+	game, err := s.GetGame(userId, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt != nil {
+		return nil, ErrGameEnded
+	}
+	if game.RoomID != nil {
+		return nil, ErrRoomGame
+	}
+
+	deck, err := s.triviaRepository.GetTriviaDeckById(game.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		answered := game.TotalCorrect + game.TotalIncorrect
+		if deck.Rules != nil && answered >= smartDeckGameQuestions {
+			return nil, nil
+		}
+
+		question, err := s.gameRepository.GetNextUnansweredQuestion(game.ID, deck.ID, deck.Rules)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		served, err := s.getOrServeQuestion(game, question)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		if isPastDeadline(served, now) {
+			_, err = s.recordAttempt(game, question, served, "", now)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		total, err := s.gameRepository.GetPlayableDeckQuestionCount(deck.ID, deck.Rules)
+		if err != nil {
+			return nil, err
+		}
+		if deck.Rules != nil {
+			*total = min(*total, smartDeckGameQuestions)
+		}
+
+		return &models.GameQuestionDTO{
+			GameID:          game.ID,
+			QuestionID:      question.ID,
+			Question:        question.Question,
+			Choices:         s.choiceService.ToGameChoices(game.ID, question.ID, served.Choices),
+			QuestionNumber:  answered + 1,
+			TotalQuestions:  max(*total, answered+1), // Questions may be unpublished after they were answered
+			ServedAt:        served.ServedAt,
+			DeadlineAt:      served.DeadlineAt,
+			TimeRemainingMs: served.DeadlineAt.Sub(now).Milliseconds(),
+		}, nil
+	}
+}
+
+// SubmitAnswer records an answer for the question that is currently being played. Answers are
+// matched by choice ID, or by the answer text when no choice ID is sent. An answer that arrives
+// after the question's deadline is recorded as timed out and scores nothing.
+func (s *GameService) SubmitAnswer(userId int64, gameId int64, dto *models.GameAnswerDTO) (*models.GameAnswerResult, error) {
+	now := time.Now().UTC()
+
+	if dto.ChoiceID == "" && strings.TrimSpace(dto.Answer) == "" {
+		return nil, fmt.Errorf("%w: choice_id or answer is required", ErrInvalidAnswer)
+	}
+
+	game, err := s.GetGame(userId, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt != nil {
+		return nil, ErrGameEnded
+	}
+	if game.RoomID != nil {
+		return nil, ErrRoomGame
+	}
+
+	deck, err := s.triviaRepository.GetTriviaDeckById(game.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.Rules != nil && game.TotalCorrect+game.TotalIncorrect >= smartDeckGameQuestions {
+		return nil, fmt.Errorf("%w: every question in this game has been answered", ErrInvalidAnswer)
+	}
+
+	question, err := s.gameRepository.GetNextUnansweredQuestion(game.ID, deck.ID, deck.Rules)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: every question in this game has been answered", ErrInvalidAnswer)
+		}
+		return nil, err
+	}
+	if question.ID != dto.QuestionID {
+		return nil, fmt.Errorf("%w: question is not the current question for this game", ErrInvalidAnswer)
+	}
+
+	served, err := s.gameRepository.GetServedQuestion(game.ID, question.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: question has not been served yet", ErrInvalidAnswer)
+		}
+		return nil, err
+	}
+
+	pickedAnswer := dto.Answer
+	if dto.ChoiceID != "" {
+		pickedAnswer, err = s.resolveChoice(served, dto.ChoiceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.recordAttempt(game, question, served, pickedAnswer, now)
+}
+
+func (s *GameService) EndGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	game, err := s.GetGame(userId, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt != nil {
+		return nil, ErrGameEnded
+	}
+	if game.RoomID != nil {
+		return nil, ErrRoomGame
+	}
+
+	ended, err := s.gameRepository.EndGameInstance(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.runGameEndedHooks(ended)
+	return ended, nil
+}
+
+// GetGameSummary builds the results of a finished game, comparing them with the player's
+// other games and with every other game played on the same deck.
+func (s *GameService) GetGameSummary(userId int64, gameId int64) (*models.GameSummary, error) {
+	game, err := s.GetGame(userId, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt == nil {
+		return nil, ErrGameInProgress
+	}
+
+	attempts, err := s.gameRepository.GetGameAttemptDetails(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	playerAverages, err := s.gameRepository.GetPlayerGameAverages(game.UserID, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	deckAverages, err := s.gameRepository.GetDeckGameAverages(game.DeckID, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.GameSummary{
+		GameID:         game.ID,
+		DeckID:         game.DeckID,
+		StartedAt:      game.StartedAt,
+		EndedAt:        *game.EndedAt,
+		TotalQuestions: len(attempts),
+		TotalCorrect:   game.TotalCorrect,
+		TotalIncorrect: game.TotalIncorrect,
+		TotalScore:     game.TotalScore,
+		Accuracy:       percentage(game.TotalCorrect, game.TotalCorrect+game.TotalIncorrect),
+		LongestStreak:  game.BestStreak,
+		Questions:      []models.GameSummaryQuestion{},
+	}
+
+	tagStats := map[string]*models.GameTagPerformance{}
+	answeredInTime := 0
+	totalResponseTimeMs := 0
+	for _, attempt := range attempts {
+		summary.Questions = append(summary.Questions, models.GameSummaryQuestion{
+			QuestionID:     attempt.QuestionID,
+			Question:       attempt.Question,
+			PickedAnswer:   attempt.PickedAnswer,
+			CorrectAnswer:  attempt.CorrectAnswer,
+			IsCorrect:      attempt.IsCorrect,
+			IsTimedOut:     attempt.IsTimedOut,
+			ResponseTimeMs: attempt.ResponseTimeMs,
+			Score:          attempt.Score,
+			Tags:           attempt.Tags,
+		})
+
+		if attempt.IsTimedOut {
+			summary.TotalTimedOut++
+		} else {
+			answeredInTime++
+			totalResponseTimeMs += attempt.ResponseTimeMs
+		}
+
+		for _, tag := range attempt.Tags {
+			stats, ok := tagStats[tag]
+			if !ok {
+				stats = &models.GameTagPerformance{Tag: tag}
+				tagStats[tag] = stats
+			}
+			stats.Total++
+			if attempt.IsCorrect {
+				stats.Correct++
+			}
+		}
+	}
+	if answeredInTime > 0 {
+		summary.AverageResponseTimeMs = totalResponseTimeMs / answeredInTime
+	}
+
+	summary.Tags = rankTagPerformance(tagStats)
+	if len(summary.Tags) > 0 {
+		summary.BestTag = &summary.Tags[0].Tag
+	}
+	if len(summary.Tags) > 1 {
+		summary.WorstTag = &summary.Tags[len(summary.Tags)-1].Tag
+	}
+
+	summary.PlayerComparison = compareWithAverages(summary, playerAverages)
+	summary.DeckComparison = compareWithAverages(summary, deckAverages)
+	return summary, nil
+}
+
+// ServeQuestionToGame serves a question chosen by a room, so every player in the room sees the
+// same choices and deadline. The question number is left for the room to fill in.
+func (s *GameService) ServeQuestionToGame(gameId int64, question *repositories.TriviaQuestionEntity, choices []string, servedAt time.Time) (*models.GameQuestionDTO, error) {
+	game, err := s.gameRepository.GetGameInstanceById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	served, err := s.gameRepository.SaveServedQuestion(&repositories.TriviaGameQuestionEntity{
+		GameInstanceID: game.ID,
+		QuestionID:     question.ID,
+		Choices:        choices,
+		ServedAt:       servedAt,
+		DeadlineAt:     servedAt.Add(time.Duration(game.TimeLimitSeconds) * time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GameQuestionDTO{
+		GameID:          game.ID,
+		QuestionID:      question.ID,
+		Question:        question.Question,
+		Choices:         s.choiceService.ToGameChoices(game.ID, question.ID, served.Choices),
+		ServedAt:        served.ServedAt,
+		DeadlineAt:      served.DeadlineAt,
+		TimeRemainingMs: served.DeadlineAt.Sub(time.Now().UTC()).Milliseconds(),
+	}, nil
+}
+
+// AnswerQuestionInGame records a room player's answer to a served question. An empty choice ID
+// records the question as timed out, which is how players who never answered are handled.
+func (s *GameService) AnswerQuestionInGame(gameId int64, questionId int64, choiceId string, answeredAt time.Time) (*models.GameAnswerResult, error) {
+	game, err := s.gameRepository.GetGameInstanceById(gameId)
+	if err != nil {
+		return nil, err
+	}
+	if game.EndedAt != nil {
+		return nil, ErrGameEnded
+	}
+
+	question, err := s.triviaRepository.GetQuestionById(questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	served, err := s.gameRepository.GetServedQuestion(game.ID, question.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: question has not been served yet", ErrInvalidAnswer)
+		}
+		return nil, err
+	}
+
+	pickedAnswer := ""
+	if choiceId != "" {
+		pickedAnswer, err = s.resolveChoice(served, choiceId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.recordAttempt(game, question, served, pickedAnswer, answeredAt)
+}
+
+// FinishGame ends a room player's game. Games that already ended are left as they are.
+func (s *GameService) FinishGame(gameId int64) error {
+	ended, err := s.gameRepository.EndGameInstance(gameId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.runGameEndedHooks(ended)
+	return nil
+}
+
+func (s *GameService) OnGameEnded(hook GameEndedHook) {
+	s.gameEndedHooks = append(s.gameEndedHooks, hook)
+}
+
+func (s *GameService) runGameEndedHooks(game *repositories.TriviaGameInstanceEntity) {
+	for _, hook := range s.gameEndedHooks {
+		hook(game)
+	}
+}
+
+// getOrServeQuestion returns a question as it was first served in the game, selecting its
+// choices and starting its timer the first time it is served.
+func (s *GameService) getOrServeQuestion(game *repositories.TriviaGameInstanceEntity, question *repositories.TriviaQuestionEntity) (*repositories.TriviaGameQuestionEntity, error) {
+	served, err := s.gameRepository.GetServedQuestion(game.ID, question.ID)
+	if err == nil {
+		return served, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	choices, err := s.choiceService.SelectChoices(question, game.NumWrongChoices)
+	if err != nil {
+		return nil, err
+	}
+
+	servedAt := time.Now().UTC()
+	return s.gameRepository.SaveServedQuestion(&repositories.TriviaGameQuestionEntity{
+		GameInstanceID: game.ID,
+		QuestionID:     question.ID,
+		Choices:        choices,
+		ServedAt:       servedAt,
+		DeadlineAt:     servedAt.Add(time.Duration(game.TimeLimitSeconds) * time.Second),
+	})
+}
+
+// recordAttempt scores an answer against the served question's timer and stores it. An empty
+// answer means the player never answered and is recorded as timed out. The game passed in is
+// updated with the new totals.
+func (s *GameService) recordAttempt(game *repositories.TriviaGameInstanceEntity, question *repositories.TriviaQuestionEntity, served *repositories.TriviaGameQuestionEntity, pickedAnswer string, answeredAt time.Time) (*models.GameAnswerResult, error) {
+	responseTime := max(answeredAt.Sub(served.ServedAt), 0)
+	isTimedOut := pickedAnswer == "" || isPastDeadline(served, answeredAt)
+	isCorrect := !isTimedOut && normalizeAnswer(pickedAnswer) == normalizeAnswer(question.CorrectAnswer)
+
+	streak := 0
+	if isCorrect {
+		streak = game.CurrentStreak + 1
+	}
+	score := CalculateAnswerScore(isCorrect, responseTime, served.DeadlineAt.Sub(served.ServedAt), streak)
+
+	_, err := s.gameRepository.CreateQuestionAttempt(&repositories.TriviaQuestionAttemptEntity{
+		GameInstanceID: game.ID,
+		QuestionID:     question.ID,
+		PickedAnswer:   pickedAnswer,
+		IsCorrect:      isCorrect,
+		IsTimedOut:     isTimedOut,
+		ResponseTimeMs: int(responseTime.Milliseconds()),
+		Score:          score,
+		Streak:         streak,
+	})
+	if errors.Is(err, repositories.ErrDuplicateAttempt) {
+		return nil, ErrQuestionAlreadyAnswered
+	}
+	// The game was ended after it was loaded
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGameEnded
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if isCorrect {
+		game.TotalCorrect++
+	} else {
+		game.TotalIncorrect++
+	}
+	game.TotalScore += score
+	game.CurrentStreak = streak
+	game.BestStreak = max(game.BestStreak, streak)
+
+	return &models.GameAnswerResult{
+		GameID:          game.ID,
+		QuestionID:      question.ID,
+		IsCorrect:       isCorrect,
+		CorrectChoiceID: s.choiceService.ChoiceID(game.ID, question.ID, question.CorrectAnswer),
+		CorrectAnswer:   question.CorrectAnswer,
+		IsTimedOut:      isTimedOut,
+		ResponseTimeMs:  int(responseTime.Milliseconds()),
+		Score:           score,
+		Streak:          streak,
+		TotalCorrect:    game.TotalCorrect,
+		TotalIncorrect:  game.TotalIncorrect,
+		TotalScore:      game.TotalScore,
+	}, nil
+}
+
+// resolveChoice finds the answer text for a choice ID among the choices served for a question.
+func (s *GameService) resolveChoice(served *repositories.TriviaGameQuestionEntity, choiceId string) (string, error) {
+	for _, choice := range s.choiceService.ToGameChoices(served.GameInstanceID, served.QuestionID, served.Choices) {
+		if choice.ID == choiceId {
+			return choice.Text, nil
+		}
+	}
+	return "", fmt.Errorf("%w: choice is not valid for this question", ErrInvalidAnswer)
+}
+
+// isPastDeadline reports whether a served question can no longer be answered. A short grace
+// period covers the time an answer spends in transit.
+func isPastDeadline(served *repositories.TriviaGameQuestionEntity, at time.Time) bool {
+	return at.After(served.DeadlineAt.Add(answerGracePeriod))
+}
+
+// CalculateAnswerScore scores an answer. A correct answer is worth a base amount plus a speed bonus
+// that shrinks as the timer runs down, and the total grows with the length of the answer streak.
+func CalculateAnswerScore(isCorrect bool, responseTime time.Duration, timeLimit time.Duration, streak int) int {
+	if !isCorrect || timeLimit <= 0 {
+		return 0
+	}
+
+	remaining := min(max(timeLimit-responseTime, 0), timeLimit)
+	speedBonus := int(int64(maxSpeedBonus) * int64(remaining) / int64(timeLimit))
+	streakBonusPercent := min(max(streak-1, 0)*streakBonusPercentPerAnswer, maxStreakBonusPercent)
+
+	return (baseAnswerScore + speedBonus) * (100 + streakBonusPercent) / 100
+}
+
+// resolveGameSettings applies the defaults and limits for the number of wrong choices and the question timer.
+func resolveGameSettings(numWrongChoicesSetting *int, timeLimitSecondsSetting *int) (int, int, error) {
+	numWrongChoices := defaultNumWrongChoices
+	if numWrongChoicesSetting != nil {
+		numWrongChoices = *numWrongChoicesSetting
+	}
+	if numWrongChoices < 1 || numWrongChoices > maxNumWrongChoices {
+		return 0, 0, fmt.Errorf("%w: num_wrong_choices must be between 1 and %d", ErrInvalidGameSettings, maxNumWrongChoices)
+	}
+
+	timeLimitSeconds := defaultTimeLimitSeconds
+	if timeLimitSecondsSetting != nil {
+		timeLimitSeconds = *timeLimitSecondsSetting
+	}
+	if timeLimitSeconds < minTimeLimitSeconds || timeLimitSeconds > maxTimeLimitSeconds {
+		return 0, 0, fmt.Errorf("%w: time_limit_seconds must be between %d and %d", ErrInvalidGameSettings, minTimeLimitSeconds, maxTimeLimitSeconds)
+	}
+
+	return numWrongChoices, timeLimitSeconds, nil
+}
+
+// getPlayableDeck loads a deck that can be played, see checkDeckPlayable.
+func getPlayableDeck(triviaRepository repositories.ITriviaRepository, gameRepository repositories.IGameRepository, deckId int64) (*repositories.TriviaDeckEntity, error) {
+	deck, err := triviaRepository.GetTriviaDeckById(deckId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = checkDeckPlayable(gameRepository, deck)
+	if err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// checkDeckPlayable returns ErrDeckNotPlayable for a deck that is archived or not approved, and
+// ErrDeckHasNoQuestions for one without a playable question.
+func checkDeckPlayable(gameRepository repositories.IGameRepository, deck *repositories.TriviaDeckEntity) error {
+	if deck.IsArchived || !deck.IsApproved {
+		return ErrDeckNotPlayable
+	}
+
+	count, err := gameRepository.GetPlayableDeckQuestionCount(deck.ID, deck.Rules)
+	if err != nil {
+		return err
+	}
+	if *count == 0 {
+		return ErrDeckHasNoQuestions
+	}
+	return nil
+}
+
+// rankTagPerformance orders tags from best to worst accuracy. Ties go to the tag with more
+// answers so that a single lucky answer does not outrank a strong category.
+func rankTagPerformance(tagStats map[string]*models.GameTagPerformance) []models.GameTagPerformance {
+	tags := make([]models.GameTagPerformance, 0, len(tagStats))
+	for _, stats := range tagStats {
+		stats.Accuracy = percentage(stats.Correct, stats.Total)
+		tags = append(tags, *stats)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Accuracy != tags[j].Accuracy {
+			return tags[i].Accuracy > tags[j].Accuracy
+		}
+		if tags[i].Total != tags[j].Total {
+			return tags[i].Total > tags[j].Total
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags
+}
+
+func compareWithAverages(summary *models.GameSummary, averages *models.GameAverages) models.GameComparison {
+	comparison := models.GameComparison{
+		GameAverages: models.GameAverages{
+			GamesPlayed:     averages.GamesPlayed,
+			AverageScore:    roundTo(averages.AverageScore, 2),
+			AverageAccuracy: roundTo(averages.AverageAccuracy, 2),
+		},
+	}
+
+	// There is nothing to compare against until another game has been played
+	if averages.GamesPlayed > 0 {
+		comparison.ScoreDifference = roundTo(float64(summary.TotalScore)-averages.AverageScore, 2)
+		comparison.AccuracyDifference = roundTo(summary.Accuracy-averages.AverageAccuracy, 2)
+	}
+	return comparison
+}
+
+// percentage returns part as a percentage of total rounded to two decimal places.
+func percentage(part int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundTo(float64(part)*100/float64(total), 2)
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
+
+// normalizeAnswer makes answer comparison ignore case and surrounding whitespace.
+func normalizeAnswer(answer string) string {
+	return strings.ToLower(strings.TrimSpace(answer))
+}
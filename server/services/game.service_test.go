@@ -0,0 +1,995 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGameRepository is a mock implementation of IGameRepository
+type MockGameRepository struct {
+	mock.Mock
+}
+
+func (m *MockGameRepository) CreateGameInstance(userId int64, deckId int64, numWrongChoices int, timeLimitSeconds int) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, deckId, numWrongChoices, timeLimitSeconds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) GetGameInstanceById(gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) EndGameInstance(gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) ArchiveGameInstance(gameId int64) error {
+	args := m.Called(gameId)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) CreateQuestionAttempt(attempt *repositories.TriviaQuestionAttemptEntity) (*repositories.TriviaQuestionAttemptEntity, error) {
+	args := m.Called(attempt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaQuestionAttemptEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) GetPlayableDeckQuestionCount(deckId int64, rules *models.TriviaDeckRules) (*int, error) {
+	args := m.Called(deckId, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockGameRepository) GetNextUnansweredQuestion(gameId int64, deckId int64, rules *models.TriviaDeckRules) (*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(gameId, deckId, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaQuestionEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) GetServedQuestion(gameId int64, questionId int64) (*repositories.TriviaGameQuestionEntity, error) {
+	args := m.Called(gameId, questionId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameQuestionEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) SaveServedQuestion(served *repositories.TriviaGameQuestionEntity) (*repositories.TriviaGameQuestionEntity, error) {
+	args := m.Called(served)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameQuestionEntity), args.Error(1)
+}
+
+// Game summary methods
+func (m *MockGameRepository) GetGameAttemptDetails(gameId int64) ([]*repositories.TriviaQuestionAttemptDetailEntity, error) {
+	args := m.Called(gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionAttemptDetailEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) GetPlayerGameAverages(userId int64, excludeGameId int64) (*models.GameAverages, error) {
+	args := m.Called(userId, excludeGameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameAverages), args.Error(1)
+}
+
+func (m *MockGameRepository) GetDeckGameAverages(deckId int64, excludeGameId int64) (*models.GameAverages, error) {
+	args := m.Called(deckId, excludeGameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameAverages), args.Error(1)
+}
+
+func (m *MockGameRepository) CreateRoomGameInstance(userId int64, room *repositories.TriviaGameRoomEntity) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, room)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameRepository) GetPlayableDeckQuestions(deckId int64, rules *models.TriviaDeckRules, limit int, seed int64) ([]*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(deckId, rules, limit, seed)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionEntity), args.Error(1)
+}
+
+var noDeckRules *models.TriviaDeckRules
+
+// ===========================================
+// START GAME TESTS
+// ===========================================
+
+// Test StartSoloGame - Success With Default Choices
+func TestGameService_StartSoloGame_Success(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	deck := &repositories.TriviaDeckEntity{ID: 3, IsApproved: true}
+	count := 10
+	expectedGame := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(deck, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockGameRepo.On("CreateGameInstance", int64(1), int64(3), 3, 20).Return(expectedGame, nil)
+
+	// Act
+	result, err := gameService.StartSoloGame(1, &models.GameStartDTO{DeckID: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedGame, result)
+	mockGameRepo.AssertExpectations(t)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test StartSoloGame - Invalid Number Of Wrong Choices
+func TestGameService_StartSoloGame_InvalidNumWrongChoices(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	for _, numWrongChoices := range []int{0, 6} {
+		// Act
+		result, err := gameService.StartSoloGame(1, &models.GameStartDTO{DeckID: 3, NumWrongChoices: &numWrongChoices})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidGameSettings)
+		assert.Nil(t, result)
+	}
+	mockTriviaRepo.AssertNotCalled(t, "GetTriviaDeckById")
+	mockGameRepo.AssertNotCalled(t, "CreateGameInstance")
+}
+
+// Test StartSoloGame - Deck Not Found
+func TestGameService_StartSoloGame_DeckNotFound(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := gameService.StartSoloGame(1, &models.GameStartDTO{DeckID: 3})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeckNotFound)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateGameInstance")
+}
+
+// Test StartSoloGame - Deck Not Approved
+func TestGameService_StartSoloGame_DeckNotApproved(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3}, nil)
+
+	// Act
+	result, err := gameService.StartSoloGame(1, &models.GameStartDTO{DeckID: 3})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeckNotPlayable)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateGameInstance")
+}
+
+// Test StartSoloGame - Deck Without Playable Questions
+func TestGameService_StartSoloGame_NoPlayableQuestions(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	count := 0
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+
+	// Act
+	result, err := gameService.StartSoloGame(1, &models.GameStartDTO{DeckID: 3})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeckHasNoQuestions)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateGameInstance")
+}
+
+// ===========================================
+// GAMEPLAY TESTS
+// ===========================================
+
+// Test GetGame - Another Player's Game
+func TestGameService_GetGame_NotOwner(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 2, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+
+	// Act
+	result, err := gameService.GetGame(1, 10)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrGameNotFound)
+	assert.Nil(t, result)
+}
+
+// Test GetNextQuestion - Success
+func TestGameService_GetNextQuestion_Success(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	game.TotalCorrect = 1
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	count := 5
+
+	servedAt := time.Now().UTC()
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"Berlin", "Paris", "London", "Madrid"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(question, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(nil, sql.ErrNoRows)
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string{"geography"}, int64(42), "Paris", 3).Return([]string{"London", "Berlin", "Madrid"}, nil)
+	mockGameRepo.On("SaveServedQuestion", mock.MatchedBy(func(served *repositories.TriviaGameQuestionEntity) bool {
+		return len(served.Choices) == 4 && served.DeadlineAt.Sub(served.ServedAt) == 20*time.Second
+	})).Return(servedQuestion, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+
+	// Act
+	result, err := gameService.GetNextQuestion(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), result.QuestionID)
+	assert.Len(t, result.Choices, 4)
+	assert.Equal(t, "Berlin", result.Choices[0].Text)
+	assert.NotEmpty(t, result.Choices[0].ID)
+	assert.Equal(t, 2, result.QuestionNumber)
+	assert.Equal(t, 5, result.TotalQuestions)
+	assert.InDelta(t, 20000, result.TimeRemainingMs, 1000)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test GetNextQuestion - Served Choices Are Reused
+func TestGameService_GetNextQuestion_ReusesServedChoices(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	servedAt := time.Now().UTC().Add(-5 * time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	count := 5
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+
+	// Act
+	first, err := gameService.GetNextQuestion(1, 10)
+	assert.NoError(t, err)
+	second, err := gameService.GetNextQuestion(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, first.Choices, second.Choices)
+	assert.Equal(t, first.DeadlineAt, second.DeadlineAt)
+	mockTriviaRepo.AssertNotCalled(t, "GetRandomWrongAnswersByTags")
+	mockGameRepo.AssertNotCalled(t, "SaveServedQuestion")
+}
+
+// Test GetNextQuestion - Expired Question Is Recorded As Timed Out
+func TestGameService_GetNextQuestion_ExpiredQuestionTimesOut(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	game.CurrentStreak = 3
+
+	servedAt := time.Now().UTC().Add(-30 * time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil).Once()
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.IsTimedOut && !attempt.IsCorrect && attempt.Score == 0 && attempt.Streak == 0
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := gameService.GetNextQuestion(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test GetNextQuestion - Every Question Answered
+func TestGameService_GetNextQuestion_NoQuestionsRemaining(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := gameService.GetNextQuestion(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+// Test GetNextQuestion - Smart Deck Game Reaches The Question Cap
+func TestGameService_GetNextQuestion_SmartDeckQuestionCap(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	game.TotalCorrect = 15
+	game.TotalIncorrect = 5
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{
+		ID:         3,
+		IsApproved: true,
+		Rules:      &models.TriviaDeckRules{IncludeTags: []string{"science"}},
+	}, nil)
+
+	// Act
+	result, err := gameService.GetNextQuestion(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "GetNextUnansweredQuestion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test SubmitAnswer - Correct Answer Ignores Case And Extends The Streak
+func TestGameService_SubmitAnswer_Correct(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	game.TotalIncorrect = 2
+	game.CurrentStreak = 1
+	game.TotalScore = 150
+
+	servedAt := time.Now().UTC().Add(-5 * time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.PickedAnswer == " paris " && attempt.IsCorrect && attempt.Streak == 2 && attempt.ResponseTimeMs >= 5000
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: " paris "})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.False(t, result.IsTimedOut)
+	assert.Equal(t, "Paris", result.CorrectAnswer)
+	assert.Equal(t, 2, result.Streak)
+	assert.Greater(t, result.Score, 0)
+	assert.Equal(t, 150+result.Score, result.TotalScore)
+	assert.Equal(t, 1, result.TotalCorrect)
+	assert.Equal(t, 2, result.TotalIncorrect)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test SubmitAnswer - Correct Choice ID
+func TestGameService_SubmitAnswer_ByChoiceID(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	choiceService := NewChoiceService(mockTriviaRepo)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, choiceService)
+
+	servedAt := time.Now().UTC().Add(-time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.PickedAnswer == "Paris" && attempt.IsCorrect
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+
+	choiceId := choiceService.ChoiceID(10, 42, "Paris")
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, ChoiceID: choiceId})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.Equal(t, choiceId, result.CorrectChoiceID)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test SubmitAnswer - Unknown Choice ID
+func TestGameService_SubmitAnswer_UnknownChoiceID(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	servedAt := time.Now().UTC().Add(-time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, ChoiceID: "not-a-choice"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAnswer)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateQuestionAttempt")
+}
+
+// Test SubmitAnswer - Incorrect Answer Resets The Streak
+func TestGameService_SubmitAnswer_Incorrect(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	game.CurrentStreak = 4
+
+	servedAt := time.Now().UTC().Add(-time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.PickedAnswer == "London" && !attempt.IsCorrect && attempt.Score == 0 && attempt.Streak == 0
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "London"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.IsCorrect)
+	assert.Equal(t, 0, result.Streak)
+	assert.Equal(t, 0, result.TotalCorrect)
+	assert.Equal(t, 1, result.TotalIncorrect)
+}
+
+// Test SubmitAnswer - An Answer Racing Another One Or The End Of The Game Is Rejected
+func TestGameService_SubmitAnswer_AttemptConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		repoErr  error
+		expected error
+	}{
+		{repoErr: repositories.ErrDuplicateAttempt, expected: ErrQuestionAlreadyAnswered},
+		{repoErr: sql.ErrNoRows, expected: ErrGameEnded},
+	} {
+		// Arrange
+		mockGameRepo := new(MockGameRepository)
+		mockTriviaRepo := new(MockTriviaRepository)
+		gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+		servedAt := time.Now().UTC().Add(-time.Second)
+		servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+		mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+		mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+		mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+		mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+		mockGameRepo.On("CreateQuestionAttempt", mock.Anything).Return(nil, tc.repoErr)
+
+		// Act
+		result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "Paris"})
+
+		// Assert
+		assert.ErrorIs(t, err, tc.expected)
+		assert.Nil(t, result)
+	}
+}
+
+// Test SubmitAnswer - Late Answer Is Timed Out
+func TestGameService_SubmitAnswer_AfterDeadline(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	servedAt := time.Now().UTC().Add(-25 * time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.PickedAnswer == "Paris" && attempt.IsTimedOut && !attempt.IsCorrect && attempt.Score == 0
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "Paris"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsTimedOut)
+	assert.False(t, result.IsCorrect)
+	assert.Equal(t, 0, result.Score)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test SubmitAnswer - Question Not Served
+func TestGameService_SubmitAnswer_NotServed(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "Paris"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAnswer)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateQuestionAttempt")
+}
+
+// Test SubmitAnswer - Not The Current Question
+func TestGameService_SubmitAnswer_WrongQuestion(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetNextUnansweredQuestion", int64(10), int64(3), noDeckRules).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 7, Answer: "Paris"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAnswer)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateQuestionAttempt")
+}
+
+// Test SubmitAnswer - Game Already Ended
+func TestGameService_SubmitAnswer_GameEnded(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	endedAt := time.Now()
+	game.EndedAt = &endedAt
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "Paris"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrGameEnded)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateQuestionAttempt")
+}
+
+// Test SubmitAnswer - Room Games Are Answered Through The Room
+func TestGameService_SubmitAnswer_RoomGame(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	roomId := int64(5)
+	game.RoomID = &roomId
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+
+	// Act
+	result, err := gameService.SubmitAnswer(1, 10, &models.GameAnswerDTO{QuestionID: 42, Answer: "Paris"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRoomGame)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateQuestionAttempt")
+}
+
+// Test AnswerQuestionInGame - No Choice Is Recorded As Timed Out
+func TestGameService_AnswerQuestionInGame_NoChoiceTimesOut(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	servedAt := time.Now().UTC().Add(-5 * time.Second)
+	servedQuestion := &repositories.TriviaGameQuestionEntity{GameInstanceID: 10, QuestionID: 42, Choices: pq.StringArray{"London", "Paris"}, ServedAt: servedAt, DeadlineAt: servedAt.Add(20 * time.Second)}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockTriviaRepo.On("GetQuestionById", int64(42)).Return(&repositories.TriviaQuestionEntity{ID: 42, IsPublished: true, Question: "What is the capital of France?", CorrectAnswer: "Paris", Tags: pq.StringArray{"geography"}}, nil)
+	mockGameRepo.On("GetServedQuestion", int64(10), int64(42)).Return(servedQuestion, nil)
+	mockGameRepo.On("CreateQuestionAttempt", mock.MatchedBy(func(attempt *repositories.TriviaQuestionAttemptEntity) bool {
+		return attempt.PickedAnswer == "" && attempt.IsTimedOut && !attempt.IsCorrect && attempt.Score == 0
+	})).Return(&repositories.TriviaQuestionAttemptEntity{ID: 1}, nil)
+
+	// Act
+	result, err := gameService.AnswerQuestionInGame(10, 42, "", time.Now().UTC())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsTimedOut)
+	assert.Equal(t, 1, result.TotalIncorrect)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// ===========================================
+// SCORING TESTS
+// ===========================================
+
+// Test CalculateAnswerScore - Speed And Streak Bonuses
+func TestCalculateAnswerScore(t *testing.T) {
+	limit := 20 * time.Second
+
+	testCases := []struct {
+		name         string
+		isCorrect    bool
+		responseTime time.Duration
+		streak       int
+		expected     int
+	}{
+		{"incorrect answer", false, time.Second, 0, 0},
+		{"instant answer", true, 0, 1, 200},
+		{"half the time", true, 10 * time.Second, 1, 150},
+		{"at the deadline", true, limit, 1, 100},
+		{"past the deadline", true, 30 * time.Second, 1, 100},
+		{"third in a streak", true, 10 * time.Second, 3, 180},
+		{"streak bonus is capped", true, 10 * time.Second, 20, 225},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CalculateAnswerScore(tc.isCorrect, tc.responseTime, limit, tc.streak))
+		})
+	}
+}
+
+// ===========================================
+// END GAME TESTS
+// ===========================================
+
+// Test EndGame - Success
+func TestGameService_EndGame_Success(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	endedGame := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	endedAt := time.Now()
+	endedGame.EndedAt = &endedAt
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockGameRepo.On("EndGameInstance", int64(10)).Return(endedGame, nil)
+
+	// Act
+	result, err := gameService.EndGame(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result.EndedAt)
+	mockGameRepo.AssertExpectations(t)
+}
+
+// Test EndGame - Game Ended Hooks Run With The Ended Game
+func TestGameService_EndGame_RunsHooks(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	endedGame := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	endedAt := time.Now()
+	endedGame.EndedAt = &endedAt
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+	mockGameRepo.On("EndGameInstance", int64(10)).Return(endedGame, nil)
+
+	hookedGames := []*repositories.TriviaGameInstanceEntity{}
+	gameService.OnGameEnded(func(game *repositories.TriviaGameInstanceEntity) {
+		hookedGames = append(hookedGames, game)
+	})
+
+	// Act
+	_, err := gameService.EndGame(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*repositories.TriviaGameInstanceEntity{endedGame}, hookedGames)
+}
+
+// Test FinishGame - Hooks Do Not Run Again For A Game That Already Ended
+func TestGameService_FinishGame_AlreadyEndedSkipsHooks(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("EndGameInstance", int64(10)).Return(nil, sql.ErrNoRows)
+	hookCalls := 0
+	gameService.OnGameEnded(func(game *repositories.TriviaGameInstanceEntity) {
+		hookCalls++
+	})
+
+	// Act
+	err := gameService.FinishGame(10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, hookCalls)
+}
+
+// Test EndGame - Already Ended
+func TestGameService_EndGame_AlreadyEnded(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	endedAt := time.Now()
+	game.EndedAt = &endedAt
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+
+	// Act
+	result, err := gameService.EndGame(1, 10)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrGameEnded)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "EndGameInstance")
+}
+
+// ===========================================
+// GAME SUMMARY TESTS
+// ===========================================
+
+// Test GetGameSummary - Success
+func TestGameService_GetGameSummary_Success(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	game := &repositories.TriviaGameInstanceEntity{
+		ID:               10,
+		UserID:           1,
+		DeckID:           3,
+		StartedAt:        time.Now(),
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+	}
+	endedAt := time.Now()
+	game.EndedAt = &endedAt
+	game.TotalCorrect = 3
+	game.TotalIncorrect = 1
+	game.TotalScore = 500
+	game.BestStreak = 2
+
+	attempts := []*repositories.TriviaQuestionAttemptDetailEntity{
+		{
+			TriviaQuestionAttemptEntity: repositories.TriviaQuestionAttemptEntity{GameInstanceID: 10, QuestionID: 1, IsCorrect: true, ResponseTimeMs: 2000},
+			Tags:                        pq.StringArray{"geography", "europe"},
+		},
+		{
+			TriviaQuestionAttemptEntity: repositories.TriviaQuestionAttemptEntity{GameInstanceID: 10, QuestionID: 2, IsCorrect: true, ResponseTimeMs: 4000},
+			Tags:                        pq.StringArray{"geography"},
+		},
+		{
+			TriviaQuestionAttemptEntity: repositories.TriviaQuestionAttemptEntity{GameInstanceID: 10, QuestionID: 3, IsTimedOut: true, ResponseTimeMs: 21000},
+			Tags:                        pq.StringArray{"history"},
+		},
+		{
+			TriviaQuestionAttemptEntity: repositories.TriviaQuestionAttemptEntity{GameInstanceID: 10, QuestionID: 4, IsCorrect: true, ResponseTimeMs: 6000},
+			Tags:                        pq.StringArray{"history"},
+		},
+	}
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(game, nil)
+	mockGameRepo.On("GetGameAttemptDetails", int64(10)).Return(attempts, nil)
+	mockGameRepo.On("GetPlayerGameAverages", int64(1), int64(10)).Return(&models.GameAverages{GamesPlayed: 2, AverageScore: 400, AverageAccuracy: 50}, nil)
+	mockGameRepo.On("GetDeckGameAverages", int64(3), int64(10)).Return(&models.GameAverages{}, nil)
+
+	// Act
+	result, err := gameService.GetGameSummary(1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.TotalQuestions)
+	assert.Equal(t, 1, result.TotalTimedOut)
+	assert.Equal(t, 75.0, result.Accuracy)
+	assert.Equal(t, 2, result.LongestStreak)
+	assert.Equal(t, 4000, result.AverageResponseTimeMs)
+	assert.Len(t, result.Questions, 4)
+	assert.Equal(t, "geography", *result.BestTag)
+	assert.Equal(t, "history", *result.WorstTag)
+	assert.Equal(t, 100.0, result.PlayerComparison.ScoreDifference)
+	assert.Equal(t, 25.0, result.PlayerComparison.AccuracyDifference)
+	assert.Equal(t, 0, result.DeckComparison.GamesPlayed)
+	assert.Equal(t, 0.0, result.DeckComparison.ScoreDifference)
+}
+
+// Test GetGameSummary - Game Still In Progress
+func TestGameService_GetGameSummary_InProgress(t *testing.T) {
+	// Arrange
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	gameService := NewGameService(mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo))
+
+	mockGameRepo.On("GetGameInstanceById", int64(10)).Return(&repositories.TriviaGameInstanceEntity{ID: 10, UserID: 1, DeckID: 3, StartedAt: time.Now(), NumWrongChoices: 3, TimeLimitSeconds: 20}, nil)
+
+	// Act
+	result, err := gameService.GetGameSummary(1, 10)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrGameInProgress)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "GetGameAttemptDetails")
+}
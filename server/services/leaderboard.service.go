@@ -0,0 +1,154 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+var (
+	ErrLeaderboardNotFound     = errors.New("leaderboard not found")
+	ErrInvalidLeaderboardQuery = errors.New("invalid leaderboard request")
+)
+
+const (
+	maxLeaderboardPageSize  = 100
+	maxLeaderboardNeighbors = 10
+)
+
+type ILeaderboardService interface {
+	GetLeaderboard(board string, period string, pageSize int, offset int) (*models.Leaderboard, error)
+	GetPlayerStanding(board string, period string, userId int64, neighbors int) (*models.LeaderboardStanding, error)
+}
+
+type LeaderboardService struct {
+	leaderboardRepository repositories.ILeaderboardRepository
+	triviaRepository      repositories.ITriviaRepository
+}
+
+func NewLeaderboardService(leaderboardRepository repositories.ILeaderboardRepository, triviaRepository repositories.ITriviaRepository) ILeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepository: leaderboardRepository,
+		triviaRepository:      triviaRepository,
+	}
+}
+
+// GetLeaderboard returns a page of a board for the current period, highest score first. The board
+// is global, deck:<id> or tag:<tag>.
+func (s *LeaderboardService) GetLeaderboard(board string, period string, pageSize int, offset int) (*models.Leaderboard, error) {
+	board, periodStart, err := s.resolveBoard(board, period)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize > maxLeaderboardPageSize {
+		pageSize = maxLeaderboardPageSize
+	}
+
+	entries, err := s.leaderboardRepository.GetLeaderboard(board, period, periodStart, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.leaderboardRepository.GetLeaderboardCount(board, period, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Leaderboard{
+		Board:       board,
+		Period:      period,
+		PeriodStart: periodStart,
+		PageSize:    pageSize,
+		Page:        (offset / pageSize) + 1,
+		Total:       *count,
+		Results:     withAccuracy(entries),
+	}, nil
+}
+
+// GetPlayerStanding returns a player's rank on a board for the current period along with
+// the players ranked around them.
+func (s *LeaderboardService) GetPlayerStanding(board string, period string, userId int64, neighbors int) (*models.LeaderboardStanding, error) {
+	board, periodStart, err := s.resolveBoard(board, period)
+	if err != nil {
+		return nil, err
+	}
+	if neighbors < 0 || neighbors > maxLeaderboardNeighbors {
+		return nil, fmt.Errorf("%w: neighbors must be between 0 and 10", ErrInvalidLeaderboardQuery)
+	}
+
+	entries, err := s.leaderboardRepository.GetLeaderboardNeighborhood(board, period, periodStart, userId, neighbors)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.leaderboardRepository.GetLeaderboardCount(board, period, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	standing := &models.LeaderboardStanding{
+		Board:       board,
+		Period:      period,
+		PeriodStart: periodStart,
+		Total:       *count,
+		Nearby:      withAccuracy(entries),
+	}
+	for i := range standing.Nearby {
+		if standing.Nearby[i].UserID == userId {
+			standing.Entry = &standing.Nearby[i]
+			break
+		}
+	}
+	return standing, nil
+}
+
+// resolveBoard checks the board and period, returning the board's stored key and when the current
+// period started. Deck boards must belong to an existing deck and tags are normalized.
+func (s *LeaderboardService) resolveBoard(board string, period string) (string, time.Time, error) {
+	periodStart, err := models.LeaderboardPeriodStart(period, time.Now())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %v", ErrInvalidLeaderboardQuery, err)
+	}
+
+	if board == models.LeaderboardGlobal {
+		return board, periodStart, nil
+	}
+	if deckIdStr, ok := strings.CutPrefix(board, models.LeaderboardDeckPrefix); ok {
+		deckId, err := strconv.ParseInt(deckIdStr, 10, 64)
+		if err != nil {
+			return "", time.Time{}, ErrLeaderboardNotFound
+		}
+		_, err = s.triviaRepository.GetTriviaDeckById(deckId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", time.Time{}, ErrLeaderboardNotFound
+		}
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return models.DeckLeaderboardKey(deckId), periodStart, nil
+	}
+	if tag, ok := strings.CutPrefix(board, models.LeaderboardTagPrefix); ok {
+		tags := normalizeTags([]string{tag})
+		if len(tags) == 0 {
+			return "", time.Time{}, ErrLeaderboardNotFound
+		}
+		return models.TagLeaderboardKey(tags[0]), periodStart, nil
+	}
+	return "", time.Time{}, ErrLeaderboardNotFound
+}
+
+// withAccuracy copies the entries and fills in each player's accuracy.
+func withAccuracy(entries []*models.LeaderboardEntry) []models.LeaderboardEntry {
+	results := make([]models.LeaderboardEntry, len(entries))
+	for i, entry := range entries {
+		results[i] = *entry
+		results[i].Accuracy = percentage(entry.TotalCorrect, entry.TotalAnswered)
+	}
+	return results
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLeaderboardRepository is a mock implementation of ILeaderboardRepository
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboardCount(boardKey string, period string, periodStart time.Time) (*int, error) {
+	args := m.Called(boardKey, period, periodStart)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboard(boardKey string, period string, periodStart time.Time, pageSize int, offset int) ([]*models.LeaderboardEntry, error) {
+	args := m.Called(boardKey, period, periodStart, pageSize, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboardNeighborhood(boardKey string, period string, periodStart time.Time, userId int64, neighbors int) ([]*models.LeaderboardEntry, error) {
+	args := m.Called(boardKey, period, periodStart, userId, neighbors)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LeaderboardEntry), args.Error(1)
+}
+
+// ===========================================
+// LEADERBOARD PERIOD TESTS
+// ===========================================
+
+// Test LeaderboardPeriodStart - Each Period Starts At UTC Midnight
+func TestLeaderboardPeriodStart(t *testing.T) {
+	// Arrange
+	at := time.Date(2026, time.October, 16, 23, 30, 0, 0, time.UTC) // A Friday
+
+	// Act
+	allTime, allTimeErr := models.LeaderboardPeriodStart(models.LeaderboardPeriodAllTime, at)
+	monthly, monthlyErr := models.LeaderboardPeriodStart(models.LeaderboardPeriodMonthly, at)
+	weekly, weeklyErr := models.LeaderboardPeriodStart(models.LeaderboardPeriodWeekly, at)
+	daily, dailyErr := models.LeaderboardPeriodStart(models.LeaderboardPeriodDaily, at)
+	_, invalidErr := models.LeaderboardPeriodStart("yearly", at)
+
+	// Assert
+	assert.NoError(t, allTimeErr)
+	assert.NoError(t, monthlyErr)
+	assert.NoError(t, weeklyErr)
+	assert.NoError(t, dailyErr)
+	assert.Equal(t, time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC), allTime)
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), monthly)
+	assert.Equal(t, time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC), weekly)
+	assert.Equal(t, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), daily)
+	assert.Error(t, invalidErr)
+}
+
+// Test LeaderboardPeriodStart - Sunday Belongs To The Week Starting The Monday Before
+func TestLeaderboardPeriodStart_SundayWeek(t *testing.T) {
+	// Arrange
+	at := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	weekly, err := models.LeaderboardPeriodStart(models.LeaderboardPeriodWeekly, at)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC), weekly)
+}
+
+// ===========================================
+// LEADERBOARD TESTS
+// ===========================================
+
+// Test GetLeaderboard - Success
+func TestLeaderboardService_GetLeaderboard_Success(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	periodStart, _ := models.LeaderboardPeriodStart(models.LeaderboardPeriodWeekly, time.Now())
+	count := 30
+	entries := []*models.LeaderboardEntry{
+		{Rank: 26, UserID: 4, DisplayName: "Ada", TotalScore: 900, TotalCorrect: 3, TotalAnswered: 4},
+		{Rank: 26, UserID: 9, DisplayName: "Grace", TotalScore: 900, TotalCorrect: 0, TotalAnswered: 0}, // Guards against dividing by zero
+	}
+	mockLeaderboardRepo.On("GetLeaderboard", "global", models.LeaderboardPeriodWeekly, periodStart, 25, 25).Return(entries, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "global", models.LeaderboardPeriodWeekly, periodStart).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetLeaderboard("global", models.LeaderboardPeriodWeekly, 25, 25)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 30, result.Total)
+	assert.Equal(t, periodStart, result.PeriodStart)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, 75.0, result.Results[0].Accuracy)
+	assert.Equal(t, 0.0, result.Results[1].Accuracy)
+	mockLeaderboardRepo.AssertExpectations(t)
+}
+
+// Test GetLeaderboard - Page Size Is Capped
+func TestLeaderboardService_GetLeaderboard_PageSizeCapped(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	count := 0
+	mockLeaderboardRepo.On("GetLeaderboard", "global", models.LeaderboardPeriodAllTime, mock.Anything, maxLeaderboardPageSize, 0).Return([]*models.LeaderboardEntry{}, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "global", models.LeaderboardPeriodAllTime, mock.Anything).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetLeaderboard("global", models.LeaderboardPeriodAllTime, 1000, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, maxLeaderboardPageSize, result.PageSize)
+	assert.Empty(t, result.Results)
+}
+
+// Test GetLeaderboard - Invalid Period Or Board
+func TestLeaderboardService_GetLeaderboard_Invalid(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	// Act
+	_, periodErr := leaderboardService.GetLeaderboard("global", "yearly", 25, 0)
+	_, boardErr := leaderboardService.GetLeaderboard("regional", models.LeaderboardPeriodDaily, 25, 0)
+
+	// Assert
+	assert.Error(t, periodErr)
+	assert.ErrorIs(t, boardErr, ErrLeaderboardNotFound)
+	mockLeaderboardRepo.AssertNotCalled(t, "GetLeaderboard")
+}
+
+// Test GetLeaderboard - Deck Board
+func TestLeaderboardService_GetLeaderboard_Deck(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, mockTriviaRepo)
+
+	count := 1
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3}, nil)
+	mockLeaderboardRepo.On("GetLeaderboard", "deck:3", models.LeaderboardPeriodAllTime, mock.Anything, 25, 0).Return([]*models.LeaderboardEntry{{Rank: 1, UserID: 1}}, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "deck:3", models.LeaderboardPeriodAllTime, mock.Anything).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetLeaderboard(models.DeckLeaderboardKey(3), models.LeaderboardPeriodAllTime, 25, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "deck:3", result.Board)
+	assert.Len(t, result.Results, 1)
+	mockLeaderboardRepo.AssertExpectations(t)
+}
+
+// Test GetLeaderboard - Deck Does Not Exist
+func TestLeaderboardService_GetLeaderboard_DeckNotFound(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, mockTriviaRepo)
+
+	mockTriviaRepo.On("GetTriviaDeckById", int64(99)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	_, err := leaderboardService.GetLeaderboard(models.DeckLeaderboardKey(99), models.LeaderboardPeriodAllTime, 25, 0)
+	_, invalidErr := leaderboardService.GetLeaderboard("deck:abc", models.LeaderboardPeriodAllTime, 25, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrLeaderboardNotFound)
+	assert.ErrorIs(t, invalidErr, ErrLeaderboardNotFound)
+	mockLeaderboardRepo.AssertNotCalled(t, "GetLeaderboard")
+}
+
+// Test GetLeaderboard - Tag Boards Are Looked Up By Their Normalized Tag
+func TestLeaderboardService_GetLeaderboard_Tag(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	count := 0
+	mockLeaderboardRepo.On("GetLeaderboard", "tag:science", models.LeaderboardPeriodWeekly, mock.Anything, 25, 0).Return([]*models.LeaderboardEntry{}, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "tag:science", models.LeaderboardPeriodWeekly, mock.Anything).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetLeaderboard(models.LeaderboardTagPrefix+" Science ", models.LeaderboardPeriodWeekly, 25, 0)
+	_, emptyErr := leaderboardService.GetLeaderboard(models.LeaderboardTagPrefix+" ", models.LeaderboardPeriodWeekly, 25, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "tag:science", result.Board)
+	assert.ErrorIs(t, emptyErr, ErrLeaderboardNotFound)
+	mockLeaderboardRepo.AssertExpectations(t)
+}
+
+// Test GetPlayerStanding - Player Is Found Among Their Neighbors
+func TestLeaderboardService_GetPlayerStanding_Success(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	count := 12
+	entries := []*models.LeaderboardEntry{
+		{Rank: 3, UserID: 4, TotalScore: 1200},
+		{Rank: 4, UserID: 1, TotalScore: 1100, TotalCorrect: 1, TotalAnswered: 2},
+		{Rank: 5, UserID: 9, TotalScore: 800},
+	}
+	mockLeaderboardRepo.On("GetLeaderboardNeighborhood", "global", models.LeaderboardPeriodDaily, mock.Anything, int64(1), 1).Return(entries, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "global", models.LeaderboardPeriodDaily, mock.Anything).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetPlayerStanding("global", models.LeaderboardPeriodDaily, 1, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 12, result.Total)
+	assert.Len(t, result.Nearby, 3)
+	assert.NotNil(t, result.Entry)
+	assert.Equal(t, 4, result.Entry.Rank)
+	assert.Equal(t, 50.0, result.Entry.Accuracy)
+}
+
+// Test GetPlayerStanding - Player Has Not Scored This Period
+func TestLeaderboardService_GetPlayerStanding_NotRanked(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	count := 12
+	mockLeaderboardRepo.On("GetLeaderboardNeighborhood", "global", models.LeaderboardPeriodMonthly, mock.Anything, int64(1), 2).Return([]*models.LeaderboardEntry{}, nil)
+	mockLeaderboardRepo.On("GetLeaderboardCount", "global", models.LeaderboardPeriodMonthly, mock.Anything).Return(&count, nil)
+
+	// Act
+	result, err := leaderboardService.GetPlayerStanding("global", models.LeaderboardPeriodMonthly, 1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.Entry)
+	assert.Empty(t, result.Nearby)
+}
+
+// Test GetPlayerStanding - Too Many Neighbors
+func TestLeaderboardService_GetPlayerStanding_InvalidNeighbors(t *testing.T) {
+	// Arrange
+	mockLeaderboardRepo := new(MockLeaderboardRepository)
+	leaderboardService := NewLeaderboardService(mockLeaderboardRepo, new(MockTriviaRepository))
+
+	// Act
+	result, err := leaderboardService.GetPlayerStanding("global", models.LeaderboardPeriodDaily, 1, 50)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockLeaderboardRepo.AssertNotCalled(t, "GetLeaderboardNeighborhood")
+}
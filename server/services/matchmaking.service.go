@@ -0,0 +1,306 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+var (
+	ErrNotInQueue       = errors.New("you are not in the matchmaking queue")
+	ErrAlreadyInQueue   = errors.New("you are already in the matchmaking queue")
+	ErrMatchFound       = errors.New("a match has already been found")
+	ErrInvalidQueueJoin = errors.New("invalid matchmaking request")
+)
+
+const (
+	minMatchPlayers       = 2
+	matchedTicketLifetime = 2 * time.Minute // How long a match result stays available to the player
+	quickPlayDeckPrefix   = "Quick Play: "
+)
+
+type IMatchmakingService interface {
+	JoinQueue(userId int64, displayName string, dto *models.MatchmakingQueueDTO) (*models.MatchmakingStatus, error)
+	LeaveQueue(userId int64) error
+	GetStatus(userId int64) (*models.MatchmakingStatus, error)
+	WaitForMatch(userId int64, timeout time.Duration) (*models.MatchmakingStatus, error)
+}
+
+type matchmakingTicket struct {
+	userId      int64
+	displayName string
+	deckId      int64
+	queuedAt    time.Time
+	status      string
+	isMatching  bool // Taken off the queue while its room is being created
+	roomCode    *string
+	err         *string
+	done        chan struct{} // Closed once the ticket leaves the queue for any reason
+	waitTimer   *time.Timer   // Matches a smaller room once the player has waited too long
+}
+
+// MatchmakingService groups players waiting to play the same deck into rooms. Queues are first
+// come, first served and live in memory, so like rooms they only work on a single API instance.
+type MatchmakingService struct {
+	triviaRepository repositories.ITriviaRepository
+	gameRepository   repositories.IGameRepository
+	roomService      IRoomService
+	roomSize         int
+	maxWait          time.Duration
+
+	mu      sync.Mutex
+	queues  map[int64][]*matchmakingTicket // Keyed by deck
+	tickets map[int64]*matchmakingTicket   // Keyed by user
+
+	quickPlayDeckMu sync.Mutex // Stops two players creating the same quick play deck at once
+}
+
+func NewMatchmakingService(triviaRepository repositories.ITriviaRepository, gameRepository repositories.IGameRepository, roomService IRoomService, roomSize int, maxWaitSeconds int) IMatchmakingService {
+	return &MatchmakingService{
+		triviaRepository: triviaRepository,
+		gameRepository:   gameRepository,
+		roomService:      roomService,
+		roomSize:         roomSize,
+		maxWait:          time.Duration(maxWaitSeconds) * time.Second,
+		queues:           map[int64][]*matchmakingTicket{},
+		tickets:          map[int64]*matchmakingTicket{},
+	}
+}
+
+// JoinQueue puts a player in the queue for a deck, or for the quick play deck of a tag. A room
+// is opened as soon as enough players are waiting, or with fewer players once the longest
+// waiting player has waited too long.
+func (s *MatchmakingService) JoinQueue(userId int64, displayName string, dto *models.MatchmakingQueueDTO) (*models.MatchmakingStatus, error) {
+	if (dto.DeckID == nil) == (dto.Tag == nil) {
+		return nil, fmt.Errorf("%w: either deck_id or tag is required", ErrInvalidQueueJoin)
+	}
+
+	var deck *repositories.TriviaDeckEntity
+	var err error
+	if dto.DeckID != nil {
+		deck, err = getPlayableDeck(s.triviaRepository, s.gameRepository, *dto.DeckID)
+	} else {
+		deck, err = s.getOrCreateQuickPlayDeck(*dto.Tag)
+		if err == nil {
+			err = checkDeckPlayable(s.gameRepository, deck)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if ticket, ok := s.tickets[userId]; ok && ticket.status == models.MatchmakingStatusQueued {
+		s.mu.Unlock()
+		return nil, ErrAlreadyInQueue
+	}
+	ticket := &matchmakingTicket{
+		userId:      userId,
+		displayName: displayName,
+		deckId:      deck.ID,
+		queuedAt:    time.Now().UTC(),
+		status:      models.MatchmakingStatusQueued,
+		done:        make(chan struct{}),
+	}
+	s.tickets[userId] = ticket
+	s.queues[deck.ID] = append(s.queues[deck.ID], ticket)
+	// Check again once this player has waited long enough for a smaller room
+	ticket.waitTimer = time.AfterFunc(s.maxWait, func() {
+		s.matchQueue(deck.ID)
+	})
+	s.mu.Unlock()
+
+	s.matchQueue(deck.ID)
+
+	return s.GetStatus(userId)
+}
+
+func (s *MatchmakingService) LeaveQueue(userId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, ok := s.tickets[userId]
+	if !ok || ticket.status != models.MatchmakingStatusQueued {
+		return ErrNotInQueue
+	}
+	if ticket.isMatching {
+		return ErrMatchFound
+	}
+
+	queue := s.queues[ticket.deckId]
+	for i, queued := range queue {
+		if queued == ticket {
+			s.queues[ticket.deckId] = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(s.queues[ticket.deckId]) == 0 {
+		delete(s.queues, ticket.deckId)
+	}
+	delete(s.tickets, userId)
+	ticket.waitTimer.Stop()
+	close(ticket.done)
+	return nil
+}
+
+func (s *MatchmakingService) GetStatus(userId int64) (*models.MatchmakingStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, ok := s.tickets[userId]
+	if !ok {
+		return nil, ErrNotInQueue
+	}
+	return s.ticketStatus(ticket), nil
+}
+
+// WaitForMatch blocks until the player is matched, leaves the queue, or the timeout passes,
+// then returns the player's latest status. It lets clients long-poll for their match.
+func (s *MatchmakingService) WaitForMatch(userId int64, timeout time.Duration) (*models.MatchmakingStatus, error) {
+	s.mu.Lock()
+	ticket, ok := s.tickets[userId]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrNotInQueue
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ticket.done:
+	case <-timer.C:
+	}
+
+	return s.GetStatus(userId)
+}
+
+// matchQueue opens rooms for a deck's queue while there are enough players to fill one, then
+// opens a smaller room if the longest waiting player has waited too long.
+func (s *MatchmakingService) matchQueue(deckId int64) {
+	s.mu.Lock()
+	queue := s.queues[deckId]
+	var groups [][]*matchmakingTicket
+	for len(queue) >= s.roomSize {
+		groups = append(groups, queue[:s.roomSize:s.roomSize])
+		queue = queue[s.roomSize:]
+	}
+	if len(queue) >= minMatchPlayers && time.Since(queue[0].queuedAt) >= s.maxWait {
+		groups = append(groups, queue)
+		queue = nil
+	}
+	if len(queue) == 0 {
+		delete(s.queues, deckId)
+	} else {
+		s.queues[deckId] = queue
+	}
+	for _, group := range groups {
+		for _, ticket := range group {
+			ticket.isMatching = true
+		}
+	}
+	s.mu.Unlock()
+
+	for _, group := range groups {
+		s.openMatchedRoom(deckId, group)
+	}
+}
+
+// openMatchedRoom creates the room for a group of tickets and tells every player about it.
+func (s *MatchmakingService) openMatchedRoom(deckId int64, group []*matchmakingTicket) {
+	players := make([]models.RoomParticipant, 0, len(group))
+	for _, ticket := range group {
+		players = append(players, models.RoomParticipant{UserID: ticket.userId, DisplayName: ticket.displayName})
+	}
+
+	room, err := s.roomService.CreateMatchedRoom(deckId, players)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+
+	s.mu.Lock()
+	for _, ticket := range group {
+		if err != nil {
+			message := "failed to create a room for the match"
+			ticket.status = models.MatchmakingStatusFailed
+			ticket.err = &message
+		} else {
+			ticket.status = models.MatchmakingStatusMatched
+			ticket.roomCode = &room.Code
+		}
+		ticket.waitTimer.Stop()
+		close(ticket.done)
+	}
+	s.mu.Unlock()
+
+	// Keep the result around long enough for the players to pick it up
+	time.AfterFunc(matchedTicketLifetime, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, ticket := range group {
+			if s.tickets[ticket.userId] == ticket {
+				delete(s.tickets, ticket.userId)
+			}
+		}
+	})
+}
+
+// getOrCreateQuickPlayDeck returns the approved system smart deck that plays every published
+// question with a tag, creating it the first time the tag is queued for if the tag has any.
+func (s *MatchmakingService) getOrCreateQuickPlayDeck(tag string) (*repositories.TriviaDeckEntity, error) {
+	tags := normalizeTags([]string{tag})
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: tag is required", ErrInvalidQueueJoin)
+	}
+	name := quickPlayDeckPrefix + tags[0]
+
+	s.quickPlayDeckMu.Lock()
+	defer s.quickPlayDeckMu.Unlock()
+
+	deck, err := s.triviaRepository.GetSystemDeckByName(name)
+	if err == nil {
+		return deck, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Only persist a deck for a tag that has something to play
+	rules := &models.TriviaDeckRules{IncludeTags: tags, PublishedOnly: true}
+	count, err := s.triviaRepository.GetQuestionsMatchingDeckRulesCount(rules, "active")
+	if err != nil {
+		return nil, err
+	}
+	if *count == 0 {
+		return nil, fmt.Errorf("%w: the tag has no published questions", ErrDeckHasNoQuestions)
+	}
+
+	return s.triviaRepository.CreateApprovedSystemSmartDeck(name, fmt.Sprintf("Every published question tagged %v", tags[0]), rules)
+}
+
+// ticketStatus must be called with the service locked.
+func (s *MatchmakingService) ticketStatus(ticket *matchmakingTicket) *models.MatchmakingStatus {
+	status := &models.MatchmakingStatus{
+		Status:   ticket.status,
+		DeckID:   ticket.deckId,
+		QueuedAt: ticket.queuedAt,
+		RoomCode: ticket.roomCode,
+		Error:    ticket.err,
+	}
+	if ticket.status == models.MatchmakingStatusQueued {
+		queue := s.queues[ticket.deckId]
+		status.QueueSize = len(queue)
+		for i, queued := range queue {
+			if queued == ticket {
+				status.Position = i + 1
+				break
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoomService is a mock implementation of IRoomService
+type MockRoomService struct {
+	mock.Mock
+}
+
+func (m *MockRoomService) CreateRoom(userId int64, displayName string, dto *models.RoomCreateDTO) (*models.RoomState, error) {
+	args := m.Called(userId, displayName, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) CreateMatchedRoom(deckId int64, players []models.RoomParticipant) (*models.RoomState, error) {
+	args := m.Called(deckId, players)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) GetRoom(code string) (*models.RoomState, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) JoinRoom(code string, userId int64, displayName string) (*models.RoomState, error) {
+	args := m.Called(code, userId, displayName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) LeaveRoom(code string, userId int64) error {
+	args := m.Called(code, userId)
+	return args.Error(0)
+}
+
+func (m *MockRoomService) StartRoom(code string, userId int64) (*models.RoomState, error) {
+	args := m.Called(code, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) SetReady(code string, userId int64, isReady bool) (*models.RoomState, error) {
+	args := m.Called(code, userId, isReady)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) KickPlayer(code string, userId int64, dto *models.RoomKickDTO) (*models.RoomState, error) {
+	args := m.Called(code, userId, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoomState), args.Error(1)
+}
+
+func (m *MockRoomService) Connect(code string, userId int64, conn RoomConnection) error {
+	args := m.Called(code, userId, conn)
+	return args.Error(0)
+}
+
+func (m *MockRoomService) Disconnect(code string, userId int64, conn RoomConnection) {
+	m.Called(code, userId, conn)
+}
+
+func (m *MockRoomService) SubmitAnswer(code string, userId int64, message *models.RoomClientMessage) error {
+	args := m.Called(code, userId, message)
+	return args.Error(0)
+}
+
+func (m *MockRoomService) CloseStaleRooms() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// ===========================================
+// MATCHMAKING QUEUE TESTS
+// ===========================================
+
+// Test JoinQueue - Deck Or Tag Is Required
+func TestMatchmakingService_JoinQueue_MissingTarget(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, new(MockGameRepository), new(MockRoomService), 4, 60)
+
+	// Act
+	result, err := matchmakingService.JoinQueue(1, "Player", &models.MatchmakingQueueDTO{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "GetTriviaDeckById")
+}
+
+// Test JoinQueue - Waiting Players Are Reported In Order
+func TestMatchmakingService_JoinQueue_Queued(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockRoomService := new(MockRoomService)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, mockGameRepo, mockRoomService, 4, 60)
+	deckId := int64(3)
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", deckId, noDeckRules).Return(&count, nil)
+	// Stop the wait timers of the players left in the queue
+	t.Cleanup(func() {
+		matchmakingService.LeaveQueue(1)
+		matchmakingService.LeaveQueue(2)
+	})
+
+	// Act
+	first, err := matchmakingService.JoinQueue(1, "First", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+	second, err := matchmakingService.JoinQueue(2, "Second", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+	_, duplicateErr := matchmakingService.JoinQueue(1, "First", &models.MatchmakingQueueDTO{DeckID: &deckId})
+
+	// Assert
+	assert.Equal(t, models.MatchmakingStatusQueued, first.Status)
+	assert.Equal(t, 1, first.Position)
+	assert.Equal(t, 2, second.Position)
+	assert.Equal(t, 2, second.QueueSize)
+	assert.Error(t, duplicateErr)
+	mockRoomService.AssertNotCalled(t, "CreateMatchedRoom")
+}
+
+// Test JoinQueue - Full Queue Opens A Room In Queue Order
+func TestMatchmakingService_JoinQueue_FillsRoom(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockRoomService := new(MockRoomService)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, mockGameRepo, mockRoomService, 2, 60)
+	deckId := int64(3)
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", deckId, noDeckRules).Return(&count, nil)
+	mockRoomService.On("CreateMatchedRoom", int64(3), []models.RoomParticipant{
+		{UserID: 1, DisplayName: "First"},
+		{UserID: 2, DisplayName: "Second"},
+	}).Return(&models.RoomState{Code: "ABC234"}, nil)
+
+	// Act
+	_, err := matchmakingService.JoinQueue(1, "First", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+	result, err := matchmakingService.JoinQueue(2, "Second", &models.MatchmakingQueueDTO{DeckID: &deckId})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MatchmakingStatusMatched, result.Status)
+	assert.Equal(t, "ABC234", *result.RoomCode)
+	firstStatus, err := matchmakingService.GetStatus(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC234", *firstStatus.RoomCode)
+	mockRoomService.AssertExpectations(t)
+}
+
+// Test JoinQueue - Smaller Room Once The Oldest Player Has Waited Too Long
+func TestMatchmakingService_JoinQueue_StartsEarlyAfterTimeout(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockRoomService := new(MockRoomService)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, mockGameRepo, mockRoomService, 4, 60).(*MatchmakingService)
+	matchmakingService.maxWait = 20 * time.Millisecond
+	deckId := int64(3)
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", deckId, noDeckRules).Return(&count, nil)
+	mockRoomService.On("CreateMatchedRoom", int64(3), mock.Anything).Return(&models.RoomState{Code: "ABC234"}, nil)
+
+	_, err := matchmakingService.JoinQueue(1, "First", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+	_, err = matchmakingService.JoinQueue(2, "Second", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+
+	// Act
+	result, err := matchmakingService.WaitForMatch(2, time.Second)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MatchmakingStatusMatched, result.Status)
+	mockRoomService.AssertNumberOfCalls(t, "CreateMatchedRoom", 1)
+}
+
+// Test LeaveQueue - Players Behind Move Up
+func TestMatchmakingService_LeaveQueue_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameRepo := new(MockGameRepository)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, mockGameRepo, new(MockRoomService), 4, 60)
+	deckId := int64(3)
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", deckId, noDeckRules).Return(&count, nil)
+	t.Cleanup(func() { matchmakingService.LeaveQueue(2) })
+
+	_, err := matchmakingService.JoinQueue(1, "First", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+	_, err = matchmakingService.JoinQueue(2, "Second", &models.MatchmakingQueueDTO{DeckID: &deckId})
+	assert.NoError(t, err)
+
+	// Act
+	err = matchmakingService.LeaveQueue(1)
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = matchmakingService.GetStatus(1)
+	assert.ErrorIs(t, err, ErrNotInQueue)
+	result, err := matchmakingService.GetStatus(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Position)
+	assert.ErrorIs(t, matchmakingService.LeaveQueue(1), ErrNotInQueue)
+}
+
+// Test JoinQueue - Tag Creates Its Quick Play Deck
+func TestMatchmakingService_JoinQueue_TagCreatesQuickPlayDeck(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameRepo := new(MockGameRepository)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, mockGameRepo, new(MockRoomService), 4, 60)
+	tag := " Geography "
+	rules := &models.TriviaDeckRules{IncludeTags: []string{"geography"}, PublishedOnly: true}
+	count := 25
+
+	mockTriviaRepo.On("GetSystemDeckByName", "Quick Play: geography").Return(nil, sql.ErrNoRows)
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRulesCount", rules, "active").Return(&count, nil)
+	mockTriviaRepo.On("CreateApprovedSystemSmartDeck", "Quick Play: geography", mock.Anything, rules).Return(&repositories.TriviaDeckEntity{ID: 9, IsApproved: true, IsSystemDeck: true, Rules: rules}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(9), rules).Return(&count, nil)
+
+	t.Cleanup(func() { matchmakingService.LeaveQueue(1) })
+
+	// Act
+	result, err := matchmakingService.JoinQueue(1, "Player", &models.MatchmakingQueueDTO{Tag: &tag})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), result.DeckID)
+	assert.Equal(t, 1, result.Position)
+	mockTriviaRepo.AssertCalled(t, "CreateApprovedSystemSmartDeck", "Quick Play: geography", mock.Anything, rules)
+}
+
+// Test JoinQueue - Tag Without Playable Questions Does Not Create A Deck
+func TestMatchmakingService_JoinQueue_TagWithoutQuestions(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	matchmakingService := NewMatchmakingService(mockTriviaRepo, new(MockGameRepository), new(MockRoomService), 4, 60)
+	tag := "made-up"
+	rules := &models.TriviaDeckRules{IncludeTags: []string{"made-up"}, PublishedOnly: true}
+	count := 0
+
+	mockTriviaRepo.On("GetSystemDeckByName", "Quick Play: made-up").Return(nil, sql.ErrNoRows)
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRulesCount", rules, "active").Return(&count, nil)
+
+	// Act
+	result, err := matchmakingService.JoinQueue(1, "Player", &models.MatchmakingQueueDTO{Tag: &tag})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "CreateApprovedSystemSmartDeck", mock.Anything, mock.Anything, mock.Anything)
+}
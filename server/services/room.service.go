@@ -0,0 +1,1295 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/snowlynxsoftware/oto-api/server/util"
+)
+
+var (
+	ErrRoomNotFound   = errors.New("room not found")
+	ErrNotInRoom      = errors.New("you are not in this room")
+	ErrNotRoomHost    = errors.New("only the host can perform this action")
+	ErrRoomNotWaiting = errors.New("room is no longer accepting players")
+	ErrRoomFull       = errors.New("room is full")
+	ErrBannedFromRoom = errors.New("you have been banned from this room")
+	ErrTooManyRooms   = errors.New("you already have too many open rooms")
+	ErrRoomStarted    = errors.New("room has already started")
+	ErrInvalidKick    = errors.New("invalid kick")
+)
+
+const (
+	roomCodeAlphabet            = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // No 0/O or 1/I to keep codes easy to read out
+	roomCodeLength              = 6
+	roomCodeAttempts            = 5
+	defaultRoomQuestionCount    = 10
+	maxRoomQuestionCount        = 50
+	roomCountdownSeconds        = 3
+	roomRevealDuration          = 5 * time.Second
+	defaultRoomMaxPlayers       = 8
+	minRoomMaxPlayers           = 2
+	maxRoomMaxPlayers           = 20
+	minAutoStartSeconds         = 10
+	maxAutoStartSeconds         = 300
+	minPlayersToAutoStart       = 2
+	roomReadyStartDelay         = 5 * time.Second  // Gives players a moment to unready once everyone is ready
+	roomReconnectGracePeriod    = 30 * time.Second // How long a lobby keeps a disconnected player's spot
+	matchedRoomAutoStartSeconds = 15
+	roomIdleTimeout             = 10 * time.Minute // How long a lobby stays open with nobody connected
+	maxOpenRoomsPerHost         = 3
+)
+
+// RoomConnection is a participant's live connection to a room. The controller wraps the
+// WebSocket so the service never has to know about the transport. Send must not block, since
+// events go out to every player from the game loop.
+type RoomConnection interface {
+	Send(event *models.RoomEvent) error
+	Close() error
+}
+
+type IRoomService interface {
+	CreateRoom(userId int64, displayName string, dto *models.RoomCreateDTO) (*models.RoomState, error)
+	CreateMatchedRoom(deckId int64, players []models.RoomParticipant) (*models.RoomState, error)
+	GetRoom(code string) (*models.RoomState, error)
+	JoinRoom(code string, userId int64, displayName string) (*models.RoomState, error)
+	LeaveRoom(code string, userId int64) error
+	StartRoom(code string, userId int64) (*models.RoomState, error)
+	SetReady(code string, userId int64, isReady bool) (*models.RoomState, error)
+	KickPlayer(code string, userId int64, dto *models.RoomKickDTO) (*models.RoomState, error)
+	Connect(code string, userId int64, conn RoomConnection) error
+	Disconnect(code string, userId int64, conn RoomConnection)
+	SubmitAnswer(code string, userId int64, message *models.RoomClientMessage) error
+	CloseStaleRooms() error
+}
+
+type roomPlayer struct {
+	userId        int64
+	displayName   string
+	joinedAt      time.Time
+	isReady       bool
+	conn          RoomConnection
+	connVersion   int // Bumped on every connect and disconnect so stale reconnect timers can be ignored
+	gameId        int64
+	totalScore    int
+	totalCorrect  int
+	hasAnswered   bool
+	lastResult    *models.GameAnswerResult
+	questionEvent *models.RoomEvent // Resent when the player reconnects mid-question
+}
+
+type activeRoom struct {
+	mu              sync.Mutex
+	room            *repositories.TriviaGameRoomEntity
+	players         map[int64]*roomPlayer
+	currentQuestion *repositories.TriviaQuestionEntity
+	answered        chan struct{} // Signalled every time a player answers the current question
+	banned          map[int64]bool
+	isStarting      bool // Set while beginGame saves the games, so the lobby cannot change under it
+
+	// Lobby countdown, see scheduleStart
+	autoStartAt  *time.Time
+	readyStartAt *time.Time
+	startsAt     *time.Time
+	startTimer   *time.Timer
+	startVersion int
+
+	// Lobby idle timeout, see scheduleIdleClose
+	idleTimer   *time.Timer
+	idleVersion int
+
+	// Deadline for answers to the current question, and the answers accepted before it that
+	// are still being saved. The reveal waits for those so it never reports them as timed out.
+	answerDeadline time.Time
+	pendingAnswers sync.WaitGroup
+}
+
+// RoomService keeps the live state of every open room in memory, so all players of a room
+// have to be connected to the same API instance.
+type RoomService struct {
+	roomRepository   repositories.IRoomRepository
+	gameRepository   repositories.IGameRepository
+	triviaRepository repositories.ITriviaRepository
+	choiceService    IChoiceService
+	gameService      IGameService
+
+	mu       sync.Mutex
+	rooms    map[string]*activeRoom
+	createMu sync.Mutex // Serializes CreateRoom so a host cannot get past the open room cap
+
+	// Pacing of a room game, shortened in tests
+	countdownSeconds     int
+	countdownTick        time.Duration
+	revealDuration       time.Duration
+	readyStartDelay      time.Duration
+	reconnectGracePeriod time.Duration
+	idleTimeout          time.Duration
+	answerGracePeriod    time.Duration
+}
+
+func NewRoomService(roomRepository repositories.IRoomRepository, gameRepository repositories.IGameRepository, triviaRepository repositories.ITriviaRepository, choiceService IChoiceService, gameService IGameService) IRoomService {
+	return &RoomService{
+		roomRepository:       roomRepository,
+		gameRepository:       gameRepository,
+		triviaRepository:     triviaRepository,
+		choiceService:        choiceService,
+		gameService:          gameService,
+		rooms:                map[string]*activeRoom{},
+		countdownSeconds:     roomCountdownSeconds,
+		countdownTick:        time.Second,
+		revealDuration:       roomRevealDuration,
+		readyStartDelay:      roomReadyStartDelay,
+		reconnectGracePeriod: roomReconnectGracePeriod,
+		idleTimeout:          roomIdleTimeout,
+		answerGracePeriod:    answerGracePeriod,
+	}
+}
+
+func (s *RoomService) CreateRoom(userId int64, displayName string, dto *models.RoomCreateDTO) (*models.RoomState, error) {
+	numWrongChoices, timeLimitSeconds, err := resolveGameSettings(dto.NumWrongChoices, dto.TimeLimitSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	questionCount := defaultRoomQuestionCount
+	if dto.QuestionCount != nil {
+		questionCount = *dto.QuestionCount
+		if questionCount < 1 || questionCount > maxRoomQuestionCount {
+			return nil, fmt.Errorf("%w: question_count must be between 1 and %d", ErrInvalidGameSettings, maxRoomQuestionCount)
+		}
+	}
+
+	maxPlayers := defaultRoomMaxPlayers
+	if dto.MaxPlayers != nil {
+		maxPlayers = *dto.MaxPlayers
+		if maxPlayers < minRoomMaxPlayers || maxPlayers > maxRoomMaxPlayers {
+			return nil, fmt.Errorf("%w: max_players must be between %d and %d", ErrInvalidGameSettings, minRoomMaxPlayers, maxRoomMaxPlayers)
+		}
+	}
+
+	if dto.AutoStartSeconds != nil && (*dto.AutoStartSeconds < minAutoStartSeconds || *dto.AutoStartSeconds > maxAutoStartSeconds) {
+		return nil, fmt.Errorf("%w: auto_start_seconds must be between %d and %d", ErrInvalidGameSettings, minAutoStartSeconds, maxAutoStartSeconds)
+	}
+
+	deck, err := getPlayableDeck(s.triviaRepository, s.gameRepository, dto.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+
+	if s.openRoomCount(userId) >= maxOpenRoomsPerHost {
+		return nil, fmt.Errorf("%w: close one of your rooms before opening another", ErrTooManyRooms)
+	}
+
+	return s.openRoom(&repositories.TriviaGameRoomEntity{
+		DeckID:           deck.ID,
+		HostUserID:       userId,
+		NumWrongChoices:  numWrongChoices,
+		TimeLimitSeconds: timeLimitSeconds,
+		QuestionCount:    questionCount,
+		MaxPlayers:       maxPlayers,
+		AutoStartSeconds: dto.AutoStartSeconds,
+	}, []models.RoomParticipant{{UserID: userId, DisplayName: displayName}})
+}
+
+// CreateMatchedRoom opens a room with default settings for players grouped by matchmaking. The
+// first player hosts, the room is capped at the players in the match, and it starts on its own
+// shortly so nobody has to wait on a host they do not know.
+func (s *RoomService) CreateMatchedRoom(deckId int64, players []models.RoomParticipant) (*models.RoomState, error) {
+	if len(players) == 0 {
+		return nil, errors.New("a room needs at least one player")
+	}
+
+	deck, err := getPlayableDeck(s.triviaRepository, s.gameRepository, deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	autoStartSeconds := matchedRoomAutoStartSeconds
+	return s.openRoom(&repositories.TriviaGameRoomEntity{
+		DeckID:           deck.ID,
+		HostUserID:       players[0].UserID,
+		NumWrongChoices:  defaultNumWrongChoices,
+		TimeLimitSeconds: defaultTimeLimitSeconds,
+		QuestionCount:    defaultRoomQuestionCount,
+		MaxPlayers:       max(len(players), minRoomMaxPlayers),
+		AutoStartSeconds: &autoStartSeconds,
+	}, players)
+}
+
+func (s *RoomService) GetRoom(code string) (*models.RoomState, error) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return nil, err
+	}
+
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	return active.state(), nil
+}
+
+func (s *RoomService) JoinRoom(code string, userId int64, displayName string) (*models.RoomState, error) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return nil, err
+	}
+
+	active.mu.Lock()
+	if _, ok := active.players[userId]; ok {
+		state := active.state()
+		active.mu.Unlock()
+		return state, nil
+	}
+	if active.banned[userId] {
+		active.mu.Unlock()
+		return nil, ErrBannedFromRoom
+	}
+	if !active.isWaiting() {
+		active.mu.Unlock()
+		return nil, ErrRoomNotWaiting
+	}
+	if len(active.players) >= active.room.MaxPlayers {
+		active.mu.Unlock()
+		return nil, ErrRoomFull
+	}
+	active.players[userId] = &roomPlayer{userId: userId, displayName: displayName, joinedAt: time.Now().UTC()}
+	s.scheduleStart(active)
+	state := active.state()
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	return state, nil
+}
+
+// LeaveRoom removes a player from a waiting room. If the host leaves, the player who joined
+// after them becomes the host, and the room is closed once everyone has left. Leaving a room
+// mid-game only drops the connection since the player's game still has to be scored until the end.
+func (s *RoomService) LeaveRoom(code string, userId int64) error {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return err
+	}
+
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok {
+		active.mu.Unlock()
+		return ErrNotInRoom
+	}
+
+	if !active.isWaiting() {
+		conn := player.conn
+		player.conn = nil
+		state := active.state()
+		active.mu.Unlock()
+
+		if conn != nil {
+			conn.Close()
+		}
+		s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+		return nil
+	}
+
+	conn, hostChanged := s.removePlayer(active, userId)
+	isEmpty := len(active.players) == 0
+	roomId := active.room.ID
+	state := active.state()
+	active.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+	if isEmpty {
+		return s.closeRoom(active)
+	}
+	if hostChanged {
+		s.saveRoomHost(roomId, state.HostUserID)
+	}
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	return nil
+}
+
+// StartRoom creates a game for every player in the room and starts serving questions.
+func (s *RoomService) StartRoom(code string, userId int64) (*models.RoomState, error) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return nil, err
+	}
+
+	active.mu.Lock()
+	if active.room.HostUserID != userId {
+		active.mu.Unlock()
+		return nil, ErrNotRoomHost
+	}
+	if !active.isWaiting() {
+		active.mu.Unlock()
+		return nil, ErrRoomStarted
+	}
+
+	active.isStarting = true
+	active.mu.Unlock()
+
+	state, err := s.beginGame(active)
+	if err != nil {
+		return nil, err
+	}
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	go s.runGame(active)
+	return state, nil
+}
+
+// SetReady marks a player in the lobby as ready or not. Once every player is ready the lobby
+// counts down to an automatic start.
+func (s *RoomService) SetReady(code string, userId int64, isReady bool) (*models.RoomState, error) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return nil, err
+	}
+
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok {
+		active.mu.Unlock()
+		return nil, ErrNotInRoom
+	}
+	if !active.isWaiting() {
+		active.mu.Unlock()
+		return nil, ErrRoomStarted
+	}
+	player.isReady = isReady
+	s.scheduleStart(active)
+	state := active.state()
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	return state, nil
+}
+
+// KickPlayer lets the host remove a player from the lobby, optionally banning them so they
+// cannot join again. A player can be banned after they have already left.
+func (s *RoomService) KickPlayer(code string, userId int64, dto *models.RoomKickDTO) (*models.RoomState, error) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return nil, err
+	}
+
+	active.mu.Lock()
+	if active.room.HostUserID != userId {
+		active.mu.Unlock()
+		return nil, ErrNotRoomHost
+	}
+	if !active.isWaiting() {
+		active.mu.Unlock()
+		return nil, fmt.Errorf("%w: players can only be removed before the game starts", ErrRoomStarted)
+	}
+	if dto.UserID == userId {
+		active.mu.Unlock()
+		return nil, fmt.Errorf("%w: you cannot remove yourself from the room", ErrInvalidKick)
+	}
+	_, isInRoom := active.players[dto.UserID]
+	if !isInRoom && !dto.Ban {
+		active.mu.Unlock()
+		return nil, fmt.Errorf("%w: player is not in this room", ErrInvalidKick)
+	}
+
+	if dto.Ban {
+		active.banned[dto.UserID] = true
+	}
+	var conn RoomConnection
+	if isInRoom {
+		// Only the host kicks and they cannot kick themselves, so the host never changes here
+		conn, _ = s.removePlayer(active, dto.UserID)
+	}
+	state := active.state()
+	active.mu.Unlock()
+
+	if conn != nil {
+		s.send(conn, &models.RoomEvent{Type: models.RoomEventKicked, Data: state})
+		conn.Close()
+	}
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	return state, nil
+}
+
+// Connect attaches a player's live connection to the room, replacing any older connection
+// they had open. A player reconnecting mid-question gets the question again.
+func (s *RoomService) Connect(code string, userId int64, conn RoomConnection) error {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return err
+	}
+
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok {
+		active.mu.Unlock()
+		return ErrNotInRoom
+	}
+	oldConn := player.conn
+	player.conn = conn
+	player.connVersion++
+	s.scheduleIdleClose(active)
+	questionEvent := player.questionEvent
+	if player.hasAnswered {
+		questionEvent = nil
+	}
+	state := active.state()
+	active.mu.Unlock()
+
+	if oldConn != nil {
+		oldConn.Close()
+	}
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	if questionEvent != nil {
+		s.send(conn, questionEvent)
+	}
+	return nil
+}
+
+// Disconnect detaches a connection from the room if it is still the player's current one.
+// A lobby keeps the player's spot and ready flag for a grace period so they can reconnect.
+func (s *RoomService) Disconnect(code string, userId int64, conn RoomConnection) {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return
+	}
+
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok || player.conn != conn {
+		active.mu.Unlock()
+		return
+	}
+	player.conn = nil
+	player.connVersion++
+	if active.isWaiting() {
+		connVersion := player.connVersion
+		time.AfterFunc(s.reconnectGracePeriod, func() {
+			s.expireDisconnectedPlayer(active, userId, connVersion)
+		})
+		s.scheduleIdleClose(active)
+	}
+	state := active.state()
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+}
+
+// expireDisconnectedPlayer removes a player from the lobby if they have not reconnected since
+// the disconnect that started their grace period.
+func (s *RoomService) expireDisconnectedPlayer(active *activeRoom, userId int64, connVersion int) {
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok || player.connVersion != connVersion || !active.isWaiting() {
+		active.mu.Unlock()
+		return
+	}
+	_, hostChanged := s.removePlayer(active, userId)
+	isEmpty := len(active.players) == 0
+	roomId := active.room.ID
+	state := active.state()
+	active.mu.Unlock()
+
+	if isEmpty {
+		if err := s.closeRoom(active); err != nil {
+			util.LogErrorWithStackTrace(err)
+		}
+		return
+	}
+	if hostChanged {
+		s.saveRoomHost(roomId, state.HostUserID)
+	}
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+}
+
+// SubmitAnswer records a player's answer to the question currently being played. Only the
+// player who answered is told whether they were right; everyone else finds out on the reveal.
+func (s *RoomService) SubmitAnswer(code string, userId int64, message *models.RoomClientMessage) error {
+	active, err := s.getActiveRoom(code)
+	if err != nil {
+		return err
+	}
+
+	answeredAt := time.Now().UTC()
+
+	active.mu.Lock()
+	player, ok := active.players[userId]
+	if !ok {
+		active.mu.Unlock()
+		return ErrNotInRoom
+	}
+	if active.currentQuestion == nil || active.currentQuestion.ID != message.QuestionID || answeredAt.After(active.answerDeadline) {
+		active.mu.Unlock()
+		return errors.New("question is not being played")
+	}
+	if player.hasAnswered {
+		active.mu.Unlock()
+		return ErrQuestionAlreadyAnswered
+	}
+	if message.ChoiceID == "" {
+		active.mu.Unlock()
+		return errors.New("choice_id is required")
+	}
+	player.hasAnswered = true
+	gameId := player.gameId
+	active.pendingAnswers.Add(1)
+	active.mu.Unlock()
+
+	result, err := s.gameService.AnswerQuestionInGame(gameId, message.QuestionID, message.ChoiceID, answeredAt)
+	if err != nil {
+		active.mu.Lock()
+		player.hasAnswered = false
+		active.mu.Unlock()
+		active.pendingAnswers.Done()
+		return err
+	}
+
+	active.mu.Lock()
+	player.lastResult = result
+	player.totalScore = result.TotalScore
+	player.totalCorrect = result.TotalCorrect
+	conn := player.conn
+	active.mu.Unlock()
+	active.pendingAnswers.Done()
+
+	s.send(conn, &models.RoomEvent{Type: models.RoomEventAnswerResult, Data: result})
+
+	select {
+	case active.answered <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// CloseStaleRooms closes the rooms a previous run of the server left waiting or in progress.
+// Rooms only live in memory, so nobody can rejoin them after a restart.
+func (s *RoomService) CloseStaleRooms() error {
+	count, err := s.roomRepository.CloseStaleRooms()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		util.LogInfo(fmt.Sprintf("Closed %d rooms left open by the last run", count))
+	}
+	return nil
+}
+
+// beginGame creates a game for every player and moves the room in progress. The caller marks the
+// room as starting under the lock and calls this once the lock is released, so the games are saved
+// without holding up the room, and starts runGame if it succeeds. If the room fails to start, the
+// games already created are archived and the lobby opens again.
+func (s *RoomService) beginGame(active *activeRoom) (*models.RoomState, error) {
+	active.mu.Lock()
+	players := active.playerList()
+	room := active.room
+	active.mu.Unlock()
+
+	gameIds := map[int64]int64{}
+	for _, player := range players {
+		game, err := s.gameRepository.CreateRoomGameInstance(player.userId, room)
+		if err != nil {
+			s.cancelStart(active, gameIds)
+			return nil, err
+		}
+		gameIds[player.userId] = game.ID
+	}
+
+	room, err := s.roomRepository.UpdateRoomStatus(room.ID, repositories.RoomStatusInProgress)
+	if err != nil {
+		s.cancelStart(active, gameIds)
+		return nil, err
+	}
+
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	for _, player := range players {
+		player.gameId = gameIds[player.userId]
+	}
+	active.room = room
+	active.isStarting = false
+	active.stopStartTimer()
+	active.stopIdleTimer()
+	return active.state(), nil
+}
+
+// cancelStart opens the lobby again after the room failed to start.
+func (s *RoomService) cancelStart(active *activeRoom, gameIds map[int64]int64) {
+	s.discardGames(gameIds)
+
+	active.mu.Lock()
+	active.isStarting = false
+	// The countdown that started the room has run out, so begin a new one rather than keep its
+	// start time, which scheduleStart would take as already armed
+	active.stopStartTimer()
+	active.autoStartAt = nil
+	active.readyStartAt = nil
+	s.scheduleStart(active)
+	// The idle timer may have fired while the room was starting, so give the lobby a fresh one
+	active.stopIdleTimer()
+	s.scheduleIdleClose(active)
+	state := active.state()
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+}
+
+// discardGames archives games created for a room that never got to play them, so they are not
+// left in progress.
+func (s *RoomService) discardGames(gameIds map[int64]int64) {
+	for _, gameId := range gameIds {
+		if err := s.gameRepository.ArchiveGameInstance(gameId); err != nil {
+			util.LogErrorWithStackTrace(err)
+		}
+	}
+}
+
+// scheduleStart works out when a waiting room should start on its own and arms the lobby timer.
+// The room starts a short while after every player is ready, or when the auto start countdown
+// that began with the second player runs out, whichever comes first. It must be called with the
+// room locked after every lobby change.
+func (s *RoomService) scheduleStart(active *activeRoom) {
+	if len(active.players) < minPlayersToAutoStart {
+		active.autoStartAt = nil
+		active.readyStartAt = nil
+	} else {
+		now := time.Now().UTC()
+		if active.autoStartAt == nil && active.room.AutoStartSeconds != nil {
+			autoStartAt := now.Add(time.Duration(*active.room.AutoStartSeconds) * time.Second)
+			active.autoStartAt = &autoStartAt
+		}
+		if !active.allReady() {
+			active.readyStartAt = nil
+		} else if active.readyStartAt == nil {
+			readyStartAt := now.Add(s.readyStartDelay)
+			active.readyStartAt = &readyStartAt
+		}
+	}
+
+	startsAt := active.autoStartAt
+	if active.readyStartAt != nil && (startsAt == nil || active.readyStartAt.Before(*startsAt)) {
+		startsAt = active.readyStartAt
+	}
+	if startsAt == active.startsAt {
+		return
+	}
+
+	active.stopStartTimer()
+	if startsAt == nil {
+		return
+	}
+	active.startsAt = startsAt
+	startVersion := active.startVersion
+	active.startTimer = time.AfterFunc(time.Until(*startsAt), func() {
+		s.autoStartGame(active, startVersion)
+	})
+}
+
+// autoStartGame starts a room when its lobby countdown runs out, unless the countdown was
+// rescheduled or cancelled in the meantime.
+func (s *RoomService) autoStartGame(active *activeRoom, startVersion int) {
+	active.mu.Lock()
+	if active.startVersion != startVersion || !active.isWaiting() {
+		active.mu.Unlock()
+		return
+	}
+
+	active.isStarting = true
+	active.mu.Unlock()
+
+	state, err := s.beginGame(active)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		return
+	}
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventState, Data: state})
+	go s.runGame(active)
+}
+
+// scheduleIdleClose arms the lobby idle timer while nobody in a waiting room is connected and
+// stops it as soon as someone is, so a lobby nobody opens does not stay around until a restart.
+// It must be called with the room locked after every connect, disconnect and lobby change.
+func (s *RoomService) scheduleIdleClose(active *activeRoom) {
+	if !active.isWaiting() || active.hasConnections() {
+		active.stopIdleTimer()
+		return
+	}
+	if active.idleTimer != nil {
+		return
+	}
+
+	idleVersion := active.idleVersion
+	active.idleTimer = time.AfterFunc(s.idleTimeout, func() {
+		s.closeIdleRoom(active, idleVersion)
+	})
+}
+
+// closeIdleRoom closes a lobby that nobody connected to before its idle timer ran out, unless
+// the timer was stopped in the meantime.
+func (s *RoomService) closeIdleRoom(active *activeRoom, idleVersion int) {
+	active.mu.Lock()
+	if active.idleVersion != idleVersion || !active.isWaiting() || active.hasConnections() {
+		active.mu.Unlock()
+		return
+	}
+	active.mu.Unlock()
+
+	if err := s.closeRoom(active); err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+}
+
+// removePlayer takes a player out of a waiting room and hands the host role on if they were
+// the host. It must be called with the room locked and returns the connection the player had
+// open, and whether the host changed so the caller can save it with saveRoomHost once unlocked.
+func (s *RoomService) removePlayer(active *activeRoom, userId int64) (RoomConnection, bool) {
+	player := active.players[userId]
+	delete(active.players, userId)
+
+	hostChanged := false
+	if userId == active.room.HostUserID && len(active.players) > 0 {
+		transferHost(active)
+		hostChanged = true
+	}
+	s.scheduleStart(active)
+	s.scheduleIdleClose(active)
+	return player.conn, hostChanged
+}
+
+// transferHost makes the longest-waiting connected player the host, falling back to the
+// longest-waiting player when nobody is connected. It must be called with the room locked.
+func transferHost(active *activeRoom) {
+	players := active.playerList()
+	newHost := players[0]
+	for _, player := range players {
+		if player.conn != nil {
+			newHost = player
+			break
+		}
+	}
+	active.room.HostUserID = newHost.userId
+}
+
+// saveRoomHost stores a host change made by transferHost. The room lives in memory, so it stays
+// playable even if the change was not saved.
+func (s *RoomService) saveRoomHost(roomId int64, hostUserId int64) {
+	_, err := s.roomRepository.UpdateRoomHost(roomId, hostUserId)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+}
+
+// runGame plays every question of the room in lockstep: a countdown, the question, then the
+// reveal and scoreboard once everyone has answered or the timer runs out.
+func (s *RoomService) runGame(active *activeRoom) {
+	active.mu.Lock()
+	room := active.room
+	active.mu.Unlock()
+
+	deck, err := s.triviaRepository.GetTriviaDeckById(room.DeckID)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		s.abandonGame(active)
+		return
+	}
+
+	questions, err := s.gameRepository.GetPlayableDeckQuestions(deck.ID, deck.Rules, room.QuestionCount, room.ID)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		s.abandonGame(active)
+		return
+	}
+
+	for i, question := range questions {
+		questionNumber := i + 1
+
+		for seconds := s.countdownSeconds; seconds > 0; seconds-- {
+			s.broadcast(active, &models.RoomEvent{
+				Type: models.RoomEventCountdown,
+				Data: models.RoomCountdown{QuestionNumber: questionNumber, SecondsRemaining: seconds},
+			})
+			time.Sleep(s.countdownTick)
+		}
+
+		s.playQuestion(active, question, questionNumber, len(questions))
+
+		active.mu.Lock()
+		scoreboard := active.scoreboard(questionNumber, len(questions))
+		active.mu.Unlock()
+		s.broadcast(active, &models.RoomEvent{Type: models.RoomEventScoreboard, Data: scoreboard})
+
+		time.Sleep(s.revealDuration)
+	}
+
+	s.finishRoom(active, len(questions))
+}
+
+// playQuestion serves a question to every player with the same choices and waits for the
+// answers. Players who never answer are timed out before the correct answer is revealed.
+func (s *RoomService) playQuestion(active *activeRoom, question *repositories.TriviaQuestionEntity, questionNumber int, totalQuestions int) {
+	active.mu.Lock()
+	numWrongChoices := active.room.NumWrongChoices
+	timeLimit := time.Duration(active.room.TimeLimitSeconds) * time.Second
+	players := active.playerList()
+	active.mu.Unlock()
+
+	choices, err := s.choiceService.SelectChoices(question, numWrongChoices)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+		choices = []string{question.CorrectAnswer}
+	}
+
+	servedAt := time.Now().UTC()
+	// Give late answers the same grace period a solo game gets before timing players out
+	answerDeadline := servedAt.Add(timeLimit + s.answerGracePeriod)
+	questionEvents := map[int64]*models.RoomEvent{}
+	for _, player := range players {
+		dto, err := s.gameService.ServeQuestionToGame(player.gameId, question, choices, servedAt)
+		if err != nil {
+			util.LogErrorWithStackTrace(err)
+			continue
+		}
+		dto.QuestionNumber = questionNumber
+		dto.TotalQuestions = totalQuestions
+		questionEvents[player.userId] = &models.RoomEvent{Type: models.RoomEventQuestion, Data: dto}
+	}
+
+	active.mu.Lock()
+	active.currentQuestion = question
+	active.answerDeadline = answerDeadline
+	for _, player := range players {
+		player.hasAnswered = false
+		player.lastResult = nil
+		player.questionEvent = questionEvents[player.userId]
+	}
+	// Drop any signal left over from the previous question
+	select {
+	case <-active.answered:
+	default:
+	}
+	active.mu.Unlock()
+
+	for _, player := range players {
+		active.mu.Lock()
+		conn := player.conn
+		active.mu.Unlock()
+		if event, ok := questionEvents[player.userId]; ok {
+			s.send(conn, event)
+		}
+	}
+
+	timer := time.NewTimer(time.Until(answerDeadline))
+	defer timer.Stop()
+
+waitForAnswers:
+	for {
+		select {
+		case <-active.answered:
+			active.mu.Lock()
+			allAnswered := active.allAnswered()
+			active.mu.Unlock()
+			if allAnswered {
+				break waitForAnswers
+			}
+		case <-timer.C:
+			break waitForAnswers
+		}
+	}
+
+	// No answer is accepted once the question is cleared, so after the ones already accepted
+	// are saved, whoever has not answered is timed out
+	active.mu.Lock()
+	active.currentQuestion = nil
+	active.mu.Unlock()
+	active.pendingAnswers.Wait()
+
+	active.mu.Lock()
+	var unanswered []*roomPlayer
+	for _, player := range players {
+		player.questionEvent = nil
+		if !player.hasAnswered {
+			player.hasAnswered = true
+			unanswered = append(unanswered, player)
+		}
+	}
+	active.mu.Unlock()
+
+	for _, player := range unanswered {
+		result, err := s.gameService.AnswerQuestionInGame(player.gameId, question.ID, "", time.Now().UTC())
+		if err != nil {
+			util.LogErrorWithStackTrace(err)
+			continue
+		}
+		active.mu.Lock()
+		player.lastResult = result
+		player.totalScore = result.TotalScore
+		player.totalCorrect = result.TotalCorrect
+		active.mu.Unlock()
+	}
+
+	active.mu.Lock()
+	reveal := models.RoomAnswerReveal{
+		QuestionID:    question.ID,
+		CorrectAnswer: question.CorrectAnswer,
+		Results:       []models.RoomAnswerOutcome{},
+	}
+	for _, player := range players {
+		outcome := models.RoomAnswerOutcome{UserID: player.userId, DisplayName: player.displayName, IsTimedOut: true}
+		if player.lastResult != nil {
+			outcome.IsCorrect = player.lastResult.IsCorrect
+			outcome.IsTimedOut = player.lastResult.IsTimedOut
+			outcome.Score = player.lastResult.Score
+		}
+		reveal.Results = append(reveal.Results, outcome)
+	}
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventAnswerReveal, Data: reveal})
+}
+
+// finishRoom ends every player's game, sends the final scoreboard and releases the room.
+func (s *RoomService) finishRoom(active *activeRoom, totalQuestions int) {
+	active.mu.Lock()
+	players := active.playerList()
+	roomId := active.room.ID
+	active.mu.Unlock()
+
+	for _, player := range players {
+		if err := s.gameService.FinishGame(player.gameId); err != nil {
+			util.LogErrorWithStackTrace(err)
+		}
+	}
+
+	room, err := s.roomRepository.UpdateRoomStatus(roomId, repositories.RoomStatusFinished)
+	if err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+
+	active.mu.Lock()
+	if room != nil {
+		active.room = room
+	} else {
+		active.room.Status = repositories.RoomStatusFinished
+	}
+	scoreboard := active.scoreboard(totalQuestions, totalQuestions)
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventGameOver, Data: scoreboard})
+	s.release(active)
+}
+
+// abandonGame archives the players' games when the room cannot get its questions, then closes it.
+func (s *RoomService) abandonGame(active *activeRoom) {
+	active.mu.Lock()
+	gameIds := map[int64]int64{}
+	for _, player := range active.players {
+		gameIds[player.userId] = player.gameId
+	}
+	active.mu.Unlock()
+
+	s.discardGames(gameIds)
+	if err := s.closeRoom(active); err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+}
+
+// closeRoom marks a room as closed, tells everyone still connected and releases it.
+func (s *RoomService) closeRoom(active *activeRoom) error {
+	active.mu.Lock()
+	roomId := active.room.ID
+	active.mu.Unlock()
+
+	room, err := s.roomRepository.UpdateRoomStatus(roomId, repositories.RoomStatusClosed)
+	if err != nil {
+		return err
+	}
+
+	active.mu.Lock()
+	active.room = room
+	state := active.state()
+	active.mu.Unlock()
+
+	s.broadcast(active, &models.RoomEvent{Type: models.RoomEventClosed, Data: state})
+	s.release(active)
+	return nil
+}
+
+// release forgets a room that is over and closes the connections still attached to it.
+func (s *RoomService) release(active *activeRoom) {
+	s.mu.Lock()
+	delete(s.rooms, active.room.Code)
+	s.mu.Unlock()
+
+	active.mu.Lock()
+	active.stopStartTimer()
+	active.stopIdleTimer()
+	var conns []RoomConnection
+	for _, player := range active.players {
+		if player.conn != nil {
+			conns = append(conns, player.conn)
+			player.conn = nil
+			player.connVersion++
+		}
+	}
+	active.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// openRoom saves a new room under a fresh join code and puts the players in its lobby, in the
+// order they are given.
+func (s *RoomService) openRoom(settings *repositories.TriviaGameRoomEntity, players []models.RoomParticipant) (*models.RoomState, error) {
+	var room *repositories.TriviaGameRoomEntity
+	for attempt := 0; attempt < roomCodeAttempts; attempt++ {
+		code, err := generateRoomCode()
+		if err != nil {
+			return nil, err
+		}
+		settings.Code = code
+		room, err = s.roomRepository.CreateRoom(settings)
+		if err == nil {
+			break
+		}
+		// The code may already be taken, so try again with a new one
+		util.LogErrorWithStackTrace(err)
+	}
+	if room == nil {
+		return nil, errors.New("failed to create room")
+	}
+
+	active := &activeRoom{
+		room:     room,
+		players:  map[int64]*roomPlayer{},
+		answered: make(chan struct{}, 1),
+		banned:   map[int64]bool{},
+	}
+	joinedAt := time.Now().UTC()
+	for i, player := range players {
+		active.players[player.UserID] = &roomPlayer{
+			userId:      player.UserID,
+			displayName: player.DisplayName,
+			joinedAt:    joinedAt.Add(time.Duration(i)), // Keeps the given order when sorting by join time
+		}
+	}
+
+	s.mu.Lock()
+	s.rooms[room.Code] = active
+	s.mu.Unlock()
+
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	s.scheduleStart(active)
+	s.scheduleIdleClose(active)
+	return active.state(), nil
+}
+
+// openRoomCount counts the rooms a user hosts that are still waiting or being played. The rooms
+// are listed first so the service lock is not held while each room is checked.
+func (s *RoomService) openRoomCount(userId int64) int {
+	s.mu.Lock()
+	rooms := make([]*activeRoom, 0, len(s.rooms))
+	for _, active := range s.rooms {
+		rooms = append(rooms, active)
+	}
+	s.mu.Unlock()
+
+	count := 0
+	for _, active := range rooms {
+		active.mu.Lock()
+		if active.room.HostUserID == userId {
+			count++
+		}
+		active.mu.Unlock()
+	}
+	return count
+}
+
+func (s *RoomService) getActiveRoom(code string) (*activeRoom, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active, ok := s.rooms[code]
+	if !ok {
+		return nil, ErrRoomNotFound
+	}
+	return active, nil
+}
+
+// broadcast sends an event to every connected player. Connections are collected under the
+// lock but written to outside of it so a slow client cannot hold up the room.
+func (s *RoomService) broadcast(active *activeRoom, event *models.RoomEvent) {
+	active.mu.Lock()
+	var conns []RoomConnection
+	for _, player := range active.players {
+		if player.conn != nil {
+			conns = append(conns, player.conn)
+		}
+	}
+	active.mu.Unlock()
+
+	for _, conn := range conns {
+		s.send(conn, event)
+	}
+}
+
+func (s *RoomService) send(conn RoomConnection, event *models.RoomEvent) {
+	if conn == nil {
+		return
+	}
+	if err := conn.Send(event); err != nil {
+		util.LogErrorWithStackTrace(err)
+	}
+}
+
+// state must be called with the room locked.
+func (a *activeRoom) state() *models.RoomState {
+	participants := []models.RoomParticipant{}
+	for _, player := range a.playerList() {
+		participants = append(participants, models.RoomParticipant{
+			UserID:      player.userId,
+			DisplayName: player.displayName,
+			IsHost:      player.userId == a.room.HostUserID,
+			IsReady:     player.isReady,
+			IsConnected: player.conn != nil,
+		})
+	}
+
+	return &models.RoomState{
+		Code:             a.room.Code,
+		DeckID:           a.room.DeckID,
+		HostUserID:       a.room.HostUserID,
+		Status:           a.room.Status,
+		NumWrongChoices:  a.room.NumWrongChoices,
+		TimeLimitSeconds: a.room.TimeLimitSeconds,
+		QuestionCount:    a.room.QuestionCount,
+		MaxPlayers:       a.room.MaxPlayers,
+		AutoStartSeconds: a.room.AutoStartSeconds,
+		StartsAt:         a.startsAt,
+		Participants:     participants,
+	}
+}
+
+// scoreboard must be called with the room locked. Ties on score go to the player with more
+// correct answers, then to display name so the order is stable.
+func (a *activeRoom) scoreboard(questionNumber int, totalQuestions int) *models.RoomScoreboard {
+	players := a.playerList()
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].totalScore != players[j].totalScore {
+			return players[i].totalScore > players[j].totalScore
+		}
+		if players[i].totalCorrect != players[j].totalCorrect {
+			return players[i].totalCorrect > players[j].totalCorrect
+		}
+		return players[i].displayName < players[j].displayName
+	})
+
+	entries := make([]models.RoomScoreboardEntry, 0, len(players))
+	for i, player := range players {
+		entries = append(entries, models.RoomScoreboardEntry{
+			Rank:         i + 1,
+			UserID:       player.userId,
+			DisplayName:  player.displayName,
+			GameID:       player.gameId,
+			TotalScore:   player.totalScore,
+			TotalCorrect: player.totalCorrect,
+		})
+	}
+
+	return &models.RoomScoreboard{
+		QuestionNumber: questionNumber,
+		TotalQuestions: totalQuestions,
+		Entries:        entries,
+	}
+}
+
+// playerList must be called with the room locked. Players are returned in the order they joined.
+func (a *activeRoom) playerList() []*roomPlayer {
+	players := make([]*roomPlayer, 0, len(a.players))
+	for _, player := range a.players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].joinedAt.Equal(players[j].joinedAt) {
+			return players[i].userId < players[j].userId
+		}
+		return players[i].joinedAt.Before(players[j].joinedAt)
+	})
+	return players
+}
+
+// allReady must be called with the room locked.
+func (a *activeRoom) allReady() bool {
+	for _, player := range a.players {
+		if !player.isReady {
+			return false
+		}
+	}
+	return true
+}
+
+// isWaiting must be called with the room locked. A room that is starting no longer takes lobby changes.
+func (a *activeRoom) isWaiting() bool {
+	return a.room.Status == repositories.RoomStatusWaiting && !a.isStarting
+}
+
+// stopStartTimer cancels the lobby countdown. It must be called with the room locked.
+func (a *activeRoom) stopStartTimer() {
+	if a.startTimer != nil {
+		a.startTimer.Stop()
+		a.startTimer = nil
+	}
+	a.startsAt = nil
+	a.startVersion++
+}
+
+func (a *activeRoom) stopIdleTimer() {
+	if a.idleTimer != nil {
+		a.idleTimer.Stop()
+		a.idleTimer = nil
+	}
+	a.idleVersion++
+}
+
+// hasConnections must be called with the room locked.
+func (a *activeRoom) hasConnections() bool {
+	for _, player := range a.players {
+		if player.conn != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// allAnswered must be called with the room locked.
+func (a *activeRoom) allAnswered() bool {
+	for _, player := range a.players {
+		if !player.hasAnswered {
+			return false
+		}
+	}
+	return true
+}
+
+func generateRoomCode() (string, error) {
+	code := make([]byte, roomCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(roomCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = roomCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
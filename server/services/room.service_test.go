@@ -0,0 +1,1604 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoomRepository is a mock implementation of IRoomRepository
+type MockRoomRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoomRepository) CreateRoom(room *repositories.TriviaGameRoomEntity) (*repositories.TriviaGameRoomEntity, error) {
+	args := m.Called(room)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameRoomEntity), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetRoomByCode(code string) (*repositories.TriviaGameRoomEntity, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameRoomEntity), args.Error(1)
+}
+
+func (m *MockRoomRepository) UpdateRoomStatus(roomId int64, status string) (*repositories.TriviaGameRoomEntity, error) {
+	args := m.Called(roomId, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameRoomEntity), args.Error(1)
+}
+
+func (m *MockRoomRepository) UpdateRoomHost(roomId int64, hostUserId int64) (*repositories.TriviaGameRoomEntity, error) {
+	args := m.Called(roomId, hostUserId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameRoomEntity), args.Error(1)
+}
+
+func (m *MockRoomRepository) CloseStaleRooms() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// MockGameService is a mock implementation of IGameService
+type MockGameService struct {
+	mock.Mock
+}
+
+func (m *MockGameService) StartSoloGame(userId int64, dto *models.GameStartDTO) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameService) GetGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameService) GetNextQuestion(userId int64, gameId int64) (*models.GameQuestionDTO, error) {
+	args := m.Called(userId, gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameQuestionDTO), args.Error(1)
+}
+
+func (m *MockGameService) SubmitAnswer(userId int64, gameId int64, dto *models.GameAnswerDTO) (*models.GameAnswerResult, error) {
+	args := m.Called(userId, gameId, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameAnswerResult), args.Error(1)
+}
+
+func (m *MockGameService) EndGame(userId int64, gameId int64) (*repositories.TriviaGameInstanceEntity, error) {
+	args := m.Called(userId, gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaGameInstanceEntity), args.Error(1)
+}
+
+func (m *MockGameService) GetGameSummary(userId int64, gameId int64) (*models.GameSummary, error) {
+	args := m.Called(userId, gameId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameSummary), args.Error(1)
+}
+
+func (m *MockGameService) ServeQuestionToGame(gameId int64, question *repositories.TriviaQuestionEntity, choices []string, servedAt time.Time) (*models.GameQuestionDTO, error) {
+	args := m.Called(gameId, question, choices, servedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameQuestionDTO), args.Error(1)
+}
+
+func (m *MockGameService) AnswerQuestionInGame(gameId int64, questionId int64, choiceId string, answeredAt time.Time) (*models.GameAnswerResult, error) {
+	args := m.Called(gameId, questionId, choiceId, answeredAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameAnswerResult), args.Error(1)
+}
+
+func (m *MockGameService) FinishGame(gameId int64) error {
+	args := m.Called(gameId)
+	return args.Error(0)
+}
+
+func (m *MockGameService) OnGameEnded(hook GameEndedHook) {
+	m.Called(hook)
+}
+
+// fakeRoomConnection records every event sent to a player
+type fakeRoomConnection struct {
+	mu     sync.Mutex
+	events []*models.RoomEvent
+	closed bool
+}
+
+func (c *fakeRoomConnection) Send(event *models.RoomEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+	return nil
+}
+
+func (c *fakeRoomConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *fakeRoomConnection) eventTypes() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	types := []string{}
+	for _, event := range c.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func (c *fakeRoomConnection) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// ===========================================
+// ROOM LOBBY TESTS
+// ===========================================
+
+// Test CreateRoom - Success With Default Settings
+func TestRoomService_CreateRoom_Success(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.MatchedBy(func(room *repositories.TriviaGameRoomEntity) bool {
+		return len(room.Code) == 6 && room.HostUserID == 1 && room.NumWrongChoices == 3 &&
+			room.TimeLimitSeconds == 20 && room.QuestionCount == 10
+	})).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+
+	// Act
+	result, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC234", result.Code)
+	assert.Len(t, result.Participants, 1)
+	assert.True(t, result.Participants[0].IsHost)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test CreateRoom - Invalid Question Count
+func TestRoomService_CreateRoom_InvalidQuestionCount(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, new(MockGameRepository), mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	for _, questionCount := range []int{0, 51} {
+		// Act
+		result, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3, QuestionCount: &questionCount})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidGameSettings)
+		assert.Nil(t, result)
+	}
+	mockTriviaRepo.AssertNotCalled(t, "GetTriviaDeckById")
+	mockRoomRepo.AssertNotCalled(t, "CreateRoom")
+}
+
+// Test CreateMatchedRoom - Players Keep Their Queue Order
+func TestRoomService_CreateMatchedRoom_Success(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	matchedRoom := &repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}
+	matchedRoom.HostUserID = 7
+	matchedRoom.MaxPlayers = 2
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.MatchedBy(func(room *repositories.TriviaGameRoomEntity) bool {
+		return room.HostUserID == 7 && room.MaxPlayers == 2 && room.AutoStartSeconds != nil
+	})).Return(matchedRoom, nil)
+
+	// Act
+	result, err := roomService.CreateMatchedRoom(3, []models.RoomParticipant{
+		{UserID: 7, DisplayName: "First"},
+		{UserID: 2, DisplayName: "Second"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Participants, 2)
+	assert.Equal(t, int64(7), result.Participants[0].UserID)
+	assert.True(t, result.Participants[0].IsHost)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test JoinRoom - Success
+func TestRoomService_JoinRoom_Success(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	hostConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 1, hostConn))
+
+	// Act
+	result, err := roomService.JoinRoom("ABC234", 2, "Guest")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Participants, 2)
+	assert.Equal(t, int64(2), result.Participants[1].UserID)
+	assert.False(t, result.Participants[1].IsConnected)
+	assert.Equal(t, []string{models.RoomEventState, models.RoomEventState}, hostConn.eventTypes())
+}
+
+// Test JoinRoom - Unknown Code
+func TestRoomService_JoinRoom_NotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(new(MockRoomRepository), new(MockGameRepository), mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	// Act
+	result, err := roomService.JoinRoom("NOPE22", 2, "Guest")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+	assert.Nil(t, result)
+}
+
+// Test LeaveRoom - Host Leaving Hands The Room To The Next Player
+func TestRoomService_LeaveRoom_HostTransfers(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	guestConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 2, guestConn))
+
+	newHostRoom := &repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}
+	newHostRoom.HostUserID = 2
+	mockRoomRepo.On("UpdateRoomHost", int64(5), int64(2)).Return(newHostRoom, nil)
+
+	// Act
+	err = roomService.LeaveRoom("ABC234", 1)
+
+	// Assert
+	assert.NoError(t, err)
+	result, err := roomService.GetRoom("ABC234")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.HostUserID)
+	assert.Len(t, result.Participants, 1)
+	assert.True(t, result.Participants[0].IsHost)
+	assert.False(t, guestConn.isClosed())
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test LeaveRoom - Last Player Leaving Closes The Room
+func TestRoomService_LeaveRoom_LastPlayerClosesRoom(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	hostConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 1, hostConn))
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusClosed).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusClosed,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+
+	// Act
+	err = roomService.LeaveRoom("ABC234", 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, hostConn.isClosed())
+	_, err = roomService.GetRoom("ABC234")
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+	mockRoomRepo.AssertNotCalled(t, "UpdateRoomHost")
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test JoinRoom - Room Is Full
+func TestRoomService_JoinRoom_Full(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	for userId := int64(2); userId <= 8; userId++ {
+		_, err := roomService.JoinRoom("ABC234", userId, "Guest")
+		assert.NoError(t, err)
+	}
+
+	// Act
+	result, err := roomService.JoinRoom("ABC234", 9, "Late")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRoomFull)
+	assert.Nil(t, result)
+}
+
+// Test KickPlayer - Banned Player Cannot Join Again
+func TestRoomService_KickPlayer_Ban(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	guestConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 2, guestConn))
+
+	// Act
+	result, err := roomService.KickPlayer("ABC234", 1, &models.RoomKickDTO{UserID: 2, Ban: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Participants, 1)
+	assert.Contains(t, guestConn.eventTypes(), models.RoomEventKicked)
+	assert.True(t, guestConn.isClosed())
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.ErrorIs(t, err, ErrBannedFromRoom)
+}
+
+// Test KickPlayer - Players Cannot Be Removed Once The Game Has Started
+func TestRoomService_KickPlayer_RoomStarted(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	active, err := roomService.getActiveRoom("ABC234")
+	assert.NoError(t, err)
+	active.room.Status = repositories.RoomStatusInProgress
+
+	// Act
+	result, err := roomService.KickPlayer("ABC234", 1, &models.RoomKickDTO{UserID: 2})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRoomStarted)
+	assert.Nil(t, result)
+}
+
+// Test KickPlayer - Only The Host Can Kick
+func TestRoomService_KickPlayer_NotHost(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	// Act
+	result, err := roomService.KickPlayer("ABC234", 2, &models.RoomKickDTO{UserID: 1})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotRoomHost)
+	assert.Nil(t, result)
+}
+
+// Test Disconnect - Player Who Does Not Reconnect Loses Their Spot
+func TestRoomService_Disconnect_GracePeriodExpires(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	guestConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 2, guestConn))
+
+	// Act
+	roomService.Disconnect("ABC234", 2, guestConn)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		result, err := roomService.GetRoom("ABC234")
+		return err == nil && len(result.Participants) == 1
+	}, time.Second, time.Millisecond)
+}
+
+// Test Disconnect - Player Who Reconnects In Time Keeps Their Spot
+func TestRoomService_Disconnect_ReconnectKeepsReadyFlag(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+	roomService.reconnectGracePeriod = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	_, err = roomService.SetReady("ABC234", 2, true)
+	assert.NoError(t, err)
+	guestConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 2, guestConn))
+
+	// Act
+	roomService.Disconnect("ABC234", 2, guestConn)
+	assert.NoError(t, roomService.Connect("ABC234", 2, &fakeRoomConnection{}))
+
+	// Assert
+	result, err := roomService.GetRoom("ABC234")
+	assert.NoError(t, err)
+	assert.Len(t, result.Participants, 2)
+	assert.True(t, result.Participants[1].IsReady)
+	assert.True(t, result.Participants[1].IsConnected)
+}
+
+// Test StartRoom - Only The Host Can Start
+func TestRoomService_StartRoom_NotHost(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	// Act
+	result, err := roomService.StartRoom("ABC234", 2)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotRoomHost)
+	assert.Nil(t, result)
+	mockGameRepo.AssertNotCalled(t, "CreateRoomGameInstance")
+	mockRoomRepo.AssertNotCalled(t, "UpdateRoomStatus")
+}
+
+// Test StartRoom - Games Already Created Are Archived When The Room Fails To Start
+func TestRoomService_StartRoom_ArchivesGamesOnFailure(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	mockGameRepo.On("CreateRoomGameInstance", int64(1), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockGameRepo.On("CreateRoomGameInstance", int64(2), mock.Anything).Return(nil, errors.New("db error"))
+	mockGameRepo.On("ArchiveGameInstance", int64(100)).Return(nil)
+
+	// Act
+	result, err := roomService.StartRoom("ABC234", 1)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockGameRepo.AssertExpectations(t)
+	mockRoomRepo.AssertNotCalled(t, "UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress)
+
+	state, err := roomService.GetRoom("ABC234")
+	assert.NoError(t, err)
+	assert.Equal(t, repositories.RoomStatusWaiting, state.Status)
+}
+
+// Test StartRoom - The Room Stays Usable While Its Games Are Saved, But Nobody Can Join
+func TestRoomService_StartRoom_LobbyClosedWhileStarting(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	saving := make(chan struct{})
+	release := make(chan struct{})
+	mockGameRepo.On("CreateRoomGameInstance", int64(1), mock.Anything).Run(func(args mock.Arguments) {
+		close(saving)
+		<-release
+	}).Return(nil, errors.New("db error"))
+
+	started := make(chan error)
+	go func() {
+		_, err := roomService.StartRoom("ABC234", 1)
+		started <- err
+	}()
+	<-saving
+
+	// Act
+	state, getErr := roomService.GetRoom("ABC234")
+	_, joinErr := roomService.JoinRoom("ABC234", 2, "Guest")
+	close(release)
+	startErr := <-started
+
+	// Assert
+	assert.NoError(t, getErr)
+	assert.Equal(t, repositories.RoomStatusWaiting, state.Status)
+	assert.ErrorIs(t, joinErr, ErrRoomNotWaiting)
+	assert.Error(t, startErr)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+}
+
+// Test CloseStaleRooms - Rooms Left Open By The Last Run Are Closed
+func TestRoomService_CloseStaleRooms(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, new(MockGameRepository), mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+	mockRoomRepo.On("CloseStaleRooms").Return(2, nil)
+
+	// Act
+	err := roomService.CloseStaleRooms()
+
+	// Assert
+	assert.NoError(t, err)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test CreateRoom - A Lobby Nobody Connects To Is Closed
+func TestRoomService_CreateRoom_IdleLobbyCloses(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+	roomService.idleTimeout = 0
+	closed := make(chan struct{})
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusClosed).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusClosed,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(closed) })
+
+	// Act
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	// Assert
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("idle room was not closed")
+	}
+	assert.Eventually(t, func() bool {
+		_, err := roomService.GetRoom("ABC234")
+		return errors.Is(err, ErrRoomNotFound)
+	}, time.Second, time.Millisecond)
+}
+
+// Test CreateRoom - A Host Cannot Keep Opening Rooms
+func TestRoomService_CreateRoom_TooManyRooms(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	for _, code := range []string{"AAA222", "BBB333", "CCC444"} {
+		room := &repositories.TriviaGameRoomEntity{
+			ID:               5,
+			Code:             "ABC234",
+			DeckID:           3,
+			HostUserID:       1,
+			Status:           repositories.RoomStatusWaiting,
+			NumWrongChoices:  3,
+			TimeLimitSeconds: 20,
+			QuestionCount:    10,
+			MaxPlayers:       8,
+		}
+		room.Code = code
+		mockRoomRepo.On("CreateRoom", mock.Anything).Return(room, nil).Once()
+		_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+		assert.NoError(t, err)
+	}
+
+	// Act
+	result, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTooManyRooms)
+	assert.Nil(t, result)
+	mockRoomRepo.AssertNumberOfCalls(t, "CreateRoom", 3)
+}
+
+// ===========================================
+// ROOM GAMEPLAY TESTS
+// ===========================================
+
+// Test StartRoom - Plays Every Question And Finishes The Room
+func TestRoomService_StartRoom_PlaysGame(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	hostConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 1, hostConn))
+
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	finished := make(chan struct{})
+	mockGameRepo.On("CreateRoomGameInstance", int64(1), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockGameRepo.On("CreateRoomGameInstance", int64(2), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 200}, nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusInProgress,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestions", int64(3), noDeckRules, 10, int64(5)).Return([]*repositories.TriviaQuestionEntity{question}, nil)
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string(question.Tags), int64(42), "Paris", 3).Return([]string{"London", "Berlin", "Madrid"}, nil)
+	mockGameService.On("ServeQuestionToGame", mock.Anything, question, mock.Anything, mock.Anything).Return(&models.GameQuestionDTO{QuestionID: 42}, nil)
+	mockGameService.On("AnswerQuestionInGame", int64(100), int64(42), "host-choice", mock.Anything).Return(&models.GameAnswerResult{IsCorrect: true, Score: 190, TotalScore: 190, TotalCorrect: 1}, nil)
+	mockGameService.On("AnswerQuestionInGame", int64(200), int64(42), "guest-choice", mock.Anything).Return(&models.GameAnswerResult{Score: 0, TotalIncorrect: 1}, nil)
+	mockGameService.On("FinishGame", mock.Anything).Return(nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusFinished).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusFinished,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(finished) })
+
+	// Act
+	result, err := roomService.StartRoom("ABC234", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, repositories.RoomStatusInProgress, result.Status)
+
+	// Answer as soon as the question is being played
+	assert.Eventually(t, func() bool {
+		return roomService.SubmitAnswer("ABC234", 1, &models.RoomClientMessage{Type: models.RoomMessageAnswer, QuestionID: 42, ChoiceID: "host-choice"}) == nil
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, roomService.SubmitAnswer("ABC234", 2, &models.RoomClientMessage{Type: models.RoomMessageAnswer, QuestionID: 42, ChoiceID: "guest-choice"}))
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("room did not finish")
+	}
+
+	// Assert
+	assert.Eventually(t, hostConn.isClosed, time.Second, time.Millisecond)
+	assert.Equal(t, []string{
+		models.RoomEventState,
+		models.RoomEventState,
+		models.RoomEventCountdown,
+		models.RoomEventQuestion,
+		models.RoomEventAnswerResult,
+		models.RoomEventAnswerReveal,
+		models.RoomEventScoreboard,
+		models.RoomEventGameOver,
+	}, hostConn.eventTypes())
+
+	gameOver := hostConn.events[len(hostConn.events)-1].Data.(*models.RoomScoreboard)
+	assert.Equal(t, int64(1), gameOver.Entries[0].UserID)
+	assert.Equal(t, 190, gameOver.Entries[0].TotalScore)
+	assert.Equal(t, int64(200), gameOver.Entries[1].GameID)
+	mockGameService.AssertNumberOfCalls(t, "FinishGame", 2)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test StartRoom - The Games Are Archived When The Room Cannot Load Its Questions
+func TestRoomService_StartRoom_ArchivesGamesWhenQuestionsFail(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), new(MockGameService)).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	closed := make(chan struct{})
+	mockGameRepo.On("CreateRoomGameInstance", int64(1), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockGameRepo.On("CreateRoomGameInstance", int64(2), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 200}, nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusInProgress,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestions", int64(3), noDeckRules, 10, int64(5)).Return(nil, errors.New("db error"))
+	mockGameRepo.On("ArchiveGameInstance", int64(100)).Return(nil)
+	mockGameRepo.On("ArchiveGameInstance", int64(200)).Return(nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusClosed).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusClosed,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(closed) })
+
+	// Act
+	_, err = roomService.StartRoom("ABC234", 1)
+	assert.NoError(t, err)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("room was not closed")
+	}
+
+	// Assert
+	mockGameRepo.AssertExpectations(t)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test StartRoom - An Answer Accepted At The Deadline Is Not Revealed As Timed Out
+func TestRoomService_StartRoom_AnswerAtDeadline(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+	roomService.answerGracePeriod = 200 * time.Millisecond
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+	hostConn := &fakeRoomConnection{}
+	assert.NoError(t, roomService.Connect("ABC234", 1, hostConn))
+
+	question := &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	finished := make(chan struct{})
+	playingRoom := &repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusInProgress,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}
+	playingRoom.TimeLimitSeconds = 0
+	mockGameRepo.On("CreateRoomGameInstance", int64(1), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockGameRepo.On("CreateRoomGameInstance", int64(2), mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 200}, nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress).Return(playingRoom, nil)
+	mockGameRepo.On("GetPlayableDeckQuestions", int64(3), noDeckRules, 10, int64(5)).Return([]*repositories.TriviaQuestionEntity{question}, nil)
+	mockTriviaRepo.On("GetRandomWrongAnswersByTags", []string(question.Tags), int64(42), "Paris", 3).Return([]string{"London", "Berlin", "Madrid"}, nil)
+	mockGameService.On("ServeQuestionToGame", mock.Anything, question, mock.Anything, mock.Anything).Return(&models.GameQuestionDTO{QuestionID: 42}, nil)
+	// The host's answer is still being saved when the answer timer runs out
+	mockGameService.On("AnswerQuestionInGame", int64(100), int64(42), "host-choice", mock.Anything).Return(&models.GameAnswerResult{IsCorrect: true, Score: 190, TotalScore: 190, TotalCorrect: 1}, nil).
+		Run(func(args mock.Arguments) { time.Sleep(2 * roomService.answerGracePeriod) })
+	mockGameService.On("AnswerQuestionInGame", int64(200), int64(42), "", mock.Anything).Return(&models.GameAnswerResult{IsTimedOut: true, TotalIncorrect: 1}, nil)
+	mockGameService.On("FinishGame", mock.Anything).Return(nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusFinished).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusFinished,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(finished) })
+
+	// Act
+	_, err = roomService.StartRoom("ABC234", 1)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return slices.Contains(hostConn.eventTypes(), models.RoomEventQuestion)
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, roomService.SubmitAnswer("ABC234", 1, &models.RoomClientMessage{Type: models.RoomMessageAnswer, QuestionID: 42, ChoiceID: "host-choice"}))
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("room did not finish")
+	}
+
+	// Assert
+	assert.Eventually(t, hostConn.isClosed, time.Second, time.Millisecond)
+	var reveal models.RoomAnswerReveal
+	for _, event := range hostConn.events {
+		if event.Type == models.RoomEventAnswerReveal {
+			reveal = event.Data.(models.RoomAnswerReveal)
+		}
+	}
+	assert.Len(t, reveal.Results, 2)
+	for _, outcome := range reveal.Results {
+		if outcome.UserID == 1 {
+			assert.False(t, outcome.IsTimedOut)
+			assert.True(t, outcome.IsCorrect)
+			assert.Equal(t, 190, outcome.Score)
+		} else {
+			assert.True(t, outcome.IsTimedOut)
+		}
+	}
+}
+
+// Test SubmitAnswer - An Answer After The Deadline Is Rejected
+func TestRoomService_SubmitAnswer_AfterDeadline(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	active, err := roomService.getActiveRoom("ABC234")
+	assert.NoError(t, err)
+	active.mu.Lock()
+	active.currentQuestion = &repositories.TriviaQuestionEntity{
+		ID:            42,
+		IsPublished:   true,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography"},
+	}
+	active.answerDeadline = time.Now().UTC().Add(-time.Millisecond)
+	active.mu.Unlock()
+
+	// Act
+	err = roomService.SubmitAnswer("ABC234", 1, &models.RoomClientMessage{Type: models.RoomMessageAnswer, QuestionID: 42, ChoiceID: "abc"})
+
+	// Assert
+	assert.Error(t, err)
+	mockGameService.AssertNotCalled(t, "AnswerQuestionInGame")
+}
+
+// Test SetReady - Room Starts Once Everyone Is Ready
+func TestRoomService_SetReady_AllReadyStartsRoom(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	finished := make(chan struct{})
+	mockGameRepo.On("CreateRoomGameInstance", mock.Anything, mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusInProgress,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestions", int64(3), noDeckRules, 10, int64(5)).Return([]*repositories.TriviaQuestionEntity{}, nil)
+	mockGameService.On("FinishGame", int64(100)).Return(nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusFinished).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusFinished,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(finished) })
+
+	// Act
+	result, err := roomService.SetReady("ABC234", 1, true)
+	assert.NoError(t, err)
+	assert.Nil(t, result.StartsAt)
+	_, err = roomService.SetReady("ABC234", 2, true)
+	assert.NoError(t, err)
+
+	// Assert
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("room did not start")
+	}
+	mockGameRepo.AssertNumberOfCalls(t, "CreateRoomGameInstance", 2)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test SetReady - A Failed Automatic Start Counts Down Again
+func TestRoomService_SetReady_RetriesAfterFailedStart(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	_, err = roomService.JoinRoom("ABC234", 2, "Guest")
+	assert.NoError(t, err)
+
+	finished := make(chan struct{})
+	mockGameRepo.On("CreateRoomGameInstance", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+	mockGameRepo.On("CreateRoomGameInstance", mock.Anything, mock.Anything).Return(&repositories.TriviaGameInstanceEntity{ID: 100}, nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusInProgress).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusInProgress,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestions", int64(3), noDeckRules, 10, int64(5)).Return([]*repositories.TriviaQuestionEntity{}, nil)
+	mockGameService.On("FinishGame", int64(100)).Return(nil)
+	mockRoomRepo.On("UpdateRoomStatus", int64(5), repositories.RoomStatusFinished).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusFinished,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil).
+		Run(func(args mock.Arguments) { close(finished) })
+
+	// Act
+	_, err = roomService.SetReady("ABC234", 1, true)
+	assert.NoError(t, err)
+	_, err = roomService.SetReady("ABC234", 2, true)
+	assert.NoError(t, err)
+
+	// Assert
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("room did not start after the failed attempt")
+	}
+	mockGameRepo.AssertNumberOfCalls(t, "CreateRoomGameInstance", 3)
+	mockRoomRepo.AssertExpectations(t)
+}
+
+// Test SubmitAnswer - Only The Current Question Can Be Answered
+func TestRoomService_SubmitAnswer_NotPlaying(t *testing.T) {
+	// Arrange
+	mockRoomRepo := new(MockRoomRepository)
+	mockGameRepo := new(MockGameRepository)
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockGameService := new(MockGameService)
+	roomService := NewRoomService(mockRoomRepo, mockGameRepo, mockTriviaRepo, NewChoiceService(mockTriviaRepo), mockGameService).(*RoomService)
+	roomService.countdownSeconds = 1
+	roomService.countdownTick = 0
+	roomService.revealDuration = 0
+	roomService.readyStartDelay = 0
+	roomService.reconnectGracePeriod = 0
+	roomService.idleTimeout = time.Hour
+
+	count := 10
+	mockTriviaRepo.On("GetTriviaDeckById", int64(3)).Return(&repositories.TriviaDeckEntity{ID: 3, IsApproved: true}, nil)
+	mockGameRepo.On("GetPlayableDeckQuestionCount", int64(3), noDeckRules).Return(&count, nil)
+	mockRoomRepo.On("CreateRoom", mock.Anything).Return(&repositories.TriviaGameRoomEntity{
+		ID:               5,
+		Code:             "ABC234",
+		DeckID:           3,
+		HostUserID:       1,
+		Status:           repositories.RoomStatusWaiting,
+		NumWrongChoices:  3,
+		TimeLimitSeconds: 20,
+		QuestionCount:    10,
+		MaxPlayers:       8,
+	}, nil)
+	_, err := roomService.CreateRoom(1, "Host", &models.RoomCreateDTO{DeckID: 3})
+	assert.NoError(t, err)
+
+	// Act
+	err = roomService.SubmitAnswer("ABC234", 1, &models.RoomClientMessage{Type: models.RoomMessageAnswer, QuestionID: 42, ChoiceID: "abc"})
+
+	// Assert
+	assert.Error(t, err)
+	mockGameService.AssertNotCalled(t, "AnswerQuestionInGame")
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+const favoriteDeckCount = 5
+
+type IStatsService interface {
+	GetPlayerStats(userId int64) (*models.PlayerStats, error)
+	GetPlayerGames(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error)
+	GetUserStats(userId int64) (*models.PlayerStats, error)
+	GetUserGames(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error)
+}
+
+type StatsService struct {
+	statsRepository repositories.IStatsRepository
+	userRepository  repositories.IUserRepository
+}
+
+func NewStatsService(statsRepository repositories.IStatsRepository, userRepository repositories.IUserRepository) IStatsService {
+	return &StatsService{
+		statsRepository: statsRepository,
+		userRepository:  userRepository,
+	}
+}
+
+// GetPlayerStats returns the lifetime stats of the signed in player.
+func (s *StatsService) GetPlayerStats(userId int64) (*models.PlayerStats, error) {
+	totals, err := s.statsRepository.GetPlayerTotals(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	rating, err := s.statsRepository.GetPlayerRating(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagStats, err := s.statsRepository.GetPlayerTagStats(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	decks, err := s.statsRepository.GetPlayerFavoriteDecks(userId, favoriteDeckCount)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.PlayerStats{
+		UserID:           userId,
+		PlayerStatTotals: *totals,
+		Rating:           models.SkillRating{Rating: roundTo(rating.Rating, 2), RatedAttempts: rating.RatedAttempts},
+		Accuracy:         percentage(totals.TotalCorrect, totals.TotalAnswered),
+		Tags:             make([]models.PlayerTagStats, len(tagStats)),
+		FavoriteDecks:    make([]models.PlayerDeckStats, len(decks)),
+	}
+	stats.AverageResponseTimeMs = roundTo(totals.AverageResponseTimeMs, 2)
+	if totals.GamesPlayed > 0 {
+		stats.AverageScore = roundTo(float64(totals.TotalScore)/float64(totals.GamesPlayed), 2)
+	}
+	for i, tag := range tagStats {
+		stats.Tags[i] = *tag
+		stats.Tags[i].Accuracy = percentage(tag.Correct, tag.Answered)
+	}
+	for i, deck := range decks {
+		stats.FavoriteDecks[i] = *deck
+	}
+	return stats, nil
+}
+
+// GetPlayerGames returns a page of the signed in player's games, newest first.
+func (s *StatsService) GetPlayerGames(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error) {
+	games, err := s.statsRepository.GetPlayerGames(userId, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.statsRepository.GetPlayerGamesCount(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]any, len(games))
+	for i, game := range games {
+		game.Accuracy = percentage(game.TotalCorrect, game.TotalCorrect+game.TotalIncorrect)
+		results[i] = game
+	}
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     (offset / pageSize) + 1,
+	}, nil
+}
+
+// GetUserStats returns the stats of any user for admins, checking that the user exists first.
+func (s *StatsService) GetUserStats(userId int64) (*models.PlayerStats, error) {
+	err := s.checkUserExists(userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPlayerStats(userId)
+}
+
+// GetUserGames returns a page of any user's games for admins, checking that the user exists first.
+func (s *StatsService) GetUserGames(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error) {
+	err := s.checkUserExists(userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPlayerGames(userId, pageSize, offset)
+}
+
+func (s *StatsService) checkUserExists(userId int64) error {
+	user, err := s.userRepository.GetUserById(int(userId))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user == nil) {
+		return ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStatsRepository is a mock implementation of IStatsRepository
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) GetPlayerTotals(userId int64) (*models.PlayerStatTotals, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlayerStatTotals), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerRating(userId int64) (*models.SkillRating, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SkillRating), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerTagStats(userId int64) ([]*models.PlayerTagStats, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerTagStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerFavoriteDecks(userId int64, limit int) ([]*models.PlayerDeckStats, error) {
+	args := m.Called(userId, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerDeckStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerGamesCount(userId int64) (*int, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerGames(userId int64, pageSize int, offset int) ([]*models.PlayerGameHistoryEntry, error) {
+	args := m.Called(userId, pageSize, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerGameHistoryEntry), args.Error(1)
+}
+
+// ===========================================
+// PLAYER STATS TESTS
+// ===========================================
+
+// Test GetPlayerStats - Success
+func TestStatsService_GetPlayerStats_Success(t *testing.T) {
+	// Arrange
+	mockStatsRepo := new(MockStatsRepository)
+	statsService := NewStatsService(mockStatsRepo, new(MockUserRepository))
+
+	totals := &models.PlayerStatTotals{
+		GamesPlayed:           3,
+		GamesFinished:         2,
+		TotalAnswered:         30,
+		TotalCorrect:          20,
+		TotalScore:            2500,
+		BestGameScore:         1200,
+		BestStreak:            7,
+		AverageResponseTimeMs: 4321.456,
+	}
+	tags := []*models.PlayerTagStats{
+		{Tag: "science", Answered: 20, Correct: 15},
+		{Tag: "history", Answered: 10, Correct: 5},
+	}
+	decks := []*models.PlayerDeckStats{{DeckID: 3, DeckName: "General", GamesPlayed: 2}}
+	mockStatsRepo.On("GetPlayerTotals", int64(1)).Return(totals, nil)
+	mockStatsRepo.On("GetPlayerRating", int64(1)).Return(&models.SkillRating{Rating: 1234.5678, RatedAttempts: 30}, nil)
+	mockStatsRepo.On("GetPlayerTagStats", int64(1)).Return(tags, nil)
+	mockStatsRepo.On("GetPlayerFavoriteDecks", int64(1), favoriteDeckCount).Return(decks, nil)
+
+	// Act
+	result, err := statsService.GetPlayerStats(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.UserID)
+	assert.Equal(t, 3, result.GamesPlayed)
+	assert.Equal(t, 66.67, result.Accuracy)
+	assert.Equal(t, 833.33, result.AverageScore)
+	assert.Equal(t, 4321.46, result.AverageResponseTimeMs)
+	assert.Equal(t, 7, result.BestStreak)
+	assert.Equal(t, 1234.57, result.Rating.Rating)
+	assert.Equal(t, 30, result.Rating.RatedAttempts)
+	assert.Equal(t, 75.0, result.Tags[0].Accuracy)
+	assert.Equal(t, 50.0, result.Tags[1].Accuracy)
+	assert.Equal(t, "General", result.FavoriteDecks[0].DeckName)
+}
+
+// Test GetPlayerStats - New Player Has Empty Stats
+func TestStatsService_GetPlayerStats_NoGames(t *testing.T) {
+	// Arrange
+	mockStatsRepo := new(MockStatsRepository)
+	statsService := NewStatsService(mockStatsRepo, new(MockUserRepository))
+
+	mockStatsRepo.On("GetPlayerTotals", int64(1)).Return(&models.PlayerStatTotals{}, nil)
+	mockStatsRepo.On("GetPlayerRating", int64(1)).Return(&models.SkillRating{Rating: models.DefaultSkillRating}, nil)
+	mockStatsRepo.On("GetPlayerTagStats", int64(1)).Return([]*models.PlayerTagStats{}, nil)
+	mockStatsRepo.On("GetPlayerFavoriteDecks", int64(1), favoriteDeckCount).Return([]*models.PlayerDeckStats{}, nil)
+
+	// Act
+	result, err := statsService.GetPlayerStats(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, result.Accuracy)
+	assert.Equal(t, 0.0, result.AverageScore)
+	assert.Equal(t, models.DefaultSkillRating, result.Rating.Rating)
+	assert.NotNil(t, result.Tags)
+	assert.NotNil(t, result.FavoriteDecks)
+}
+
+// Test GetPlayerGames - Success
+func TestStatsService_GetPlayerGames_Success(t *testing.T) {
+	// Arrange
+	mockStatsRepo := new(MockStatsRepository)
+	statsService := NewStatsService(mockStatsRepo, new(MockUserRepository))
+
+	count := 12
+	games := []*models.PlayerGameHistoryEntry{
+		{GameID: 9, TotalCorrect: 3, TotalIncorrect: 1},
+		{GameID: 8},
+	}
+	mockStatsRepo.On("GetPlayerGames", int64(1), 10, 10).Return(games, nil)
+	mockStatsRepo.On("GetPlayerGamesCount", int64(1)).Return(&count, nil)
+
+	// Act
+	result, err := statsService.GetPlayerGames(1, 10, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 12, result.Total)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, 75.0, result.Results[0].(*models.PlayerGameHistoryEntry).Accuracy)
+}
+
+// Test GetUserStats - User Does Not Exist
+func TestStatsService_GetUserStats_UserNotFound(t *testing.T) {
+	// Arrange
+	mockStatsRepo := new(MockStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+	statsService := NewStatsService(mockStatsRepo, mockUserRepo)
+
+	mockUserRepo.On("GetUserById", 99).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := statsService.GetUserStats(99)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Nil(t, result)
+	mockStatsRepo.AssertNotCalled(t, "GetPlayerTotals")
+}
+
+// Test GetUserGames - Success
+func TestStatsService_GetUserGames_Success(t *testing.T) {
+	// Arrange
+	mockStatsRepo := new(MockStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+	statsService := NewStatsService(mockStatsRepo, mockUserRepo)
+
+	count := 0
+	mockUserRepo.On("GetUserById", 4).Return(&repositories.UserEntity{ID: 4}, nil)
+	mockStatsRepo.On("GetPlayerGames", int64(4), 25, 0).Return([]*models.PlayerGameHistoryEntry{}, nil)
+	mockStatsRepo.On("GetPlayerGamesCount", int64(4)).Return(&count, nil)
+
+	// Act
+	result, err := statsService.GetUserGames(4, 25, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Page)
+	assert.Empty(t, result.Results)
+	mockStatsRepo.AssertExpectations(t)
+}
+
+// ===========================================
+// SKILL RATING TESTS
+// ===========================================
+
+// Test CalculateEloRatings - Correct Answer Between Equal New Ratings
+func TestCalculateEloRatings_CorrectAnswer(t *testing.T) {
+	// Arrange
+	player := models.SkillRating{Rating: 1200}
+	question := models.SkillRating{Rating: 1200}
+
+	// Act
+	newPlayer, newQuestion := models.CalculateEloRatings(player, question, true)
+
+	// Assert
+	assert.Equal(t, 1220.0, newPlayer.Rating)
+	assert.Equal(t, 1180.0, newQuestion.Rating)
+	assert.Equal(t, 1, newPlayer.RatedAttempts)
+	assert.Equal(t, 1, newQuestion.RatedAttempts)
+}
+
+// Test CalculateEloRatings - Established Ratings Move Less
+func TestCalculateEloRatings_EstablishedRating(t *testing.T) {
+	// Arrange
+	player := models.SkillRating{Rating: 1200, RatedAttempts: 100}
+	question := models.SkillRating{Rating: 1200, RatedAttempts: 5}
+
+	// Act
+	newPlayer, newQuestion := models.CalculateEloRatings(player, question, false)
+
+	// Assert
+	assert.Equal(t, 1192.0, newPlayer.Rating)
+	assert.Equal(t, 1220.0, newQuestion.Rating)
+}
+
+// Test CalculateEloRatings - Expected Result Barely Moves The Ratings
+func TestCalculateEloRatings_ExpectedResult(t *testing.T) {
+	// Arrange
+	player := models.SkillRating{Rating: 1600, RatedAttempts: 100}
+	question := models.SkillRating{Rating: 1000, RatedAttempts: 100}
+
+	// Act
+	newPlayer, newQuestion := models.CalculateEloRatings(player, question, true)
+	upsetPlayer, upsetQuestion := models.CalculateEloRatings(player, question, false)
+
+	// Assert
+	assert.Less(t, newPlayer.Rating-player.Rating, 1.0)
+	assert.Less(t, question.Rating-newQuestion.Rating, 1.0)
+	assert.Greater(t, player.Rating-upsetPlayer.Rating, 15.0)
+	assert.Greater(t, upsetQuestion.Rating-question.Rating, 15.0)
+}
@@ -1,8 +1,9 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,15 +14,49 @@ const (
 	verificationTokenExpirationInHours     = 3
 	loginWithEmailTokenExpirationInMinutes = 10
 	refreshTokenExpirationInHours          = 160
+	passwordResetTokenExpirationInMinutes  = 30
 	claimIssuer                            = "https://opentriviaonline.com"
+	claimAudience                          = "https://api.opentriviaonline.com"
+)
+
+// TokenPurpose is what a token can be used for. It is stored in the token's "sub" claim, and a token is
+// only ever accepted for the purpose it was issued for.
+type TokenPurpose string
+
+const (
+	TokenPurposeAccess         TokenPurpose = "api_access_token"
+	TokenPurposeRefresh        TokenPurpose = "api_refresh_token"
+	TokenPurposeVerification   TokenPurpose = "api_verification_token"
+	TokenPurposeLoginWithEmail TokenPurpose = "loginwithemail_token"
+	TokenPurposePasswordReset  TokenPurpose = "password_reset_token"
 )
 
 type ITokenService interface {
-	GenerateAccessToken(id int) (*string, error)
+	GenerateAccessToken(id int, sessionId string) (*string, error)
 	GenerateLoginWithEmailToken(id int) (*string, error)
 	GenerateVerificationToken(id int) (*string, error)
 	GenerateRefreshToken(id int) (*string, error)
-	ValidateToken(tokenToVerify *string) (*int, error)
+	GeneratePasswordResetToken(id int) (*string, error)
+
+	// ValidateToken checks the token's signature, expiry, issuer and audience, and that it was issued for the purpose
+	ValidateToken(tokenToVerify *string, purpose TokenPurpose) (*TokenClaims, error)
+}
+
+// TokenClaims are the claims of a validated token.
+type TokenClaims struct {
+	UserID    int
+	Purpose   TokenPurpose
+	TokenID   string // The jti claim, which is unique to every token
+	SessionID string // Only set for access tokens
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// tokenClaims is how the claims are laid out in the JWT.
+type tokenClaims struct {
+	UserID    int    `json:"user"`
+	SessionID string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
 }
 
 type TokenService struct {
@@ -34,67 +69,50 @@ func NewTokenService(jwtSecretKey string) ITokenService {
 	}
 }
 
-func (s *TokenService) GenerateAccessToken(id int) (*string, error) {
-
-	expirationTime := time.Now().Add(accessTokenExpirationInMinutes * time.Minute).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512,
-		jwt.MapClaims{
-			"iss":  claimIssuer,
-			"sub":  "api_access_token",
-			"exp":  expirationTime,
-			"user": id,
-		})
-	signedToken, err := token.SignedString([]byte(s.jwtSecretKey))
-	if err != nil {
-		return nil, err
-	}
-	return &signedToken, nil
+// GenerateAccessToken creates an access token for a session. The session is checked on every request,
+// so logging the session out stops the token working before it expires.
+func (s *TokenService) GenerateAccessToken(id int, sessionId string) (*string, error) {
+	return s.generateToken(id, TokenPurposeAccess, accessTokenExpirationInMinutes*time.Minute, sessionId)
 }
 
 func (s *TokenService) GenerateLoginWithEmailToken(id int) (*string, error) {
-
-	expirationTime := time.Now().Add(loginWithEmailTokenExpirationInMinutes * time.Minute).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512,
-		jwt.MapClaims{
-			"iss":  claimIssuer,
-			"sub":  "loginwithemail_token",
-			"exp":  expirationTime,
-			"user": id,
-		})
-	signedToken, err := token.SignedString([]byte(s.jwtSecretKey))
-	if err != nil {
-		return nil, err
-	}
-	return &signedToken, nil
+	return s.generateToken(id, TokenPurposeLoginWithEmail, loginWithEmailTokenExpirationInMinutes*time.Minute, "")
 }
 
 func (s *TokenService) GenerateVerificationToken(id int) (*string, error) {
+	return s.generateToken(id, TokenPurposeVerification, verificationTokenExpirationInHours*time.Hour, "")
+}
 
-	expirationTime := time.Now().Add(verificationTokenExpirationInHours * time.Hour).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512,
-		jwt.MapClaims{
-			"iss":  claimIssuer,
-			"sub":  "api_verification_token",
-			"exp":  expirationTime,
-			"user": id,
-		})
-	signedToken, err := token.SignedString([]byte(s.jwtSecretKey))
+func (s *TokenService) GenerateRefreshToken(id int) (*string, error) {
+	return s.generateToken(id, TokenPurposeRefresh, refreshTokenExpirationInHours*time.Hour, "")
+}
+
+func (s *TokenService) GeneratePasswordResetToken(id int) (*string, error) {
+	return s.generateToken(id, TokenPurposePasswordReset, passwordResetTokenExpirationInMinutes*time.Minute, "")
+}
+
+// generateToken signs a token for the purpose. Each one carries a random jti, so that no two tokens are
+// ever the same, even for the same user in the same second.
+func (s *TokenService) generateToken(id int, purpose TokenPurpose, lifetime time.Duration, sessionId string) (*string, error) {
+
+	tokenId, err := newTokenID()
 	if err != nil {
 		return nil, err
 	}
-	return &signedToken, nil
-}
-
-func (s *TokenService) GenerateRefreshToken(id int) (*string, error) {
 
-	expirationTime := time.Now().Add(refreshTokenExpirationInHours * time.Hour).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512,
-		jwt.MapClaims{
-			"iss":  claimIssuer,
-			"sub":  "api_refresh_token",
-			"exp":  expirationTime,
-			"user": id,
-		})
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, tokenClaims{
+		UserID:    id,
+		SessionID: sessionId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    claimIssuer,
+			Subject:   string(purpose),
+			Audience:  jwt.ClaimStrings{claimAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        tokenId,
+		},
+	})
 	signedToken, err := token.SignedString([]byte(s.jwtSecretKey))
 	if err != nil {
 		return nil, err
@@ -102,23 +120,46 @@ func (s *TokenService) GenerateRefreshToken(id int) (*string, error) {
 	return &signedToken, nil
 }
 
-func (s *TokenService) ValidateToken(tokenToVerify *string) (*int, error) {
-
-	parsedToken, err := jwt.Parse(*tokenToVerify, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
+func (s *TokenService) ValidateToken(tokenToVerify *string, purpose TokenPurpose) (*TokenClaims, error) {
 
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(*tokenToVerify, claims, func(t *jwt.Token) (interface{}, error) {
 		return []byte(s.jwtSecretKey), nil
-	})
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS512.Alg()}),
+		jwt.WithIssuer(claimIssuer),
+		jwt.WithAudience(claimAudience),
+		jwt.WithSubject(string(purpose)),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 	if err != nil {
 		return nil, errors.New("JWT could not be validated")
 	}
 
-	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok {
-		userId := int(claims["user"].(float64))
-		return &userId, nil
-	} else {
+	if claims.ID == "" || claims.IssuedAt == nil || claims.UserID == 0 {
+		return nil, errors.New("JWT claims could not be validated")
+	}
+	if purpose == TokenPurposeAccess && claims.SessionID == "" {
 		return nil, errors.New("JWT claims could not be validated")
 	}
+
+	return &TokenClaims{
+		UserID:    claims.UserID,
+		Purpose:   purpose,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// newTokenID returns a random 128-bit ID as a hex string.
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
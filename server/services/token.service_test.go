@@ -12,7 +12,7 @@ func TestGenerateAccessToken(t *testing.T) {
 	service := NewTokenService(jwtSecretKey)
 
 	userID := 123
-	token, err := service.GenerateAccessToken(userID)
+	token, err := service.GenerateAccessToken(userID, "session_123")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -27,19 +27,19 @@ func TestValidateToken(t *testing.T) {
 	service := NewTokenService(jwtSecretKey)
 
 	userID := 123
-	token, err := service.GenerateAccessToken(userID)
+	token, err := service.GenerateAccessToken(userID, "session_123")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
 	// Validate the generated token
-	validatedUserID, err := service.ValidateToken(token)
+	claims, err := service.ValidateToken(token, TokenPurposeAccess)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if validatedUserID == nil || *validatedUserID != userID {
-		t.Fatalf("expected userID %d, got %v", userID, validatedUserID)
+	if claims == nil || claims.UserID != userID {
+		t.Fatalf("expected userID %d, got %v", userID, claims)
 	}
 }
 
@@ -48,7 +48,7 @@ func TestValidateToken_InvalidToken(t *testing.T) {
 	service := NewTokenService(jwtSecretKey)
 
 	invalidToken := "invalid.token.value"
-	_, err := service.ValidateToken(&invalidToken)
+	_, err := service.ValidateToken(&invalidToken, TokenPurposeAccess)
 	if err == nil {
 		t.Fatalf("expected an error for invalid token, got nil")
 	}
@@ -77,7 +77,7 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 	}
 
 	// Validate the expired token
-	_, err = service.ValidateToken(&signedToken)
+	_, err = service.ValidateToken(&signedToken, TokenPurposeAccess)
 	if err == nil {
 		t.Fatalf("expected an error for expired token, got nil")
 	}
@@ -94,7 +94,7 @@ func TestValidateToken_MalformedToken(t *testing.T) {
 
 	// Malformed token (missing parts)
 	malformedToken := "malformed.token"
-	_, err := service.ValidateToken(&malformedToken)
+	_, err := service.ValidateToken(&malformedToken, TokenPurposeAccess)
 	if err == nil {
 		t.Fatalf("expected an error for malformed token, got nil")
 	}
@@ -111,7 +111,7 @@ func TestValidateToken_EmptyToken(t *testing.T) {
 
 	// Empty token
 	emptyToken := ""
-	_, err := service.ValidateToken(&emptyToken)
+	_, err := service.ValidateToken(&emptyToken, TokenPurposeAccess)
 	if err == nil {
 		t.Fatalf("expected an error for empty token, got nil")
 	}
@@ -121,3 +121,100 @@ func TestValidateToken_EmptyToken(t *testing.T) {
 		t.Fatalf("expected error '%s', got '%s'", expectedError, err.Error())
 	}
 }
+
+func TestValidateToken_AccessTokenClaims(t *testing.T) {
+	jwtSecretKey := "testSecretKey"
+	service := NewTokenService(jwtSecretKey)
+
+	userID := 123
+	before := time.Now().Add(-time.Second)
+	token, err := service.GenerateAccessToken(userID, "session_123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	claims, err := service.ValidateToken(token, TokenPurposeAccess)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if claims.SessionID != "session_123" || claims.TokenID == "" {
+		t.Fatalf("expected session session_123 and a token ID, got %+v", claims)
+	}
+	if claims.IssuedAt.Before(before) {
+		t.Fatalf("expected the token to be issued after %v, got %v", before, claims.IssuedAt)
+	}
+}
+
+func TestValidateToken_WrongPurpose(t *testing.T) {
+	jwtSecretKey := "testSecretKey"
+	service := NewTokenService(jwtSecretKey)
+
+	// Each token must only be accepted for the purpose it was issued for
+	generators := map[TokenPurpose]func(int) (*string, error){
+		TokenPurposeRefresh:        service.GenerateRefreshToken,
+		TokenPurposeVerification:   service.GenerateVerificationToken,
+		TokenPurposeLoginWithEmail: service.GenerateLoginWithEmailToken,
+	}
+	for issuedFor, generate := range generators {
+		token, err := generate(123)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, purpose := range []TokenPurpose{TokenPurposeAccess, TokenPurposeRefresh, TokenPurposeVerification, TokenPurposeLoginWithEmail} {
+			_, err = service.ValidateToken(token, purpose)
+			if purpose == issuedFor && err != nil {
+				t.Fatalf("expected a %v token to be valid, got %v", issuedFor, err)
+			}
+			if purpose != issuedFor && err == nil {
+				t.Fatalf("expected a %v token to be rejected as a %v token", issuedFor, purpose)
+			}
+		}
+	}
+}
+
+func TestValidateToken_WrongAudience(t *testing.T) {
+	jwtSecretKey := "testSecretKey"
+	service := NewTokenService(jwtSecretKey)
+
+	// A token signed with the same key for another audience
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{
+		"iss":  claimIssuer,
+		"sub":  string(TokenPurposeVerification),
+		"aud":  "https://other.opentriviaonline.com",
+		"iat":  time.Now().Unix(),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+		"jti":  "jti_123",
+		"user": 123,
+	})
+	signedToken, err := token.SignedString([]byte(jwtSecretKey))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = service.ValidateToken(&signedToken, TokenPurposeVerification)
+	if err == nil {
+		t.Fatalf("expected an error for the wrong audience, got nil")
+	}
+}
+
+func TestGenerateToken_UniqueTokenIDs(t *testing.T) {
+	jwtSecretKey := "testSecretKey"
+	service := NewTokenService(jwtSecretKey)
+
+	first, err := service.GenerateVerificationToken(123)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := service.GenerateVerificationToken(123)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	firstClaims, _ := service.ValidateToken(first, TokenPurposeVerification)
+	secondClaims, _ := service.ValidateToken(second, TokenPurposeVerification)
+	if firstClaims.TokenID == secondClaims.TokenID {
+		t.Fatalf("expected each token to have its own jti, got %v twice", firstClaims.TokenID)
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
+	"github.com/snowlynxsoftware/oto-api/server/models"
+)
+
+const (
+	defaultCSVTagDelimiter = ";"
+	maxCSVImportRows       = 5000
+	csvFormulaPrefixes     = "=+-@\t\r" // Spreadsheets treat a cell starting with any of these as a formula
+)
+
+// csvField is a field read from a CSV import, along with the headers it is recognised by.
+type csvField struct {
+	name     string
+	headers  []string
+	required bool
+}
+
+var questionCSVFields = []csvField{
+	{name: "question", headers: []string{"question", "question_text", "prompt"}, required: true},
+	{name: "correct_answer", headers: []string{"correct_answer", "answer", "correct"}, required: true},
+	{name: "tags", headers: []string{"tags", "tag", "categories", "category"}},
+}
+
+var wrongAnswerCSVFields = []csvField{
+	{name: "answer_text", headers: []string{"answer_text", "wrong_answer", "answer", "text"}, required: true},
+	{name: "tags", headers: []string{"tags", "tag", "categories", "category"}, required: true},
+}
+
+// csvRow is one data row of a CSV import, keyed by field name.
+type csvRow struct {
+	line   int
+	values map[string]string
+}
+
+// readCSVRows reads every data row of a CSV file, matching the header row against the fields.
+// A row that cannot be read stops the import there, and is returned as a row error along with
+// the rows read before it. Rows with every field blank are left out.
+func readCSVRows(data io.Reader, fields []csvField, columns map[string]string) ([]csvRow, []models.TriviaCSVImportRowError, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+
+	indexes, err := mapCSVHeader(header, fields, columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := []csvRow{}
+	rowErrors := []models.TriviaCSVImportRowError{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line := 0
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.StartLine
+			}
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{
+				Line:    line,
+				Message: fmt.Sprintf("the row could not be read, so the rest of the file was not imported: %v", err),
+			})
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := csvRow{line: line, values: make(map[string]string, len(fields))}
+		blank := true
+		for _, field := range fields {
+			index, ok := indexes[field.name]
+			if !ok || index >= len(record) {
+				continue
+			}
+			value := unescapeCSVFormula(strings.TrimSpace(record[index]))
+			row.values[field.name] = value
+			if value != "" {
+				blank = false
+			}
+		}
+		if blank {
+			continue
+		}
+
+		if len(rows) == maxCSVImportRows {
+			return nil, nil, fmt.Errorf("no more than %d rows can be imported at once", maxCSVImportRows)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// mapCSVHeader finds the column of each field. Columns named in the options are used first, then
+// the first header matching one of the field's recognised headers. Other columns are ignored.
+func mapCSVHeader(header []string, fields []csvField, columns map[string]string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = normalizeCSVHeader(name)
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.name] = true
+	}
+	for name := range columns {
+		if !known[name] {
+			return nil, fmt.Errorf("(%v) is not a field that can be imported", name)
+		}
+	}
+
+	indexes := make(map[string]int, len(fields))
+	for _, field := range fields {
+		if column, ok := columns[field.name]; ok {
+			index, ok := positions[normalizeCSVHeader(column)]
+			if !ok {
+				return nil, fmt.Errorf("the CSV file has no (%v) column for %v", column, field.name)
+			}
+			indexes[field.name] = index
+			continue
+		}
+
+		for _, name := range field.headers {
+			if index, ok := positions[name]; ok {
+				indexes[field.name] = index
+				break
+			}
+		}
+		if _, ok := indexes[field.name]; !ok && field.required {
+			return nil, fmt.Errorf("the CSV file has no %v column", field.name)
+		}
+	}
+	return indexes, nil
+}
+
+// normalizeCSVHeader lowercases a header and joins its words with underscores, so "Correct Answer"
+// matches correct_answer. Spreadsheets often start the file with a byte order mark, which is dropped.
+func normalizeCSVHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// splitCSVTags splits a tags cell on the delimiter and normalizes the tags.
+func splitCSVTags(value string, delimiter string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return normalizeTags(strings.Split(value, delimiter))
+}
+
+// escapeCSVFormula prefixes a cell that a spreadsheet would run as a formula with a single quote,
+// so exported text is always shown as text.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVFormula undoes escapeCSVFormula, so an exported file can be imported again as it is.
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func writeQuestionsCSV(w io.Writer, questions []*repositories.TriviaQuestionEntity) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "question", "correct_answer", "tags", "is_published", "is_archived", "review_status", "difficulty", "created_at"})
+	for _, question := range questions {
+		difficulty := ""
+		if question.Difficulty != nil {
+			difficulty = *question.Difficulty
+		}
+		writer.Write([]string{
+			fmt.Sprint(question.ID),
+			escapeCSVFormula(question.Question),
+			escapeCSVFormula(question.CorrectAnswer),
+			escapeCSVFormula(strings.Join(question.Tags, defaultCSVTagDelimiter)),
+			fmt.Sprint(question.IsPublished),
+			fmt.Sprint(question.IsArchived),
+			question.ReviewStatus,
+			difficulty,
+			question.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeWrongAnswersCSV(w io.Writer, answers []*repositories.WrongAnswerPoolEntity) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "answer_text", "tags", "question_id", "is_archived", "created_at"})
+	for _, answer := range answers {
+		questionId := ""
+		if answer.QuestionID != nil {
+			questionId = fmt.Sprint(*answer.QuestionID)
+		}
+		writer.Write([]string{
+			fmt.Sprint(answer.ID),
+			escapeCSVFormula(answer.AnswerText),
+			escapeCSVFormula(strings.Join(answer.Tags, defaultCSVTagDelimiter)),
+			questionId,
+			fmt.Sprint(answer.IsArchived),
+			answer.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -1,7 +1,15 @@
 package services
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
 
 	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
 	"github.com/snowlynxsoftware/oto-api/server/models"
@@ -10,19 +18,50 @@ import (
 type ITriviaService interface {
 	ImportTriviaQuestions(data []models.TriviaQuestionImportData) (*models.TriviaQuestionImportResults, error)
 	ImportWrongAnswers(data []models.TriviaWrongAnswerImportData) (*models.TriviaWrongAnswerImportResults, error)
+	ImportOpenTDBQuestions(data []models.OpenTDBQuestion) (*models.OpenTDBImportResults, error)
+	ImportTriviaQuestionsCSV(data io.Reader, options *models.TriviaCSVImportOptions) (*models.TriviaCSVImportResults, error)
+	ImportWrongAnswersCSV(data io.Reader, options *models.TriviaCSVImportOptions) (*models.TriviaCSVImportResults, error)
+	ExportQuestions(format string, searchString, statusFilter, tagFilter, difficultyFilter string) ([]byte, error)
+	ExportWrongAnswers(format string, searchString, statusFilter, tagFilter string) ([]byte, error)
 	GetTriviaDeckById(deckId int64) (*repositories.TriviaDeckEntity, error)
 	CreateNewTriviaDeck(name string, description string, isSystemDeck bool) (*repositories.TriviaDeckEntity, error)
 	UpdateTriviaDeckMetadata(deckId int64, name string, description string) (*repositories.TriviaDeckEntity, error)
 	UpdateTriviaDeckApprovalStatus(deckId int64, isApproved bool) (*repositories.TriviaDeckEntity, error)
 	UpdateTriviaDeckArchivalStatus(deckId int64, isArchived bool) (*repositories.TriviaDeckEntity, error)
+	GetTriviaDecks(pageSize, offset int, searchString, statusFilter string) (*models.PaginatedResponse, error)
+
+	// Deck membership methods
+	AddQuestionsToDeck(deckId int64, questionIds []int64) (*models.TriviaDeckQuestionsAddResults, error)
+	RemoveQuestionsFromDeck(deckId int64, questionIds []int64) (*models.TriviaDeckQuestionsRemoveResults, error)
+	ReorderDeckQuestions(deckId int64, questionIds []int64) error
+	GetDeckQuestions(deckId int64, pageSize, offset int, statusFilter string) (*models.PaginatedResponse, error)
+	GetDeckQuestionCounts(deckId int64) (*models.TriviaDeckQuestionCounts, error)
+
+	// Smart deck methods
+	UpdateTriviaDeckRules(deckId int64, rules *models.TriviaDeckRules) (*repositories.TriviaDeckEntity, error)
+	PreviewTriviaDeckRules(rules *models.TriviaDeckRules, pageSize, offset int) (*models.PaginatedResponse, error)
 
 	// New CRUD methods for questions
-	GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter string) (*models.PaginatedResponse, error)
+	GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter, difficultyFilter, sortBy string) (*models.PaginatedResponse, error)
 	GetQuestionById(id int64) (*repositories.TriviaQuestionEntity, error)
 	CreateQuestion(dto *models.TriviaQuestionCreateDTO) (*repositories.TriviaQuestionEntity, error)
-	UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64) (*repositories.TriviaQuestionEntity, error)
+	UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64, editorUserId int64) (*repositories.TriviaQuestionEntity, error)
 	ToggleQuestionArchived(id int64) error
-	ToggleQuestionPublished(id int64) error
+	ToggleQuestionPublished(id int64, userId int64) error
+
+	// Question review methods
+	SubmitQuestionForReview(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error)
+	AssignQuestionReviewer(id int64, reviewerUserId int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error)
+	ApproveQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error)
+	RejectQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error)
+	CommentOnQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionReviewEntity, error)
+	GetQuestionReviews(id int64) ([]*repositories.TriviaQuestionReviewEntity, error)
+	GetReviewQueue(pageSize, offset int, statusFilter string, reviewerUserId *int64) (*models.PaginatedResponse, error)
+
+	// Question revision methods
+	GetQuestionRevisions(questionId int64) ([]*repositories.TriviaQuestionRevisionEntity, error)
+	DiffQuestionRevisions(questionId int64, fromRevisionId int64, toRevisionId *int64) (*models.TriviaQuestionRevisionDiff, error)
+	RollbackQuestion(questionId int64, revisionId int64, editorUserId int64) (*repositories.TriviaQuestionEntity, error)
 
 	// New CRUD methods for wrong answers
 	GetWrongAnswers(pageSize, offset int, searchString, statusFilter, tagFilter string) (*models.PaginatedResponse, error)
@@ -32,13 +71,36 @@ type ITriviaService interface {
 	ToggleWrongAnswerArchived(id int64) error
 }
 
+var (
+	ErrQuestionNotFound         = errors.New("question not found")
+	ErrQuestionRevisionNotFound = errors.New("question revision not found")
+	ErrQuestionReviewStatus     = errors.New("the question is not in the right review status")
+	ErrNotQuestionReviewer      = errors.New("only the assigned reviewer can do this")
+	ErrQuestionReviewerNotFound = errors.New("reviewer not found")
+	ErrInvalidQuestionReviewer  = errors.New("invalid reviewer")
+	ErrInvalidQuestion          = errors.New("invalid question")
+	ErrInvalidQuestionReview    = errors.New("invalid question review")
+	ErrDeckNotFound             = errors.New("deck not found")
+	ErrInvalidDeck              = errors.New("invalid deck")
+	ErrInvalidDeckQuestions     = errors.New("invalid deck questions")
+	ErrInvalidDeckRules         = errors.New("invalid deck rules")
+)
+
+// The maximum number of question IDs accepted in a single deck membership request.
+const maxDeckQuestionBatchSize = 500
+
+// Exports are read from the database this many rows at a time.
+const exportBatchSize = 500
+
 type TriviaService struct {
 	triviaRepository repositories.ITriviaRepository
+	userRepository   repositories.IUserRepository
 }
 
-func NewTriviaService(triviaRepository repositories.ITriviaRepository) ITriviaService {
+func NewTriviaService(triviaRepository repositories.ITriviaRepository, userRepository repositories.IUserRepository) ITriviaService {
 	return &TriviaService{
 		triviaRepository: triviaRepository,
+		userRepository:   userRepository,
 	}
 }
 
@@ -58,8 +120,297 @@ func (s *TriviaService) ImportWrongAnswers(data []models.TriviaWrongAnswerImport
 	return results, nil
 }
 
+// ImportOpenTDBQuestions imports questions in the Open Trivia DB format. HTML entities are decoded, the
+// category becomes the question's tags, and the incorrect answers are added as wrong answers for that
+// question only. True or false questions are skipped since the game always offers several choices.
+// The difficulty is not imported because question difficulty comes from how players answer.
+func (s *TriviaService) ImportOpenTDBQuestions(data []models.OpenTDBQuestion) (*models.OpenTDBImportResults, error) {
+	results := &models.OpenTDBImportResults{
+		TotalQuestionsProcessed: int64(len(data)),
+	}
+
+	questions := []models.TriviaQuestionImportData{}
+	seen := map[string]bool{}
+	for _, item := range data {
+		question := strings.TrimSpace(html.UnescapeString(item.Question))
+		correctAnswer := strings.TrimSpace(html.UnescapeString(item.CorrectAnswer))
+		if item.Type == "boolean" || question == "" || correctAnswer == "" {
+			results.QuestionsSkipped++
+			continue
+		}
+		if seen[question] {
+			results.DuplicateQuestions++
+			continue
+		}
+		seen[question] = true
+
+		wrongAnswers := []string{}
+		for _, answer := range item.IncorrectAnswers {
+			answer = strings.TrimSpace(html.UnescapeString(answer))
+			if answer == "" || strings.EqualFold(answer, correctAnswer) || slices.Contains(wrongAnswers, answer) {
+				continue
+			}
+			wrongAnswers = append(wrongAnswers, answer)
+		}
+
+		questions = append(questions, models.TriviaQuestionImportData{
+			Question:      question,
+			CorrectAnswer: correctAnswer,
+			Tags:          openTDBCategoryTags(item.Category),
+			WrongAnswers:  wrongAnswers,
+		})
+	}
+	if len(questions) == 0 {
+		return results, nil
+	}
+
+	imported, err := s.triviaRepository.ImportTriviaQuestions(questions)
+	if err != nil {
+		return nil, err
+	}
+	results.QuestionsAdded = imported.QuestionsAdded
+	results.DuplicateQuestions += int64(len(questions)) - imported.QuestionsAdded
+	results.WrongAnswersAdded = imported.WrongAnswersAdded
+	return results, nil
+}
+
+// openTDBCategoryTags turns a category such as "Entertainment: Video Games" into the tags
+// entertainment and video games.
+func openTDBCategoryTags(category string) []string {
+	return normalizeTags(strings.Split(html.UnescapeString(category), ":"))
+}
+
+// ImportTriviaQuestionsCSV imports questions from a CSV file with a header row. Each row needs a
+// question and a correct answer, and the tags column is split on the tag delimiter. Rows with
+// errors are reported by line and left out, and nothing is saved on a dry run.
+func (s *TriviaService) ImportTriviaQuestionsCSV(data io.Reader, options *models.TriviaCSVImportOptions) (*models.TriviaCSVImportResults, error) {
+	delimiter, err := csvTagDelimiter(options)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, rowErrors, err := readCSVRows(data, questionCSVFields, options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := []models.TriviaQuestionImportData{}
+	lines := map[string]int{}
+	for _, row := range rows {
+		question := row.values["question"]
+		correctAnswer := row.values["correct_answer"]
+		switch {
+		case question == "":
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: "question is required"})
+		case correctAnswer == "":
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: "correct answer is required"})
+		case lines[question] != 0:
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: fmt.Sprintf("the question is already on line %d", lines[question])})
+		default:
+			lines[question] = row.line
+			questions = append(questions, models.TriviaQuestionImportData{
+				Question:      question,
+				CorrectAnswer: correctAnswer,
+				Tags:          splitCSVTags(row.values["tags"], delimiter),
+			})
+		}
+	}
+
+	results := newCSVImportResults(options, len(rows), len(questions), rowErrors)
+	if len(questions) == 0 {
+		return results, nil
+	}
+
+	texts := make([]string, len(questions))
+	for i, question := range questions {
+		texts[i] = question.Question
+	}
+	existing, err := s.triviaRepository.GetExistingQuestionTexts(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	isExisting := make(map[string]bool, len(existing))
+	for _, text := range existing {
+		isExisting[text] = true
+	}
+	questions = slices.DeleteFunc(questions, func(question models.TriviaQuestionImportData) bool {
+		return isExisting[question.Question]
+	})
+	results.RowsSkipped = results.ValidRows - int64(len(questions))
+	if options.DryRun || len(questions) == 0 {
+		return results, nil
+	}
+
+	imported, err := s.triviaRepository.ImportTriviaQuestions(questions)
+	if err != nil {
+		return nil, err
+	}
+	results.RowsAdded = imported.QuestionsAdded
+	results.RowsSkipped += int64(len(questions)) - imported.QuestionsAdded
+	return results, nil
+}
+
+// ImportWrongAnswersCSV imports wrong answers from a CSV file with a header row. Each row needs the
+// answer text and at least one tag, otherwise it works the same way as ImportTriviaQuestionsCSV.
+func (s *TriviaService) ImportWrongAnswersCSV(data io.Reader, options *models.TriviaCSVImportOptions) (*models.TriviaCSVImportResults, error) {
+	delimiter, err := csvTagDelimiter(options)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, rowErrors, err := readCSVRows(data, wrongAnswerCSVFields, options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := []models.TriviaWrongAnswerImportData{}
+	lines := map[string]int{}
+	for _, row := range rows {
+		answerText := row.values["answer_text"]
+		tags := splitCSVTags(row.values["tags"], delimiter)
+		switch {
+		case answerText == "":
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: "answer text is required"})
+		case len(tags) == 0:
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: "at least one tag is required"})
+		case lines[answerText] != 0:
+			rowErrors = append(rowErrors, models.TriviaCSVImportRowError{Line: row.line, Message: fmt.Sprintf("the answer is already on line %d", lines[answerText])})
+		default:
+			lines[answerText] = row.line
+			answers = append(answers, models.TriviaWrongAnswerImportData{
+				AnswerText: answerText,
+				Tags:       tags,
+			})
+		}
+	}
+
+	results := newCSVImportResults(options, len(rows), len(answers), rowErrors)
+	if len(answers) == 0 {
+		return results, nil
+	}
+
+	texts := make([]string, len(answers))
+	for i, answer := range answers {
+		texts[i] = answer.AnswerText
+	}
+	existing, err := s.triviaRepository.GetExistingWrongAnswerTexts(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	isExisting := make(map[string]bool, len(existing))
+	for _, text := range existing {
+		isExisting[text] = true
+	}
+	answers = slices.DeleteFunc(answers, func(answer models.TriviaWrongAnswerImportData) bool {
+		return isExisting[answer.AnswerText]
+	})
+	results.RowsSkipped = results.ValidRows - int64(len(answers))
+	if options.DryRun || len(answers) == 0 {
+		return results, nil
+	}
+
+	imported, err := s.triviaRepository.ImportWrongAnswers(answers)
+	if err != nil {
+		return nil, err
+	}
+	results.RowsAdded = imported.AnswersAdded
+	results.RowsSkipped += int64(len(answers)) - imported.AnswersAdded
+	return results, nil
+}
+
+// ExportQuestions returns every question matching the same filters as GetQuestions, as CSV or JSON.
+func (s *TriviaService) ExportQuestions(format string, searchString, statusFilter, tagFilter, difficultyFilter string) ([]byte, error) {
+	err := validateExportFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := []*repositories.TriviaQuestionEntity{}
+	for offset := 0; ; offset += exportBatchSize {
+		batch, err := s.triviaRepository.GetQuestions(exportBatchSize, offset, searchString, statusFilter, tagFilter, difficultyFilter, "")
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, batch...)
+		if len(batch) < exportBatchSize {
+			break
+		}
+	}
+
+	if format == models.TriviaExportFormatJSON {
+		return json.Marshal(questions)
+	}
+	var buf bytes.Buffer
+	err = writeQuestionsCSV(&buf, questions)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportWrongAnswers returns every wrong answer matching the same filters as GetWrongAnswers, as CSV or JSON.
+func (s *TriviaService) ExportWrongAnswers(format string, searchString, statusFilter, tagFilter string) ([]byte, error) {
+	err := validateExportFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := []*repositories.WrongAnswerPoolEntity{}
+	for offset := 0; ; offset += exportBatchSize {
+		batch, err := s.triviaRepository.GetWrongAnswers(exportBatchSize, offset, searchString, statusFilter, tagFilter)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, batch...)
+		if len(batch) < exportBatchSize {
+			break
+		}
+	}
+
+	if format == models.TriviaExportFormatJSON {
+		return json.Marshal(answers)
+	}
+	var buf bytes.Buffer
+	err = writeWrongAnswersCSV(&buf, answers)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func csvTagDelimiter(options *models.TriviaCSVImportOptions) (string, error) {
+	if options.TagDelimiter == "" {
+		return defaultCSVTagDelimiter, nil
+	}
+	if strings.ContainsAny(options.TagDelimiter, "\r\n\"") {
+		return "", errors.New("the tag delimiter cannot contain quotes or line breaks")
+	}
+	return options.TagDelimiter, nil
+}
+
+func newCSVImportResults(options *models.TriviaCSVImportOptions, totalRows int, validRows int, rowErrors []models.TriviaCSVImportRowError) *models.TriviaCSVImportResults {
+	slices.SortStableFunc(rowErrors, func(a, b models.TriviaCSVImportRowError) int {
+		return a.Line - b.Line
+	})
+	return &models.TriviaCSVImportResults{
+		DryRun:    options.DryRun,
+		TotalRows: int64(totalRows),
+		ValidRows: int64(validRows),
+		Errors:    rowErrors,
+	}
+}
+
+func validateExportFormat(format string) error {
+	if format != models.TriviaExportFormatCSV && format != models.TriviaExportFormatJSON {
+		return errors.New("format must be csv or json")
+	}
+	return nil
+}
+
 func (s *TriviaService) GetTriviaDeckById(deckId int64) (*repositories.TriviaDeckEntity, error) {
-	deck, err := s.triviaRepository.GetTriviaDeckById(deckId)
+	deck, err := s.getDeck(deckId)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +418,10 @@ func (s *TriviaService) GetTriviaDeckById(deckId int64) (*repositories.TriviaDec
 }
 
 func (s *TriviaService) CreateNewTriviaDeck(name string, description string, isSystemDeck bool) (*repositories.TriviaDeckEntity, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: deck name is required", ErrInvalidDeck)
+	}
+
 	deck, err := s.triviaRepository.CreateNewTriviaDeck(name, description, isSystemDeck)
 	if err != nil {
 		return nil, err
@@ -75,7 +430,14 @@ func (s *TriviaService) CreateNewTriviaDeck(name string, description string, isS
 }
 
 func (s *TriviaService) UpdateTriviaDeckMetadata(deckId int64, name string, description string) (*repositories.TriviaDeckEntity, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: deck name is required", ErrInvalidDeck)
+	}
+
 	deck, err := s.triviaRepository.UpdateTriviaDeckMetadata(deckId, name, description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +446,9 @@ func (s *TriviaService) UpdateTriviaDeckMetadata(deckId int64, name string, desc
 
 func (s *TriviaService) UpdateTriviaDeckApprovalStatus(deckId int64, isApproved bool) (*repositories.TriviaDeckEntity, error) {
 	deck, err := s.triviaRepository.UpdateTriviaDeckApprovalStatus(deckId, isApproved)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -92,20 +457,304 @@ func (s *TriviaService) UpdateTriviaDeckApprovalStatus(deckId int64, isApproved
 
 func (s *TriviaService) UpdateTriviaDeckArchivalStatus(deckId int64, isArchived bool) (*repositories.TriviaDeckEntity, error) {
 	deck, err := s.triviaRepository.UpdateTriviaDeckArchivalStatus(deckId, isArchived)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+func (s *TriviaService) GetTriviaDecks(pageSize, offset int, searchString, statusFilter string) (*models.PaginatedResponse, error) {
+	decks, err := s.triviaRepository.GetTriviaDecks(pageSize, offset, searchString, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.triviaRepository.GetTriviaDecksCount(searchString, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(decks))
+	for i, deck := range decks {
+		results[i] = deck
+	}
+
+	currentPage := (offset / pageSize) + 1
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     currentPage,
+	}, nil
+}
+
+// Deck membership methods
+func (s *TriviaService) AddQuestionsToDeck(deckId int64, questionIds []int64) (*models.TriviaDeckQuestionsAddResults, error) {
+	questionIds, err := normalizeDeckQuestionIds(questionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	deck, err := s.getDeck(deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.Rules != nil {
+		return nil, fmt.Errorf("%w: questions cannot be added to a smart deck", ErrInvalidDeck)
+	}
+
+	added, err := s.triviaRepository.AddQuestionsToDeck(deckId, questionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TriviaDeckQuestionsAddResults{
+		TotalQuestionsProcessed: int64(len(questionIds)),
+		QuestionsAdded:          added,
+	}, nil
+}
+
+func (s *TriviaService) RemoveQuestionsFromDeck(deckId int64, questionIds []int64) (*models.TriviaDeckQuestionsRemoveResults, error) {
+	questionIds, err := normalizeDeckQuestionIds(questionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.getDeck(deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.triviaRepository.RemoveQuestionsFromDeck(deckId, questionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TriviaDeckQuestionsRemoveResults{
+		TotalQuestionsProcessed: int64(len(questionIds)),
+		QuestionsRemoved:        removed,
+	}, nil
+}
+
+func (s *TriviaService) ReorderDeckQuestions(deckId int64, questionIds []int64) error {
+	_, err := s.getDeck(deckId)
+	if err != nil {
+		return err
+	}
+
+	// The new order must contain every question in the deck exactly once
+	err = s.triviaRepository.ReorderDeckQuestions(deckId, questionIds)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: the new order must include every question in the deck exactly once", ErrInvalidDeckQuestions)
+	}
+	return err
+}
+
+func (s *TriviaService) GetDeckQuestions(deckId int64, pageSize, offset int, statusFilter string) (*models.PaginatedResponse, error) {
+	deck, err := s.getDeck(deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Smart decks are resolved from their rules rather than the membership table
+	if deck.Rules != nil {
+		return s.getQuestionsMatchingDeckRules(deck.Rules, pageSize, offset, statusFilter)
+	}
+
+	questions, err := s.triviaRepository.GetDeckQuestions(deckId, pageSize, offset, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.triviaRepository.GetDeckQuestionsCount(deckId, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(questions))
+	for i, question := range questions {
+		results[i] = question
+	}
+
+	currentPage := (offset / pageSize) + 1
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     currentPage,
+	}, nil
+}
+
+func (s *TriviaService) GetDeckQuestionCounts(deckId int64) (*models.TriviaDeckQuestionCounts, error) {
+	deck, err := s.getDeck(deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.Rules != nil {
+		counts, err := s.triviaRepository.GetDeckRuleQuestionCounts(deck.Rules)
+		if err != nil {
+			return nil, err
+		}
+		counts.DeckID = deckId
+		return counts, nil
+	}
+
+	return s.triviaRepository.GetDeckQuestionCounts(deckId)
+}
+
+// Smart deck methods
+func (s *TriviaService) UpdateTriviaDeckRules(deckId int64, rules *models.TriviaDeckRules) (*repositories.TriviaDeckEntity, error) {
+	// Clearing the rules turns a smart deck back into a static deck
+	if rules != nil {
+		err := normalizeDeckRules(rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deck, err := s.triviaRepository.UpdateTriviaDeckRules(deckId, rules)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return deck, nil
 }
 
+func (s *TriviaService) PreviewTriviaDeckRules(rules *models.TriviaDeckRules, pageSize, offset int) (*models.PaginatedResponse, error) {
+	if rules == nil {
+		return nil, fmt.Errorf("%w: deck rules are required", ErrInvalidDeckRules)
+	}
+
+	err := normalizeDeckRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getQuestionsMatchingDeckRules(rules, pageSize, offset, "")
+}
+
+// getQuestionsMatchingDeckRules returns a page of the questions a smart deck's rules match, narrowed
+// down by the same status filter as a static deck's questions.
+func (s *TriviaService) getQuestionsMatchingDeckRules(rules *models.TriviaDeckRules, pageSize, offset int, statusFilter string) (*models.PaginatedResponse, error) {
+	questions, err := s.triviaRepository.GetQuestionsMatchingDeckRules(rules, pageSize, offset, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.triviaRepository.GetQuestionsMatchingDeckRulesCount(rules, statusFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(questions))
+	for i, question := range questions {
+		results[i] = question
+	}
+
+	currentPage := (offset / pageSize) + 1
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     currentPage,
+	}, nil
+}
+
+func (s *TriviaService) getDeck(deckId int64) (*repositories.TriviaDeckEntity, error) {
+	deck, err := s.triviaRepository.GetTriviaDeckById(deckId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeckNotFound
+	}
+	return deck, err
+}
+
+// normalizeDeckRules lowercases and de-duplicates the rule tags the same way question
+// tags are stored, then checks that the rules can match something.
+func normalizeDeckRules(rules *models.TriviaDeckRules) error {
+	rules.IncludeTags = normalizeTags(rules.IncludeTags)
+	rules.ExcludeTags = normalizeTags(rules.ExcludeTags)
+
+	for _, tag := range rules.IncludeTags {
+		for _, excluded := range rules.ExcludeTags {
+			if tag == excluded {
+				return fmt.Errorf("%w: tag (%v) cannot be both included and excluded", ErrInvalidDeckRules, tag)
+			}
+		}
+	}
+
+	if rules.MinAgeDays != nil && *rules.MinAgeDays < 0 {
+		return fmt.Errorf("%w: min_age_days cannot be negative", ErrInvalidDeckRules)
+	}
+	if rules.MaxAgeDays != nil && *rules.MaxAgeDays < 0 {
+		return fmt.Errorf("%w: max_age_days cannot be negative", ErrInvalidDeckRules)
+	}
+	if rules.MinAgeDays != nil && rules.MaxAgeDays != nil && *rules.MinAgeDays > *rules.MaxAgeDays {
+		return fmt.Errorf("%w: min_age_days cannot be greater than max_age_days", ErrInvalidDeckRules)
+	}
+
+	return nil
+}
+
+// normalizeTags lowercases and trims tags, dropping empty and duplicate values.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// normalizeDeckQuestionIds validates a batch of question IDs and removes any duplicates
+// while keeping the order they were given in.
+func normalizeDeckQuestionIds(questionIds []int64) ([]int64, error) {
+	if len(questionIds) == 0 {
+		return nil, fmt.Errorf("%w: at least one question ID is required", ErrInvalidDeckQuestions)
+	}
+	if len(questionIds) > maxDeckQuestionBatchSize {
+		return nil, fmt.Errorf("%w: no more than %d question IDs can be processed at once", ErrInvalidDeckQuestions, maxDeckQuestionBatchSize)
+	}
+
+	seen := make(map[int64]bool, len(questionIds))
+	normalized := make([]int64, 0, len(questionIds))
+	for _, id := range questionIds {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: question IDs must be positive", ErrInvalidDeckQuestions)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+	return normalized, nil
+}
+
 // Question CRUD methods
-func (s *TriviaService) GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter string) (*models.PaginatedResponse, error) {
-	questions, err := s.triviaRepository.GetQuestions(pageSize, offset, searchString, statusFilter, tagFilter)
+// GetQuestions lists questions along with their skill rating. Difficulty filters to easy, medium
+// or hard questions and sortBy can be difficulty_asc or difficulty_desc, otherwise newest first.
+func (s *TriviaService) GetQuestions(pageSize, offset int, searchString, statusFilter, tagFilter, difficultyFilter, sortBy string) (*models.PaginatedResponse, error) {
+	questions, err := s.triviaRepository.GetQuestions(pageSize, offset, searchString, statusFilter, tagFilter, difficultyFilter, sortBy)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := s.triviaRepository.GetQuestionsCount(searchString, statusFilter, tagFilter)
+	count, err := s.triviaRepository.GetQuestionsCount(searchString, statusFilter, tagFilter, difficultyFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +786,12 @@ func (s *TriviaService) GetQuestionById(id int64) (*repositories.TriviaQuestionE
 func (s *TriviaService) CreateQuestion(dto *models.TriviaQuestionCreateDTO) (*repositories.TriviaQuestionEntity, error) {
 	// Validate required fields
 	if dto.Question == "" || dto.CorrectAnswer == "" {
-		return nil, errors.New("question and correct answer are required")
+		return nil, fmt.Errorf("%w: question and correct answer are required", ErrInvalidQuestion)
+	}
+
+	// New questions start out as drafts and are only published once they have been reviewed
+	if dto.IsPublished {
+		return nil, fmt.Errorf("%w: new questions must be reviewed before they are published", ErrQuestionReviewStatus)
 	}
 
 	question, err := s.triviaRepository.CreateQuestion(dto)
@@ -147,13 +801,23 @@ func (s *TriviaService) CreateQuestion(dto *models.TriviaQuestionCreateDTO) (*re
 	return question, nil
 }
 
-func (s *TriviaService) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64) (*repositories.TriviaQuestionEntity, error) {
+// UpdateQuestion saves an edit to a question, keeping the version it replaces as a revision.
+// Changing is_published goes through the same review transition as ToggleQuestionPublished, so
+// only an approved question can be published. Editing the content of an approved or published
+// question unpublishes it and sends it back to draft for another review.
+func (s *TriviaService) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64, editorUserId int64) (*repositories.TriviaQuestionEntity, error) {
 	// Validate required fields
 	if dto.Question == "" || dto.CorrectAnswer == "" {
-		return nil, errors.New("question and correct answer are required")
+		return nil, fmt.Errorf("%w: question and correct answer are required", ErrInvalidQuestion)
 	}
 
-	question, err := s.triviaRepository.UpdateQuestion(dto, id)
+	question, err := s.triviaRepository.UpdateQuestion(dto, id, editorUserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuestionNotFound
+	}
+	if errors.Is(err, repositories.ErrQuestionNotPublishable) {
+		return nil, fmt.Errorf("%w: %v", ErrQuestionReviewStatus, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -164,8 +828,314 @@ func (s *TriviaService) ToggleQuestionArchived(id int64) error {
 	return s.triviaRepository.ToggleQuestionArchived(id)
 }
 
-func (s *TriviaService) ToggleQuestionPublished(id int64) error {
-	return s.triviaRepository.ToggleQuestionPublished(id)
+// ToggleQuestionPublished publishes an approved question, or takes a published question back to approved.
+func (s *TriviaService) ToggleQuestionPublished(id int64, userId int64) error {
+	question, err := s.getQuestion(id)
+	if err != nil {
+		return err
+	}
+	return s.setQuestionPublished(question, question.ReviewStatus != models.QuestionReviewPublished, userId)
+}
+
+// setQuestionPublished publishes an approved question, or takes a published question back to approved.
+func (s *TriviaService) setQuestionPublished(question *repositories.TriviaQuestionEntity, isPublished bool, userId int64) error {
+	var err error
+	switch {
+	case isPublished && question.ReviewStatus == models.QuestionReviewApproved:
+		_, err = s.updateQuestionReviewStatus(question, models.QuestionReviewPublished, question.ReviewerUserID, userId, "")
+	case !isPublished && question.ReviewStatus == models.QuestionReviewPublished:
+		_, err = s.updateQuestionReviewStatus(question, models.QuestionReviewApproved, question.ReviewerUserID, userId, "")
+	default:
+		return fmt.Errorf("%w: only approved questions can be published", ErrQuestionReviewStatus)
+	}
+	return err
+}
+
+// Question review methods
+// SubmitQuestionForReview sends a draft or rejected question to the review queue. Any reviewer
+// from an earlier review is cleared so the question can be picked up by anyone.
+func (s *TriviaService) SubmitQuestionForReview(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.getQuestion(id)
+	if err != nil {
+		return nil, err
+	}
+	if question.ReviewStatus != models.QuestionReviewDraft && question.ReviewStatus != models.QuestionReviewRejected {
+		return nil, fmt.Errorf("%w: only draft or rejected questions can be submitted", ErrQuestionReviewStatus)
+	}
+	return s.updateQuestionReviewStatus(question, models.QuestionReviewSubmitted, nil, userId, comment)
+}
+
+// AssignQuestionReviewer puts a submitted question in review with a support or admin reviewer.
+// A question already in review can be handed to a different reviewer.
+func (s *TriviaService) AssignQuestionReviewer(id int64, reviewerUserId int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.getQuestion(id)
+	if err != nil {
+		return nil, err
+	}
+	if question.ReviewStatus != models.QuestionReviewSubmitted && question.ReviewStatus != models.QuestionReviewInReview {
+		return nil, fmt.Errorf("%w: only submitted questions can be assigned a reviewer", ErrQuestionReviewStatus)
+	}
+
+	reviewer, err := s.userRepository.GetUserById(int(reviewerUserId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrQuestionReviewerNotFound
+		}
+		return nil, err
+	}
+	if reviewer.UserTypeKey != repositories.UserTypeAdmin && reviewer.UserTypeKey != repositories.UserTypeSupport {
+		return nil, fmt.Errorf("%w: the reviewer must be a support or admin user", ErrInvalidQuestionReviewer)
+	}
+	if reviewer.IsArchived || reviewer.IsBanned {
+		return nil, fmt.Errorf("%w: the reviewer is no longer active", ErrInvalidQuestionReviewer)
+	}
+
+	return s.updateQuestionReviewStatus(question, models.QuestionReviewInReview, &reviewer.ID, userId, comment)
+}
+
+// ApproveQuestion approves a question so it can be published. Only the assigned reviewer can approve it.
+func (s *TriviaService) ApproveQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.getQuestionInReview(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateQuestionReviewStatus(question, models.QuestionReviewApproved, question.ReviewerUserID, userId, comment)
+}
+
+// RejectQuestion sends a question back to its author with a comment saying why. Only the assigned
+// reviewer can reject it.
+func (s *TriviaService) RejectQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, fmt.Errorf("%w: a comment is required when rejecting a question", ErrInvalidQuestionReview)
+	}
+
+	question, err := s.getQuestionInReview(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateQuestionReviewStatus(question, models.QuestionReviewRejected, question.ReviewerUserID, userId, comment)
+}
+
+// CommentOnQuestion adds a reviewer comment to a question without changing its review status.
+func (s *TriviaService) CommentOnQuestion(id int64, userId int64, comment string) (*repositories.TriviaQuestionReviewEntity, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, fmt.Errorf("%w: comment is required", ErrInvalidQuestionReview)
+	}
+
+	_, err := s.getQuestion(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.triviaRepository.CreateQuestionReviewComment(id, userId, comment)
+}
+
+// GetQuestionReviews lists the status changes and comments on a question, oldest first.
+func (s *TriviaService) GetQuestionReviews(id int64) ([]*repositories.TriviaQuestionReviewEntity, error) {
+	_, err := s.getQuestion(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.triviaRepository.GetQuestionReviews(id)
+}
+
+// GetReviewQueue lists the questions waiting on a review, longest waiting first. The status filter can
+// be submitted or in_review, otherwise both are included, and reviewerUserId limits the queue to the
+// questions assigned to one reviewer.
+func (s *TriviaService) GetReviewQueue(pageSize, offset int, statusFilter string, reviewerUserId *int64) (*models.PaginatedResponse, error) {
+	statuses := []string{models.QuestionReviewSubmitted, models.QuestionReviewInReview}
+	switch statusFilter {
+	case "":
+	case models.QuestionReviewSubmitted, models.QuestionReviewInReview:
+		statuses = []string{statusFilter}
+	default:
+		return nil, fmt.Errorf("%w: status must be submitted or in_review", ErrInvalidQuestionReview)
+	}
+
+	questions, err := s.triviaRepository.GetReviewQueue(pageSize, offset, statuses, reviewerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.triviaRepository.GetReviewQueueCount(statuses, reviewerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(questions))
+	for i, question := range questions {
+		results[i] = question
+	}
+
+	currentPage := (offset / pageSize) + 1
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     currentPage,
+	}, nil
+}
+
+// getQuestionInReview returns a question that is in review with userId as its reviewer.
+func (s *TriviaService) getQuestionInReview(id int64, userId int64) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.getQuestion(id)
+	if err != nil {
+		return nil, err
+	}
+	if question.ReviewStatus != models.QuestionReviewInReview {
+		return nil, fmt.Errorf("%w: the question is not in review", ErrQuestionReviewStatus)
+	}
+	if question.ReviewerUserID == nil || *question.ReviewerUserID != userId {
+		return nil, ErrNotQuestionReviewer
+	}
+	return question, nil
+}
+
+// updateQuestionReviewStatus moves a question on from the status it was loaded with. The question
+// may have moved on in the meantime, in which case nothing is changed.
+func (s *TriviaService) updateQuestionReviewStatus(question *repositories.TriviaQuestionEntity, toStatus string, reviewerUserId *int64, userId int64, comment string) (*repositories.TriviaQuestionEntity, error) {
+	var reviewComment *string
+	if comment = strings.TrimSpace(comment); comment != "" {
+		reviewComment = &comment
+	}
+
+	updated, err := s.triviaRepository.UpdateQuestionReviewStatus(question.ID, []string{question.ReviewStatus}, toStatus, reviewerUserId, userId, reviewComment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: the question was archived or changed by someone else", ErrQuestionReviewStatus)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Question revision methods
+// GetQuestionRevisions lists the earlier versions of a question, newest first.
+func (s *TriviaService) GetQuestionRevisions(questionId int64) ([]*repositories.TriviaQuestionRevisionEntity, error) {
+	_, err := s.getQuestion(questionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.triviaRepository.GetQuestionRevisions(questionId)
+}
+
+// DiffQuestionRevisions compares a revision of a question with a later revision, or with the
+// question as it is now when no later revision is given.
+func (s *TriviaService) DiffQuestionRevisions(questionId int64, fromRevisionId int64, toRevisionId *int64) (*models.TriviaQuestionRevisionDiff, error) {
+	question, err := s.getQuestion(questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.getQuestionRevision(questionId, fromRevisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	to := models.TriviaQuestionVersion{
+		Question:      question.Question,
+		CorrectAnswer: question.CorrectAnswer,
+		Tags:          question.Tags,
+		IsPublished:   question.IsPublished,
+	}
+	if toRevisionId != nil {
+		revision, err := s.getQuestionRevision(questionId, *toRevisionId)
+		if err != nil {
+			return nil, err
+		}
+		to = revisionVersion(revision)
+	}
+
+	return diffQuestionVersions(questionId, revisionVersion(from), to), nil
+}
+
+// RollbackQuestion restores the question text, correct answer and tags of a revision. It is saved
+// as an edit, so the version being replaced is kept as a new revision and the rollback can itself
+// be undone, and an approved or published question goes back to draft for another review.
+func (s *TriviaService) RollbackQuestion(questionId int64, revisionId int64, editorUserId int64) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.getQuestion(questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.getQuestionRevision(questionId, revisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdateQuestion(&models.TriviaQuestionUpdateDTO{
+		Question:      revision.Question,
+		CorrectAnswer: revision.CorrectAnswer,
+		Tags:          revision.Tags,
+		IsPublished:   question.IsPublished,
+	}, questionId, editorUserId)
+}
+
+func (s *TriviaService) getQuestion(questionId int64) (*repositories.TriviaQuestionEntity, error) {
+	question, err := s.triviaRepository.GetQuestionById(questionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuestionNotFound
+	}
+	return question, err
+}
+
+func (s *TriviaService) getQuestionRevision(questionId int64, revisionId int64) (*repositories.TriviaQuestionRevisionEntity, error) {
+	revision, err := s.triviaRepository.GetQuestionRevisionById(questionId, revisionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuestionRevisionNotFound
+	}
+	return revision, err
+}
+
+func revisionVersion(revision *repositories.TriviaQuestionRevisionEntity) models.TriviaQuestionVersion {
+	return models.TriviaQuestionVersion{
+		RevisionID:     &revision.ID,
+		RevisionNumber: &revision.RevisionNumber,
+		Question:       revision.Question,
+		CorrectAnswer:  revision.CorrectAnswer,
+		Tags:           revision.Tags,
+		IsPublished:    revision.IsPublished,
+	}
+}
+
+// diffQuestionVersions lists every field that differs between two versions of a question, in
+// a fixed order. Tags are compared as a set so reordering them is not a change.
+func diffQuestionVersions(questionId int64, from models.TriviaQuestionVersion, to models.TriviaQuestionVersion) *models.TriviaQuestionRevisionDiff {
+	diff := &models.TriviaQuestionRevisionDiff{
+		QuestionID:  questionId,
+		From:        from,
+		To:          to,
+		Changes:     []models.TriviaQuestionFieldChange{},
+		TagsAdded:   []string{},
+		TagsRemoved: []string{},
+	}
+
+	if from.Question != to.Question {
+		diff.Changes = append(diff.Changes, models.TriviaQuestionFieldChange{Field: "question", Before: from.Question, After: to.Question})
+	}
+	if from.CorrectAnswer != to.CorrectAnswer {
+		diff.Changes = append(diff.Changes, models.TriviaQuestionFieldChange{Field: "correct_answer", Before: from.CorrectAnswer, After: to.CorrectAnswer})
+	}
+
+	for _, tag := range to.Tags {
+		if !slices.Contains(from.Tags, tag) {
+			diff.TagsAdded = append(diff.TagsAdded, tag)
+		}
+	}
+	for _, tag := range from.Tags {
+		if !slices.Contains(to.Tags, tag) {
+			diff.TagsRemoved = append(diff.TagsRemoved, tag)
+		}
+	}
+	if len(diff.TagsAdded) > 0 || len(diff.TagsRemoved) > 0 {
+		diff.Changes = append(diff.Changes, models.TriviaQuestionFieldChange{Field: "tags", Before: from.Tags, After: to.Tags})
+	}
+
+	if from.IsPublished != to.IsPublished {
+		diff.Changes = append(diff.Changes, models.TriviaQuestionFieldChange{Field: "is_published", Before: from.IsPublished, After: to.IsPublished})
+	}
+	return diff
 }
 
 // Wrong Answer CRUD methods
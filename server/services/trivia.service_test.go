@@ -1,7 +1,9 @@
 package services
 
 import (
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,6 +51,22 @@ func (m *MockTriviaRepository) ImportWrongAnswers(data []models.TriviaWrongAnswe
 	return args.Get(0).(*models.TriviaWrongAnswerImportResults), args.Error(1)
 }
 
+func (m *MockTriviaRepository) GetExistingQuestionTexts(questions []string) ([]string, error) {
+	args := m.Called(questions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetExistingWrongAnswerTexts(answers []string) ([]string, error) {
+	args := m.Called(answers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockTriviaRepository) GetTriviaDeckById(deckId int64) (*repositories.TriviaDeckEntity, error) {
 	args := m.Called(deckId)
 	if args.Get(0) == nil {
@@ -57,6 +75,14 @@ func (m *MockTriviaRepository) GetTriviaDeckById(deckId int64) (*repositories.Tr
 	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
 }
 
+func (m *MockTriviaRepository) GetSystemDeckByName(name string) (*repositories.TriviaDeckEntity, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
+}
+
 func (m *MockTriviaRepository) CreateNewTriviaDeck(name string, description string, isSystemDeck bool) (*repositories.TriviaDeckEntity, error) {
 	args := m.Called(name, description, isSystemDeck)
 	if args.Get(0) == nil {
@@ -65,6 +91,14 @@ func (m *MockTriviaRepository) CreateNewTriviaDeck(name string, description stri
 	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
 }
 
+func (m *MockTriviaRepository) CreateApprovedSystemSmartDeck(name string, description string, rules *models.TriviaDeckRules) (*repositories.TriviaDeckEntity, error) {
+	args := m.Called(name, description, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
+}
+
 func (m *MockTriviaRepository) UpdateTriviaDeckMetadata(deckId int64, name string, description string) (*repositories.TriviaDeckEntity, error) {
 	args := m.Called(deckId, name, description)
 	if args.Get(0) == nil {
@@ -89,17 +123,123 @@ func (m *MockTriviaRepository) UpdateTriviaDeckArchivalStatus(deckId int64, isAr
 	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
 }
 
+func (m *MockTriviaRepository) GetTriviaDecksCount(searchString, statusFilter string) (*int, error) {
+	args := m.Called(searchString, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetTriviaDecks(limit, offset int, searchString, statusFilter string) ([]*repositories.TriviaDeckEntity, error) {
+	args := m.Called(limit, offset, searchString, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaDeckEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) UpdateTriviaDeckRules(deckId int64, rules *models.TriviaDeckRules) (*repositories.TriviaDeckEntity, error) {
+	args := m.Called(deckId, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaDeckEntity), args.Error(1)
+}
+
+// Multiple choice methods
+func (m *MockTriviaRepository) GetRandomWrongAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error) {
+	args := m.Called(tags, questionId, correctAnswer, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetRandomCorrectAnswersByTags(tags []string, questionId int64, correctAnswer string, limit int) ([]string, error) {
+	args := m.Called(tags, questionId, correctAnswer, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Smart deck methods
+func (m *MockTriviaRepository) GetQuestionsMatchingDeckRulesCount(rules *models.TriviaDeckRules, statusFilter string) (*int, error) {
+	args := m.Called(rules, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetQuestionsMatchingDeckRules(rules *models.TriviaDeckRules, limit, offset int, statusFilter string) ([]*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(rules, limit, offset, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetDeckRuleQuestionCounts(rules *models.TriviaDeckRules) (*models.TriviaDeckQuestionCounts, error) {
+	args := m.Called(rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TriviaDeckQuestionCounts), args.Error(1)
+}
+
+// Deck membership methods
+func (m *MockTriviaRepository) AddQuestionsToDeck(deckId int64, questionIds []int64) (int64, error) {
+	args := m.Called(deckId, questionIds)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTriviaRepository) RemoveQuestionsFromDeck(deckId int64, questionIds []int64) (int64, error) {
+	args := m.Called(deckId, questionIds)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTriviaRepository) ReorderDeckQuestions(deckId int64, questionIds []int64) error {
+	args := m.Called(deckId, questionIds)
+	return args.Error(0)
+}
+
+func (m *MockTriviaRepository) GetDeckQuestionsCount(deckId int64, statusFilter string) (*int, error) {
+	args := m.Called(deckId, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetDeckQuestions(deckId int64, limit, offset int, statusFilter string) ([]*repositories.TriviaDeckQuestionEntity, error) {
+	args := m.Called(deckId, limit, offset, statusFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaDeckQuestionEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetDeckQuestionCounts(deckId int64) (*models.TriviaDeckQuestionCounts, error) {
+	args := m.Called(deckId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TriviaDeckQuestionCounts), args.Error(1)
+}
+
 // New CRUD methods for questions
-func (m *MockTriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilter string) (*int, error) {
-	args := m.Called(searchString, statusFilter, tagFilter)
+func (m *MockTriviaRepository) GetQuestionsCount(searchString, statusFilter, tagFilter, difficultyFilter string) (*int, error) {
+	args := m.Called(searchString, statusFilter, tagFilter, difficultyFilter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*int), args.Error(1)
 }
 
-func (m *MockTriviaRepository) GetQuestions(limit, offset int, searchString, statusFilter, tagFilter string) ([]*repositories.TriviaQuestionEntity, error) {
-	args := m.Called(limit, offset, searchString, statusFilter, tagFilter)
+func (m *MockTriviaRepository) GetQuestions(limit, offset int, searchString, statusFilter, tagFilter, difficultyFilter, sortBy string) ([]*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(limit, offset, searchString, statusFilter, tagFilter, difficultyFilter, sortBy)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -122,22 +262,73 @@ func (m *MockTriviaRepository) CreateQuestion(dto *models.TriviaQuestionCreateDT
 	return args.Get(0).(*repositories.TriviaQuestionEntity), args.Error(1)
 }
 
-func (m *MockTriviaRepository) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64) (*repositories.TriviaQuestionEntity, error) {
-	args := m.Called(dto, id)
+func (m *MockTriviaRepository) UpdateQuestion(dto *models.TriviaQuestionUpdateDTO, id int64, editorUserId int64) (*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(dto, id, editorUserId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*repositories.TriviaQuestionEntity), args.Error(1)
 }
 
+func (m *MockTriviaRepository) GetQuestionRevisions(questionId int64) ([]*repositories.TriviaQuestionRevisionEntity, error) {
+	args := m.Called(questionId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionRevisionEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetQuestionRevisionById(questionId int64, revisionId int64) (*repositories.TriviaQuestionRevisionEntity, error) {
+	args := m.Called(questionId, revisionId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaQuestionRevisionEntity), args.Error(1)
+}
+
 func (m *MockTriviaRepository) ToggleQuestionArchived(id int64) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockTriviaRepository) ToggleQuestionPublished(id int64) error {
-	args := m.Called(id)
-	return args.Error(0)
+func (m *MockTriviaRepository) UpdateQuestionReviewStatus(id int64, fromStatuses []string, toStatus string, reviewerUserId *int64, userId int64, comment *string) (*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(id, fromStatuses, toStatus, reviewerUserId, userId, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaQuestionEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) CreateQuestionReviewComment(id int64, userId int64, comment string) (*repositories.TriviaQuestionReviewEntity, error) {
+	args := m.Called(id, userId, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.TriviaQuestionReviewEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetQuestionReviews(questionId int64) ([]*repositories.TriviaQuestionReviewEntity, error) {
+	args := m.Called(questionId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionReviewEntity), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetReviewQueueCount(statuses []string, reviewerUserId *int64) (*int, error) {
+	args := m.Called(statuses, reviewerUserId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockTriviaRepository) GetReviewQueue(pageSize, offset int, statuses []string, reviewerUserId *int64) ([]*repositories.TriviaQuestionEntity, error) {
+	args := m.Called(pageSize, offset, statuses, reviewerUserId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.TriviaQuestionEntity), args.Error(1)
 }
 
 // New CRUD methods for wrong answers
@@ -190,7 +381,7 @@ func (m *MockTriviaRepository) ToggleWrongAnswerArchived(id int64) error {
 func TestTriviaService_ImportTriviaQuestions_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	importData := []models.TriviaQuestionImportData{
 		{
@@ -227,7 +418,7 @@ func TestTriviaService_ImportTriviaQuestions_Success(t *testing.T) {
 func TestTriviaService_ImportTriviaQuestions_RepositoryError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	importData := []models.TriviaQuestionImportData{
 		{
@@ -253,7 +444,7 @@ func TestTriviaService_ImportTriviaQuestions_RepositoryError(t *testing.T) {
 func TestTriviaService_ImportWrongAnswers_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	importData := []models.TriviaWrongAnswerImportData{
 		{
@@ -290,7 +481,7 @@ func TestTriviaService_ImportWrongAnswers_Success(t *testing.T) {
 func TestTriviaService_ImportWrongAnswers_RepositoryError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	importData := []models.TriviaWrongAnswerImportData{
 		{
@@ -316,7 +507,7 @@ func TestTriviaService_ImportWrongAnswers_RepositoryError(t *testing.T) {
 func TestTriviaService_CreateNewTriviaDeck_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	name := "General Knowledge"
 	description := "A deck of general knowledge questions"
@@ -350,7 +541,7 @@ func TestTriviaService_CreateNewTriviaDeck_Success(t *testing.T) {
 func TestTriviaService_CreateNewTriviaDeck_RepositoryError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	name := "Test Deck"
 	description := "Test description"
@@ -372,7 +563,7 @@ func TestTriviaService_CreateNewTriviaDeck_RepositoryError(t *testing.T) {
 func TestTriviaService_GetTriviaDeckById_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	deckId := int64(123)
 	description := "A test deck"
@@ -400,166 +591,140 @@ func TestTriviaService_GetTriviaDeckById_Success(t *testing.T) {
 func TestTriviaService_GetTriviaDeckById_DeckNotFound(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	deckId := int64(999)
-	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(nil, errors.New("deck not found"))
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(nil, sql.ErrNoRows)
 
 	// Act
 	result, err := triviaService.GetTriviaDeckById(deckId)
 
 	// Assert
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrDeckNotFound)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "deck not found")
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// ===========================================
-// TRIVIA QUESTION CRUD TESTS
-// ===========================================
-
-// Test GetQuestions - Success with pagination
-func TestTriviaService_GetQuestions_Success(t *testing.T) {
+// Test CreateNewTriviaDeck - Validation Error
+func TestTriviaService_CreateNewTriviaDeck_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
-
-	count := 150
-	expectedQuestions := []*repositories.TriviaQuestionEntity{
-		{
-			ID:            1,
-			Question:      "What is the capital of France?",
-			CorrectAnswer: "Paris",
-			Tags:          pq.StringArray{"geography", "europe"},
-			IsPublished:   true,
-			IsArchived:    false,
-			CreatedAt:     time.Now(),
-			ModifiedAt:    nil,
-		},
-		{
-			ID:            2,
-			Question:      "What is 2+2?",
-			CorrectAnswer: "4",
-			Tags:          pq.StringArray{"math", "basic"},
-			IsPublished:   true,
-			IsArchived:    false,
-			CreatedAt:     time.Now(),
-			ModifiedAt:    nil,
-		},
-	}
-
-	mockTriviaRepo.On("GetQuestionsCount", "", "", "").Return(&count, nil)
-	mockTriviaRepo.On("GetQuestions", 25, 0, "", "", "").Return(expectedQuestions, nil)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	// Act
-	result, err := triviaService.GetQuestions(25, 0, "", "", "")
+	result, err := triviaService.CreateNewTriviaDeck("   ", "No name", false)
 
 	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, 150, result.Total)
-	assert.Equal(t, 25, result.PageSize)
-	assert.Equal(t, 1, result.Page)
-	assert.Len(t, result.Results, 2)
-	mockTriviaRepo.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "deck name is required")
+	mockTriviaRepo.AssertNotCalled(t, "CreateNewTriviaDeck")
 }
 
-// Test GetQuestions - Success with search and filters
-func TestTriviaService_GetQuestions_WithFilters_Success(t *testing.T) {
+// Test UpdateTriviaDeckMetadata - Success
+func TestTriviaService_UpdateTriviaDeckMetadata_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
-	count := 10
-	expectedQuestions := []*repositories.TriviaQuestionEntity{
-		{
-			ID:            1,
-			Question:      "What is the capital of France?",
-			CorrectAnswer: "Paris",
-			Tags:          pq.StringArray{"geography", "europe"},
-			IsPublished:   true,
-			IsArchived:    false,
-			CreatedAt:     time.Now(),
-			ModifiedAt:    nil,
-		},
+	deckId := int64(123)
+	description := "Updated description"
+	expectedDeck := &repositories.TriviaDeckEntity{
+		ID:          deckId,
+		Name:        "Updated Deck",
+		Description: &description,
 	}
 
-	mockTriviaRepo.On("GetQuestionsCount", "capital", "published", "geography").Return(&count, nil)
-	mockTriviaRepo.On("GetQuestions", 10, 0, "capital", "published", "geography").Return(expectedQuestions, nil)
+	mockTriviaRepo.On("UpdateTriviaDeckMetadata", deckId, "Updated Deck", description).Return(expectedDeck, nil)
 
 	// Act
-	result, err := triviaService.GetQuestions(10, 0, "capital", "published", "geography")
+	result, err := triviaService.UpdateTriviaDeckMetadata(deckId, "Updated Deck", description)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 10, result.Total)
-	assert.Equal(t, 10, result.PageSize)
-	assert.Len(t, result.Results, 1)
+	assert.Equal(t, "Updated Deck", result.Name)
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// Test GetQuestions - Repository error
-func TestTriviaService_GetQuestions_RepositoryError(t *testing.T) {
+// Test UpdateTriviaDeckMetadata - Validation Error
+func TestTriviaService_UpdateTriviaDeckMetadata_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
-
-	mockTriviaRepo.On("GetQuestions", 25, 0, "", "", "").Return(nil, errors.New("database error"))
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	// Act
-	result, err := triviaService.GetQuestions(25, 0, "", "", "")
+	result, err := triviaService.UpdateTriviaDeckMetadata(123, "", "description")
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "database error")
+	assert.Contains(t, err.Error(), "deck name is required")
+	mockTriviaRepo.AssertNotCalled(t, "UpdateTriviaDeckMetadata")
+}
+
+// Test UpdateTriviaDeckApprovalStatus - Success
+func TestTriviaService_UpdateTriviaDeckApprovalStatus_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(123)
+	expectedDeck := &repositories.TriviaDeckEntity{
+		ID:         deckId,
+		Name:       "Test Deck",
+		IsApproved: true,
+	}
+
+	mockTriviaRepo.On("UpdateTriviaDeckApprovalStatus", deckId, true).Return(expectedDeck, nil)
+
+	// Act
+	result, err := triviaService.UpdateTriviaDeckApprovalStatus(deckId, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsApproved)
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// Test GetQuestionById - Success
-func TestTriviaService_GetQuestionById_Success(t *testing.T) {
+// Test GetTriviaDecks - Success
+func TestTriviaService_GetTriviaDecks_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
-	expectedQuestion := &repositories.TriviaQuestionEntity{
-		ID:            1,
-		Question:      "What is the capital of France?",
-		CorrectAnswer: "Paris",
-		Tags:          pq.StringArray{"geography", "europe"},
-		IsPublished:   true,
-		IsArchived:    false,
-		CreatedAt:     time.Now(),
-		ModifiedAt:    nil,
+	expectedDecks := []*repositories.TriviaDeckEntity{
+		{ID: 1, Name: "Science", IsApproved: true},
+		{ID: 2, Name: "History", IsApproved: true},
 	}
+	totalCount := 12
 
-	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(expectedQuestion, nil)
+	mockTriviaRepo.On("GetTriviaDecks", 10, 10, "sci", "approved").Return(expectedDecks, nil)
+	mockTriviaRepo.On("GetTriviaDecksCount", "sci", "approved").Return(&totalCount, nil)
 
 	// Act
-	result, err := triviaService.GetQuestionById(1)
+	result, err := triviaService.GetTriviaDecks(10, 10, "sci", "approved")
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, int64(1), result.ID)
-	assert.Equal(t, "What is the capital of France?", result.Question)
-	assert.Equal(t, "Paris", result.CorrectAnswer)
-	assert.Equal(t, pq.StringArray{"geography", "europe"}, result.Tags)
+	assert.Equal(t, totalCount, result.Total)
+	assert.Equal(t, 10, result.PageSize)
+	assert.Equal(t, 2, result.Page)
+	assert.Len(t, result.Results, 2)
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// Test GetQuestionById - Not found
-func TestTriviaService_GetQuestionById_NotFound(t *testing.T) {
+// Test GetTriviaDecks - Repository Error
+func TestTriviaService_GetTriviaDecks_RepositoryError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
-	mockTriviaRepo.On("GetQuestionById", int64(999)).Return(nil, errors.New("question not found"))
+	mockTriviaRepo.On("GetTriviaDecks", 25, 0, "", "").Return(nil, errors.New("database error"))
 
 	// Act
-	result, err := triviaService.GetQuestionById(999)
+	result, err := triviaService.GetTriviaDecks(25, 0, "", "")
 
 	// Assert
 	assert.Error(t, err)
@@ -567,35 +732,766 @@ func TestTriviaService_GetQuestionById_NotFound(t *testing.T) {
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// Test CreateQuestion - Success
-func TestTriviaService_CreateQuestion_Success(t *testing.T) {
+// Test ImportOpenTDBQuestions - Decodes entities and maps categories and incorrect answers
+func TestTriviaService_ImportOpenTDBQuestions_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
-	createDTO := &models.TriviaQuestionCreateDTO{
-		Question:      "What is the capital of Spain?",
-		CorrectAnswer: "Madrid",
-		Tags:          []string{"Geography", "Europe"},
-		IsPublished:   true,
+	data := []models.OpenTDBQuestion{
+		{
+			Type:             "multiple",
+			Difficulty:       "easy",
+			Category:         "Entertainment: Video Games",
+			Question:         "Which company made &quot;Super Mario Bros.&quot;?",
+			CorrectAnswer:    "Nintendo",
+			IncorrectAnswers: []string{"Sega", "Sony &amp; Co", "nintendo", "Sega"},
+		},
+		{Type: "boolean", Category: "General Knowledge", Question: "The sky is blue.", CorrectAnswer: "True", IncorrectAnswers: []string{"False"}},
+		{Type: "multiple", Category: "Science &amp; Nature", Question: "What is H2O?", CorrectAnswer: "Water", IncorrectAnswers: []string{"Salt"}},
+		{Type: "multiple", Category: "Science &amp; Nature", Question: "What is H2O?", CorrectAnswer: "Water", IncorrectAnswers: []string{"Salt"}},
+		{Type: "multiple", Category: "History", Question: "Who was the first US president?", CorrectAnswer: "", IncorrectAnswers: []string{"Lincoln"}},
 	}
 
-	expectedQuestion := &repositories.TriviaQuestionEntity{
-		ID:            1,
-		Question:      "What is the capital of Spain?",
-		CorrectAnswer: "Madrid",
-		Tags:          pq.StringArray{"geography", "europe"}, // Tags should be lowercased
-		IsPublished:   true,
-		IsArchived:    false,
-		CreatedAt:     time.Now(),
-		ModifiedAt:    nil,
+	expectedImport := []models.TriviaQuestionImportData{
+		{Question: "Which company made \"Super Mario Bros.\"?", CorrectAnswer: "Nintendo", Tags: []string{"entertainment", "video games"}, WrongAnswers: []string{"Sega", "Sony & Co"}},
+		{Question: "What is H2O?", CorrectAnswer: "Water", Tags: []string{"science & nature"}, WrongAnswers: []string{"Salt"}},
 	}
-
-	// Mock duplicate check - not implemented in service yet, so skip this mock
-	mockTriviaRepo.On("CreateQuestion", mock.AnythingOfType("*models.TriviaQuestionCreateDTO")).Return(expectedQuestion, nil)
+	mockTriviaRepo.On("ImportTriviaQuestions", expectedImport).Return(&models.TriviaQuestionImportResults{TotalQuestionsProcessed: 2, QuestionsAdded: 1, WrongAnswersAdded: 2}, nil)
 
 	// Act
-	result, err := triviaService.CreateQuestion(createDTO)
+	result, err := triviaService.ImportOpenTDBQuestions(data)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &models.OpenTDBImportResults{
+		TotalQuestionsProcessed: 5,
+		QuestionsAdded:          1,
+		QuestionsSkipped:        2,
+		DuplicateQuestions:      2,
+		WrongAnswersAdded:       2,
+	}, result)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ImportOpenTDBQuestions - Nothing usable
+func TestTriviaService_ImportOpenTDBQuestions_NothingToImport(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := []models.OpenTDBQuestion{{Type: "boolean", Question: "The sky is blue.", CorrectAnswer: "True"}}
+
+	// Act
+	result, err := triviaService.ImportOpenTDBQuestions(data)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.QuestionsSkipped)
+	mockTriviaRepo.AssertNotCalled(t, "ImportTriviaQuestions", mock.Anything)
+}
+
+// ===========================================
+// CSV IMPORT AND EXPORT TESTS
+// ===========================================
+
+// Test ImportTriviaQuestionsCSV - Header mapping, tag splitting and row errors
+func TestTriviaService_ImportTriviaQuestionsCSV_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "\ufeffPrompt,Correct Answer,Notes,Tags\n" +
+		"What is the capital of Spain?,Madrid,,Geography; Europe\n" +
+		"What is 2 + 2?,,,math\n" +
+		"\"What is the capital\nof France?\",Paris,,geography|europe\n" +
+		"What is the capital of Spain?,Madrid,,geography\n" +
+		"What is the capital of Italy?,Rome,,\n"
+
+	expectedImport := []models.TriviaQuestionImportData{
+		{Question: "What is the capital of Spain?", CorrectAnswer: "Madrid", Tags: []string{"geography", "europe"}},
+		{Question: "What is the capital\nof France?", CorrectAnswer: "Paris", Tags: []string{"geography|europe"}},
+	}
+
+	mockTriviaRepo.On("GetExistingQuestionTexts", []string{"What is the capital of Spain?", "What is the capital\nof France?", "What is the capital of Italy?"}).
+		Return([]string{"What is the capital of Italy?"}, nil)
+	mockTriviaRepo.On("ImportTriviaQuestions", expectedImport).Return(&models.TriviaQuestionImportResults{TotalQuestionsProcessed: 2, QuestionsAdded: 2}, nil)
+
+	// Act
+	result, err := triviaService.ImportTriviaQuestionsCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{Columns: map[string]string{"question": "prompt"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), result.TotalRows)
+	assert.Equal(t, int64(3), result.ValidRows)
+	assert.Equal(t, int64(2), result.RowsAdded)
+	assert.Equal(t, int64(1), result.RowsSkipped)
+	assert.Equal(t, []models.TriviaCSVImportRowError{
+		{Line: 3, Message: "correct answer is required"},
+		{Line: 6, Message: "the question is already on line 2"},
+	}, result.Errors)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ImportTriviaQuestionsCSV - Dry run saves nothing
+func TestTriviaService_ImportTriviaQuestionsCSV_DryRun(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "question,correct_answer,tags\nWhat is the capital of Spain?,Madrid,geography|europe\n"
+
+	mockTriviaRepo.On("GetExistingQuestionTexts", []string{"What is the capital of Spain?"}).Return([]string{}, nil)
+
+	// Act
+	result, err := triviaService.ImportTriviaQuestionsCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{DryRun: true, TagDelimiter: "|"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, int64(1), result.ValidRows)
+	assert.Equal(t, int64(0), result.RowsAdded)
+	assert.Empty(t, result.Errors)
+	mockTriviaRepo.AssertNotCalled(t, "ImportTriviaQuestions", mock.Anything)
+}
+
+// Test ImportTriviaQuestionsCSV - Missing column
+func TestTriviaService_ImportTriviaQuestionsCSV_MissingColumn(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "question,tags\nWhat is the capital of Spain?,geography\n"
+
+	// Act
+	result, err := triviaService.ImportTriviaQuestionsCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "no correct_answer column")
+}
+
+// Test ImportTriviaQuestionsCSV - A broken row stops the import with its line number
+func TestTriviaService_ImportTriviaQuestionsCSV_ParseError(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "question,correct_answer\nWhat is the capital of Spain?,Madrid\nWhat is \"2 + 2\"?,4\nWhat is the capital of Italy?,Rome\n"
+
+	mockTriviaRepo.On("GetExistingQuestionTexts", []string{"What is the capital of Spain?"}).Return([]string{}, nil)
+	mockTriviaRepo.On("ImportTriviaQuestions", mock.Anything).Return(&models.TriviaQuestionImportResults{TotalQuestionsProcessed: 1, QuestionsAdded: 1}, nil)
+
+	// Act
+	result, err := triviaService.ImportTriviaQuestionsCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsAdded)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, 3, result.Errors[0].Line)
+}
+
+// Test ImportWrongAnswersCSV - Tags are required
+func TestTriviaService_ImportWrongAnswersCSV_TagsRequired(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "Wrong Answer,Tags\nBarcelona,geography;europe\nLisbon,\n"
+
+	mockTriviaRepo.On("GetExistingWrongAnswerTexts", []string{"Barcelona"}).Return([]string{}, nil)
+	mockTriviaRepo.On("ImportWrongAnswers", []models.TriviaWrongAnswerImportData{{AnswerText: "Barcelona", Tags: []string{"geography", "europe"}}}).
+		Return(&models.TriviaWrongAnswerImportResults{TotalAnswersProcessed: 1, AnswersAdded: 1}, nil)
+
+	// Act
+	result, err := triviaService.ImportWrongAnswersCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsAdded)
+	assert.Equal(t, []models.TriviaCSVImportRowError{{Line: 3, Message: "at least one tag is required"}}, result.Errors)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ExportQuestions - CSV
+func TestTriviaService_ExportQuestions_CSV(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	questions := []*repositories.TriviaQuestionEntity{
+		{ID: 1, CreatedAt: createdAt, Question: "What is the capital of Spain, in Europe?", CorrectAnswer: "Madrid", Tags: pq.StringArray{"geography", "europe"}, IsPublished: true, ReviewStatus: models.QuestionReviewPublished},
+	}
+
+	mockTriviaRepo.On("GetQuestions", exportBatchSize, 0, "capital", "published", "geography", "", "").Return(questions, nil)
+
+	// Act
+	result, err := triviaService.ExportQuestions(models.TriviaExportFormatCSV, "capital", "published", "geography", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "id,question,correct_answer,tags,is_published,is_archived,review_status,difficulty,created_at\n"+
+		"1,\"What is the capital of Spain, in Europe?\",Madrid,geography;europe,true,false,published,,2026-01-02T03:04:05Z\n", string(result))
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ExportQuestions - CSV cells that look like formulas are escaped
+func TestTriviaService_ExportQuestions_CSVFormulaEscaped(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	questions := []*repositories.TriviaQuestionEntity{
+		{ID: 1, CreatedAt: createdAt, Question: "=HYPERLINK(\"http://example.com\")", CorrectAnswer: "-1", Tags: pq.StringArray{"@math"}, ReviewStatus: models.QuestionReviewDraft},
+	}
+
+	mockTriviaRepo.On("GetQuestions", exportBatchSize, 0, "", "", "", "", "").Return(questions, nil)
+
+	// Act
+	result, err := triviaService.ExportQuestions(models.TriviaExportFormatCSV, "", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "id,question,correct_answer,tags,is_published,is_archived,review_status,difficulty,created_at\n"+
+		"1,\"'=HYPERLINK(\"\"http://example.com\"\")\",'-1,'@math,false,false,draft,,2026-01-02T03:04:05Z\n", string(result))
+}
+
+// Test ImportWrongAnswersCSV - Escaped formula cells are imported as they were exported
+func TestTriviaService_ImportWrongAnswersCSV_UnescapesFormulas(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	data := "answer_text,tags\n'-1,math\n"
+
+	mockTriviaRepo.On("GetExistingWrongAnswerTexts", []string{"-1"}).Return([]string{}, nil)
+	mockTriviaRepo.On("ImportWrongAnswers", []models.TriviaWrongAnswerImportData{{AnswerText: "-1", Tags: []string{"math"}}}).
+		Return(&models.TriviaWrongAnswerImportResults{TotalAnswersProcessed: 1, AnswersAdded: 1}, nil)
+
+	// Act
+	result, err := triviaService.ImportWrongAnswersCSV(strings.NewReader(data), &models.TriviaCSVImportOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsAdded)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ExportWrongAnswers - Invalid format
+func TestTriviaService_ExportWrongAnswers_InvalidFormat(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	// Act
+	result, err := triviaService.ExportWrongAnswers("xlsx", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "GetWrongAnswers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ===========================================
+// TRIVIA DECK MEMBERSHIP TESTS
+// ===========================================
+
+// Test AddQuestionsToDeck - Success
+func TestTriviaService_AddQuestionsToDeck_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	// Duplicates are removed before reaching the repository
+	mockTriviaRepo.On("AddQuestionsToDeck", deckId, []int64{3, 1, 2}).Return(int64(2), nil)
+
+	// Act
+	result, err := triviaService.AddQuestionsToDeck(deckId, []int64{3, 1, 3, 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(3), result.TotalQuestionsProcessed)
+	assert.Equal(t, int64(2), result.QuestionsAdded)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test AddQuestionsToDeck - Empty Request
+func TestTriviaService_AddQuestionsToDeck_EmptyRequest(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	// Act
+	result, err := triviaService.AddQuestionsToDeck(7, []int64{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidDeckQuestions)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "at least one question ID is required")
+	mockTriviaRepo.AssertNotCalled(t, "AddQuestionsToDeck")
+}
+
+// Test AddQuestionsToDeck - Deck Not Found
+func TestTriviaService_AddQuestionsToDeck_DeckNotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetTriviaDeckById", int64(999)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.AddQuestionsToDeck(999, []int64{1})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeckNotFound)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "AddQuestionsToDeck")
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test RemoveQuestionsFromDeck - Success
+func TestTriviaService_RemoveQuestionsFromDeck_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	mockTriviaRepo.On("RemoveQuestionsFromDeck", deckId, []int64{4, 5}).Return(int64(1), nil)
+
+	// Act
+	result, err := triviaService.RemoveQuestionsFromDeck(deckId, []int64{4, 5})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.TotalQuestionsProcessed)
+	assert.Equal(t, int64(1), result.QuestionsRemoved)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ReorderDeckQuestions - Success
+func TestTriviaService_ReorderDeckQuestions_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	mockTriviaRepo.On("ReorderDeckQuestions", deckId, []int64{3, 1, 2}).Return(nil)
+
+	// Act
+	err := triviaService.ReorderDeckQuestions(deckId, []int64{3, 1, 2})
+
+	// Assert
+	assert.NoError(t, err)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ReorderDeckQuestions - Incomplete Order
+func TestTriviaService_ReorderDeckQuestions_IncompleteOrder(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	mockTriviaRepo.On("ReorderDeckQuestions", deckId, []int64{3, 1}).Return(sql.ErrNoRows)
+	mockTriviaRepo.On("ReorderDeckQuestions", deckId, []int64{3, 1, 1}).Return(sql.ErrNoRows)
+
+	// Act
+	errMissing := triviaService.ReorderDeckQuestions(deckId, []int64{3, 1})
+	errDuplicate := triviaService.ReorderDeckQuestions(deckId, []int64{3, 1, 1})
+
+	// Assert
+	assert.ErrorIs(t, errMissing, ErrInvalidDeckQuestions)
+	assert.ErrorIs(t, errDuplicate, ErrInvalidDeckQuestions)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetDeckQuestions - Success
+func TestTriviaService_GetDeckQuestions_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	questions := []*repositories.TriviaDeckQuestionEntity{
+		{TriviaQuestionEntity: repositories.TriviaQuestionEntity{ID: 1, Question: "Q1"}, Position: 1},
+		{TriviaQuestionEntity: repositories.TriviaQuestionEntity{ID: 2, Question: "Q2"}, Position: 2},
+	}
+	total := 2
+
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	mockTriviaRepo.On("GetDeckQuestions", deckId, 25, 0, "published").Return(questions, nil)
+	mockTriviaRepo.On("GetDeckQuestionsCount", deckId, "published").Return(&total, nil)
+
+	// Act
+	result, err := triviaService.GetDeckQuestions(deckId, 25, 0, "published")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 1, result.Page)
+	assert.Len(t, result.Results, 2)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetDeckQuestionCounts - Success
+func TestTriviaService_GetDeckQuestionCounts_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	expectedCounts := &models.TriviaDeckQuestionCounts{DeckID: deckId, Total: 10, Published: 6, Unpublished: 3, Archived: 1}
+
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+	mockTriviaRepo.On("GetDeckQuestionCounts", deckId).Return(expectedCounts, nil)
+
+	// Act
+	result, err := triviaService.GetDeckQuestionCounts(deckId)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), result.Published)
+	assert.Equal(t, int64(3), result.Unpublished)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// ===========================================
+// SMART DECK TESTS
+// ===========================================
+
+// Test UpdateTriviaDeckRules - Normalizes Tags
+func TestTriviaService_UpdateTriviaDeckRules_NormalizesTags(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	rules := &models.TriviaDeckRules{
+		IncludeTags:   []string{" Science ", "science", "PHYSICS", ""},
+		ExcludeTags:   []string{"Kids"},
+		PublishedOnly: true,
+	}
+
+	mockTriviaRepo.On("UpdateTriviaDeckRules", deckId, mock.MatchedBy(func(r *models.TriviaDeckRules) bool {
+		return assert.ObjectsAreEqual([]string{"science", "physics"}, r.IncludeTags) &&
+			assert.ObjectsAreEqual([]string{"kids"}, r.ExcludeTags)
+	})).Return(&repositories.TriviaDeckEntity{ID: deckId, Rules: rules}, nil)
+
+	// Act
+	result, err := triviaService.UpdateTriviaDeckRules(deckId, rules)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Rules)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test UpdateTriviaDeckRules - Clear Rules
+func TestTriviaService_UpdateTriviaDeckRules_ClearRules(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	var noRules *models.TriviaDeckRules
+	mockTriviaRepo.On("UpdateTriviaDeckRules", deckId, noRules).Return(&repositories.TriviaDeckEntity{ID: deckId}, nil)
+
+	// Act
+	result, err := triviaService.UpdateTriviaDeckRules(deckId, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.Rules)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test UpdateTriviaDeckRules - Validation Errors
+func TestTriviaService_UpdateTriviaDeckRules_ValidationErrors(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	minAge := 30
+	maxAge := 7
+	negative := -1
+
+	testCases := []*models.TriviaDeckRules{
+		{IncludeTags: []string{"science"}, ExcludeTags: []string{"Science"}},
+		{IncludeTags: []string{"science"}, MinAgeDays: &minAge, MaxAgeDays: &maxAge},
+		{IncludeTags: []string{"science"}, MinAgeDays: &negative},
+	}
+
+	for _, rules := range testCases {
+		// Act
+		result, err := triviaService.UpdateTriviaDeckRules(7, rules)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidDeckRules)
+		assert.Nil(t, result)
+	}
+	mockTriviaRepo.AssertNotCalled(t, "UpdateTriviaDeckRules")
+}
+
+// Test PreviewTriviaDeckRules - Success
+func TestTriviaService_PreviewTriviaDeckRules_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	rules := &models.TriviaDeckRules{IncludeTags: []string{"science"}, PublishedOnly: true}
+	questions := []*repositories.TriviaQuestionEntity{
+		{ID: 1, Question: "What is H2O?", Tags: []string{"science", "chemistry"}},
+	}
+	total := 1
+
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRules", rules, 25, 0, "").Return(questions, nil)
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRulesCount", rules, "").Return(&total, nil)
+
+	// Act
+	result, err := triviaService.PreviewTriviaDeckRules(rules, 25, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Len(t, result.Results, 1)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetDeckQuestions - Smart Deck Uses Rules And The Status Filter
+func TestTriviaService_GetDeckQuestions_SmartDeck(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	rules := &models.TriviaDeckRules{IncludeTags: []string{"science"}}
+	total := 0
+
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId, Rules: rules}, nil)
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRules", rules, 25, 0, "unpublished").Return([]*repositories.TriviaQuestionEntity{}, nil)
+	mockTriviaRepo.On("GetQuestionsMatchingDeckRulesCount", rules, "unpublished").Return(&total, nil)
+
+	// Act
+	result, err := triviaService.GetDeckQuestions(deckId, 25, 0, "unpublished")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+	mockTriviaRepo.AssertNotCalled(t, "GetDeckQuestions")
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test AddQuestionsToDeck - Smart Deck
+func TestTriviaService_AddQuestionsToDeck_SmartDeck(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	deckId := int64(7)
+	rules := &models.TriviaDeckRules{IncludeTags: []string{"science"}}
+	mockTriviaRepo.On("GetTriviaDeckById", deckId).Return(&repositories.TriviaDeckEntity{ID: deckId, Rules: rules}, nil)
+
+	// Act
+	result, err := triviaService.AddQuestionsToDeck(deckId, []int64{1})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidDeck)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "smart deck")
+	mockTriviaRepo.AssertNotCalled(t, "AddQuestionsToDeck")
+}
+
+// ===========================================
+// TRIVIA QUESTION CRUD TESTS
+// ===========================================
+
+// Test GetQuestions - Success with pagination
+func TestTriviaService_GetQuestions_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	count := 150
+	expectedQuestions := []*repositories.TriviaQuestionEntity{
+		{
+			ID:            1,
+			Question:      "What is the capital of France?",
+			CorrectAnswer: "Paris",
+			Tags:          pq.StringArray{"geography", "europe"},
+			IsPublished:   true,
+			IsArchived:    false,
+			CreatedAt:     time.Now(),
+			ModifiedAt:    nil,
+		},
+		{
+			ID:            2,
+			Question:      "What is 2+2?",
+			CorrectAnswer: "4",
+			Tags:          pq.StringArray{"math", "basic"},
+			IsPublished:   true,
+			IsArchived:    false,
+			CreatedAt:     time.Now(),
+			ModifiedAt:    nil,
+		},
+	}
+
+	mockTriviaRepo.On("GetQuestionsCount", "", "", "", "").Return(&count, nil)
+	mockTriviaRepo.On("GetQuestions", 25, 0, "", "", "", "", "").Return(expectedQuestions, nil)
+
+	// Act
+	result, err := triviaService.GetQuestions(25, 0, "", "", "", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 150, result.Total)
+	assert.Equal(t, 25, result.PageSize)
+	assert.Equal(t, 1, result.Page)
+	assert.Len(t, result.Results, 2)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetQuestions - Success with search and filters
+func TestTriviaService_GetQuestions_WithFilters_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	count := 10
+	expectedQuestions := []*repositories.TriviaQuestionEntity{
+		{
+			ID:            1,
+			Question:      "What is the capital of France?",
+			CorrectAnswer: "Paris",
+			Tags:          pq.StringArray{"geography", "europe"},
+			IsPublished:   true,
+			IsArchived:    false,
+			CreatedAt:     time.Now(),
+			ModifiedAt:    nil,
+		},
+	}
+
+	mockTriviaRepo.On("GetQuestionsCount", "capital", "published", "geography", "hard").Return(&count, nil)
+	mockTriviaRepo.On("GetQuestions", 10, 0, "capital", "published", "geography", "hard", "difficulty_desc").Return(expectedQuestions, nil)
+
+	// Act
+	result, err := triviaService.GetQuestions(10, 0, "capital", "published", "geography", "hard", "difficulty_desc")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 10, result.Total)
+	assert.Equal(t, 10, result.PageSize)
+	assert.Len(t, result.Results, 1)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetQuestions - Repository error
+func TestTriviaService_GetQuestions_RepositoryError(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestions", 25, 0, "", "", "", "", "").Return(nil, errors.New("database error"))
+
+	// Act
+	result, err := triviaService.GetQuestions(25, 0, "", "", "", "", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "database error")
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetQuestionById - Success
+func TestTriviaService_GetQuestionById_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	expectedQuestion := &repositories.TriviaQuestionEntity{
+		ID:            1,
+		Question:      "What is the capital of France?",
+		CorrectAnswer: "Paris",
+		Tags:          pq.StringArray{"geography", "europe"},
+		IsPublished:   true,
+		IsArchived:    false,
+		CreatedAt:     time.Now(),
+		ModifiedAt:    nil,
+	}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(expectedQuestion, nil)
+
+	// Act
+	result, err := triviaService.GetQuestionById(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(1), result.ID)
+	assert.Equal(t, "What is the capital of France?", result.Question)
+	assert.Equal(t, "Paris", result.CorrectAnswer)
+	assert.Equal(t, pq.StringArray{"geography", "europe"}, result.Tags)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetQuestionById - Not found
+func TestTriviaService_GetQuestionById_NotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(999)).Return(nil, errors.New("question not found"))
+
+	// Act
+	result, err := triviaService.GetQuestionById(999)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test CreateQuestion - Success
+func TestTriviaService_CreateQuestion_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	createDTO := &models.TriviaQuestionCreateDTO{
+		Question:      "What is the capital of Spain?",
+		CorrectAnswer: "Madrid",
+		Tags:          []string{"Geography", "Europe"},
+		IsPublished:   false,
+	}
+
+	expectedQuestion := &repositories.TriviaQuestionEntity{
+		ID:            1,
+		Question:      "What is the capital of Spain?",
+		CorrectAnswer: "Madrid",
+		Tags:          pq.StringArray{"geography", "europe"}, // Tags should be lowercased
+		IsPublished:   false,
+		IsArchived:    false,
+		CreatedAt:     time.Now(),
+		ModifiedAt:    nil,
+	}
+
+	// Mock duplicate check - not implemented in service yet, so skip this mock
+	mockTriviaRepo.On("CreateQuestion", mock.AnythingOfType("*models.TriviaQuestionCreateDTO")).Return(expectedQuestion, nil)
+
+	// Act
+	result, err := triviaService.CreateQuestion(createDTO)
 
 	// Assert
 	assert.NoError(t, err)
@@ -610,7 +1506,7 @@ func TestTriviaService_CreateQuestion_Success(t *testing.T) {
 func TestTriviaService_CreateQuestion_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	createDTO := &models.TriviaQuestionCreateDTO{
 		Question:      "", // Empty question
@@ -628,47 +1524,138 @@ func TestTriviaService_CreateQuestion_ValidationError(t *testing.T) {
 	assert.Contains(t, err.Error(), "question and correct answer are required")
 }
 
+// Test CreateQuestion - Published before review
+func TestTriviaService_CreateQuestion_PublishedBeforeReview(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	createDTO := &models.TriviaQuestionCreateDTO{
+		Question:      "What is the capital of Spain?",
+		CorrectAnswer: "Madrid",
+		IsPublished:   true,
+	}
+
+	// Act
+	result, err := triviaService.CreateQuestion(createDTO)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "CreateQuestion", mock.Anything)
+}
+
 // Test CreateQuestion - Duplicate error (Skip since not implemented in service)
 func TestTriviaService_CreateQuestion_DuplicateError(t *testing.T) {
 	// TODO: Implement duplicate check in service layer
 	t.Skip("Duplicate check not implemented in service layer yet")
 }
 
-// Test UpdateQuestion - Success
-func TestTriviaService_UpdateQuestion_Success(t *testing.T) {
+// Test UpdateQuestion - Success
+func TestTriviaService_UpdateQuestion_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	updateDTO := &models.TriviaQuestionUpdateDTO{
+		Question:      "What is the capital of Italy?",
+		CorrectAnswer: "Rome",
+		Tags:          []string{"Geography", "Europe"},
+		IsPublished:   true,
+	}
+
+	expectedQuestion := &repositories.TriviaQuestionEntity{
+		ID:            1,
+		Question:      "What is the capital of Italy?",
+		CorrectAnswer: "Rome",
+		Tags:          pq.StringArray{"geography", "europe"},
+		IsPublished:   true,
+		IsArchived:    false,
+		CreatedAt:     time.Now(),
+		ModifiedAt:    nil,
+	}
+
+	mockTriviaRepo.On("UpdateQuestion", mock.AnythingOfType("*models.TriviaQuestionUpdateDTO"), int64(1), int64(5)).Return(expectedQuestion, nil)
+
+	// Act
+	result, err := triviaService.UpdateQuestion(updateDTO, 1, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "What is the capital of Italy?", result.Question)
+	assert.Equal(t, "Rome", result.CorrectAnswer)
+	assert.Equal(t, pq.StringArray{"geography", "europe"}, result.Tags)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test UpdateQuestion - Publishing a question that was not approved
+func TestTriviaService_UpdateQuestion_PublishedChanged(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	updateDTO := &models.TriviaQuestionUpdateDTO{
+		Question:      "What is the capital of Italy?",
+		CorrectAnswer: "Rome",
+		IsPublished:   true,
+	}
+
+	mockTriviaRepo.On("UpdateQuestion", updateDTO, int64(1), int64(5)).Return(nil, repositories.ErrQuestionNotPublishable)
+
+	// Act
+	result, err := triviaService.UpdateQuestion(updateDTO, 1, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test UpdateQuestion - Question not found
+func TestTriviaService_UpdateQuestion_NotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	updateDTO := &models.TriviaQuestionUpdateDTO{
+		Question:      "What is the capital of Italy?",
+		CorrectAnswer: "Rome",
+	}
+
+	mockTriviaRepo.On("UpdateQuestion", updateDTO, int64(99), int64(5)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.UpdateQuestion(updateDTO, 99, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionNotFound)
+	assert.Nil(t, result)
+}
+
+// Test UpdateQuestion - Publishing an approved question through an edit
+func TestTriviaService_UpdateQuestion_PublishApproved(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	updateDTO := &models.TriviaQuestionUpdateDTO{
 		Question:      "What is the capital of Italy?",
 		CorrectAnswer: "Rome",
-		Tags:          []string{"Geography", "Europe"},
 		IsPublished:   true,
 	}
 
-	expectedQuestion := &repositories.TriviaQuestionEntity{
-		ID:            1,
-		Question:      "What is the capital of Italy?",
-		CorrectAnswer: "Rome",
-		Tags:          pq.StringArray{"geography", "europe"},
-		IsPublished:   true,
-		IsArchived:    false,
-		CreatedAt:     time.Now(),
-		ModifiedAt:    nil,
-	}
+	reviewerId := int64(7)
+	published := &repositories.TriviaQuestionEntity{ID: 1, IsPublished: true, ReviewStatus: models.QuestionReviewPublished, ReviewerUserID: &reviewerId}
 
-	mockTriviaRepo.On("UpdateQuestion", mock.AnythingOfType("*models.TriviaQuestionUpdateDTO"), int64(1)).Return(expectedQuestion, nil)
+	mockTriviaRepo.On("UpdateQuestion", updateDTO, int64(1), int64(5)).Return(published, nil)
 
 	// Act
-	result, err := triviaService.UpdateQuestion(updateDTO, 1)
+	result, err := triviaService.UpdateQuestion(updateDTO, 1, 5)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, "What is the capital of Italy?", result.Question)
-	assert.Equal(t, "Rome", result.CorrectAnswer)
-	assert.Equal(t, pq.StringArray{"geography", "europe"}, result.Tags)
+	assert.True(t, result.IsPublished)
 	mockTriviaRepo.AssertExpectations(t)
 }
 
@@ -676,7 +1663,7 @@ func TestTriviaService_UpdateQuestion_Success(t *testing.T) {
 func TestTriviaService_UpdateQuestion_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	updateDTO := &models.TriviaQuestionUpdateDTO{
 		Question:      "What is the capital of Italy?",
@@ -686,7 +1673,7 @@ func TestTriviaService_UpdateQuestion_ValidationError(t *testing.T) {
 	}
 
 	// Act
-	result, err := triviaService.UpdateQuestion(updateDTO, 1)
+	result, err := triviaService.UpdateQuestion(updateDTO, 1, 5)
 
 	// Assert
 	assert.Error(t, err)
@@ -698,7 +1685,7 @@ func TestTriviaService_UpdateQuestion_ValidationError(t *testing.T) {
 func TestTriviaService_ToggleQuestionArchived_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	mockTriviaRepo.On("ToggleQuestionArchived", int64(1)).Return(nil)
 
@@ -710,22 +1697,481 @@ func TestTriviaService_ToggleQuestionArchived_Success(t *testing.T) {
 	mockTriviaRepo.AssertExpectations(t)
 }
 
-// Test ToggleQuestionPublished - Success
+// Test ToggleQuestionPublished - Publishes an approved question
 func TestTriviaService_ToggleQuestionPublished_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	reviewerId := int64(7)
+	question := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewApproved, ReviewerUserID: &reviewerId}
+	published := &repositories.TriviaQuestionEntity{ID: 1, IsPublished: true, ReviewStatus: models.QuestionReviewPublished, ReviewerUserID: &reviewerId}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(question, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewApproved}, models.QuestionReviewPublished, &reviewerId, int64(5), (*string)(nil)).Return(published, nil)
+
+	// Act
+	err := triviaService.ToggleQuestionPublished(1, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ToggleQuestionPublished - Unpublishes a published question
+func TestTriviaService_ToggleQuestionPublished_Unpublish(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	question := &repositories.TriviaQuestionEntity{ID: 1, IsPublished: true, ReviewStatus: models.QuestionReviewPublished}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(question, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewPublished}, models.QuestionReviewApproved, (*int64)(nil), int64(5), (*string)(nil)).
+		Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewApproved}, nil)
+
+	// Act
+	err := triviaService.ToggleQuestionPublished(1, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ToggleQuestionPublished - Not approved
+func TestTriviaService_ToggleQuestionPublished_NotApproved(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewInReview}, nil)
+
+	// Act
+	err := triviaService.ToggleQuestionPublished(1, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	mockTriviaRepo.AssertNotCalled(t, "UpdateQuestionReviewStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ===========================================
+// QUESTION REVIEW TESTS
+// ===========================================
+
+// Test SubmitQuestionForReview - Success
+func TestTriviaService_SubmitQuestionForReview_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	reviewerId := int64(7)
+	question := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewRejected, ReviewerUserID: &reviewerId}
+	submitted := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewSubmitted}
+	comment := "Fixed the spelling"
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(question, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewRejected}, models.QuestionReviewSubmitted, (*int64)(nil), int64(5), &comment).Return(submitted, nil)
+
+	// Act
+	result, err := triviaService.SubmitQuestionForReview(1, 5, "  Fixed the spelling ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QuestionReviewSubmitted, result.ReviewStatus)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test SubmitQuestionForReview - Already approved
+func TestTriviaService_SubmitQuestionForReview_WrongStatus(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewApproved}, nil)
+
+	// Act
+	result, err := triviaService.SubmitQuestionForReview(1, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	assert.Nil(t, result)
+}
+
+// Test SubmitQuestionForReview - Changed by someone else
+func TestTriviaService_SubmitQuestionForReview_Conflict(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewDraft}, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewDraft}, models.QuestionReviewSubmitted, (*int64)(nil), int64(5), (*string)(nil)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.SubmitQuestionForReview(1, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	assert.Nil(t, result)
+}
+
+// Test AssignQuestionReviewer - Success
+func TestTriviaService_AssignQuestionReviewer_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockUserRepo := new(MockUserRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, mockUserRepo)
+
+	reviewerId := int64(7)
+	inReview := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewInReview, ReviewerUserID: &reviewerId}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewSubmitted}, nil)
+	mockUserRepo.On("GetUserById", 7).Return(&repositories.UserEntity{ID: 7, UserTypeKey: repositories.UserTypeSupport}, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewSubmitted}, models.QuestionReviewInReview, &reviewerId, int64(5), (*string)(nil)).Return(inReview, nil)
+
+	// Act
+	result, err := triviaService.AssignQuestionReviewer(1, 7, 5, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &reviewerId, result.ReviewerUserID)
+	mockTriviaRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test AssignQuestionReviewer - Reviewer is a player
+func TestTriviaService_AssignQuestionReviewer_NotStaff(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockUserRepo := new(MockUserRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, mockUserRepo)
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewSubmitted}, nil)
+	mockUserRepo.On("GetUserById", 7).Return(&repositories.UserEntity{ID: 7, UserTypeKey: repositories.UserTypePlayer}, nil)
+
+	// Act
+	result, err := triviaService.AssignQuestionReviewer(1, 7, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidQuestionReviewer)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "support or admin")
+	mockTriviaRepo.AssertNotCalled(t, "UpdateQuestionReviewStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test AssignQuestionReviewer - Reviewer does not exist
+func TestTriviaService_AssignQuestionReviewer_ReviewerNotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockUserRepo := new(MockUserRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, mockUserRepo)
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewSubmitted}, nil)
+	mockUserRepo.On("GetUserById", 7).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.AssignQuestionReviewer(1, 7, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewerNotFound)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "UpdateQuestionReviewStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test AssignQuestionReviewer - Draft question
+func TestTriviaService_AssignQuestionReviewer_NotSubmitted(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	mockUserRepo := new(MockUserRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, mockUserRepo)
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewDraft}, nil)
+
+	// Act
+	result, err := triviaService.AssignQuestionReviewer(1, 7, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionReviewStatus)
+	assert.Nil(t, result)
+	mockUserRepo.AssertNotCalled(t, "GetUserById", mock.Anything)
+}
+
+// Test ApproveQuestion - Success
+func TestTriviaService_ApproveQuestion_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	reviewerId := int64(7)
+	question := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewInReview, ReviewerUserID: &reviewerId}
+	approved := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewApproved, ReviewerUserID: &reviewerId}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(question, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewInReview}, models.QuestionReviewApproved, &reviewerId, int64(7), (*string)(nil)).Return(approved, nil)
+
+	// Act
+	result, err := triviaService.ApproveQuestion(1, 7, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QuestionReviewApproved, result.ReviewStatus)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test ApproveQuestion - Not the assigned reviewer
+func TestTriviaService_ApproveQuestion_NotReviewer(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	reviewerId := int64(7)
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewInReview, ReviewerUserID: &reviewerId}, nil)
+
+	// Act
+	result, err := triviaService.ApproveQuestion(1, 5, "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotQuestionReviewer)
+	assert.Nil(t, result)
+}
+
+// Test RejectQuestion - Comment required
+func TestTriviaService_RejectQuestion_CommentRequired(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	// Act
+	result, err := triviaService.RejectQuestion(1, 7, "   ")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "comment is required")
+	mockTriviaRepo.AssertNotCalled(t, "GetQuestionById", mock.Anything)
+}
+
+// Test RejectQuestion - Success
+func TestTriviaService_RejectQuestion_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	reviewerId := int64(7)
+	comment := "The answer is out of date"
+	question := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewInReview, ReviewerUserID: &reviewerId}
+	rejected := &repositories.TriviaQuestionEntity{ID: 1, ReviewStatus: models.QuestionReviewRejected, ReviewerUserID: &reviewerId}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(question, nil)
+	mockTriviaRepo.On("UpdateQuestionReviewStatus", int64(1), []string{models.QuestionReviewInReview}, models.QuestionReviewRejected, &reviewerId, int64(7), &comment).Return(rejected, nil)
+
+	// Act
+	result, err := triviaService.RejectQuestion(1, 7, comment)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.QuestionReviewRejected, result.ReviewStatus)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test CommentOnQuestion - Question not found
+func TestTriviaService_CommentOnQuestion_NotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(99)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.CommentOnQuestion(99, 5, "Looks good")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionNotFound)
+	assert.Nil(t, result)
+}
+
+// Test GetReviewQueue - Defaults to submitted and in review questions
+func TestTriviaService_GetReviewQueue_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	statuses := []string{models.QuestionReviewSubmitted, models.QuestionReviewInReview}
+	questions := []*repositories.TriviaQuestionEntity{{ID: 1, ReviewStatus: models.QuestionReviewSubmitted}}
+	count := 1
+
+	mockTriviaRepo.On("GetReviewQueue", 10, 0, statuses, (*int64)(nil)).Return(questions, nil)
+	mockTriviaRepo.On("GetReviewQueueCount", statuses, (*int64)(nil)).Return(&count, nil)
+
+	// Act
+	result, err := triviaService.GetReviewQueue(10, 0, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Len(t, result.Results, 1)
+	mockTriviaRepo.AssertExpectations(t)
+}
+
+// Test GetReviewQueue - Invalid status
+func TestTriviaService_GetReviewQueue_InvalidStatus(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	// Act
+	result, err := triviaService.GetReviewQueue(10, 0, models.QuestionReviewPublished, nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+// ===========================================
+// QUESTION REVISION TESTS
+// ===========================================
+
+// Test GetQuestionRevisions - Question Not Found
+func TestTriviaService_GetQuestionRevisions_QuestionNotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+	mockTriviaRepo.On("GetQuestionById", int64(99)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.GetQuestionRevisions(99)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionNotFound)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "GetQuestionRevisions", int64(99))
+}
+
+// Test DiffQuestionRevisions - Revision Against The Current Question
+func TestTriviaService_DiffQuestionRevisions_AgainstCurrent(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+	revision := &repositories.TriviaQuestionRevisionEntity{
+		ID:             7,
+		QuestionID:     1,
+		RevisionNumber: 1,
+		Question:       "What is the capitol of Italy?",
+		CorrectAnswer:  "Rome",
+		Tags:           pq.StringArray{"geography", "italy"},
+	}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{
+		ID:            1,
+		Question:      "What is the capital of Italy?",
+		CorrectAnswer: "Rome",
+		Tags:          pq.StringArray{"europe", "geography"},
+		IsPublished:   true,
+	}, nil)
+	mockTriviaRepo.On("GetQuestionRevisionById", int64(1), int64(7)).Return(revision, nil)
+
+	// Act
+	result, err := triviaService.DiffQuestionRevisions(1, 7, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), *result.From.RevisionID)
+	assert.Nil(t, result.To.RevisionID)
+	assert.Len(t, result.Changes, 3)
+	assert.Equal(t, "question", result.Changes[0].Field)
+	assert.Equal(t, "What is the capitol of Italy?", result.Changes[0].Before)
+	assert.Equal(t, "tags", result.Changes[1].Field)
+	assert.Equal(t, "is_published", result.Changes[2].Field)
+	assert.Equal(t, []string{"europe"}, result.TagsAdded)
+	assert.Equal(t, []string{"italy"}, result.TagsRemoved)
+}
+
+// Test DiffQuestionRevisions - Reordered Tags Are Not A Change
+func TestTriviaService_DiffQuestionRevisions_BetweenRevisions(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+	earlier := &repositories.TriviaQuestionRevisionEntity{
+		ID:             7,
+		QuestionID:     1,
+		RevisionNumber: 1,
+		Question:       "What is the capitol of Italy?",
+		CorrectAnswer:  "Rome",
+		Tags:           pq.StringArray{"geography", "italy"},
+	}
+	later := &repositories.TriviaQuestionRevisionEntity{
+		ID:             8,
+		QuestionID:     1,
+		RevisionNumber: 2,
+		Question:       "What is the capitol of Italy?",
+		CorrectAnswer:  "Rome",
+		Tags:           pq.StringArray{"italy", "geography"},
+	}
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1}, nil)
+	mockTriviaRepo.On("GetQuestionRevisionById", int64(1), int64(7)).Return(earlier, nil)
+	mockTriviaRepo.On("GetQuestionRevisionById", int64(1), int64(8)).Return(later, nil)
+	toRevisionId := int64(8)
+
+	// Act
+	result, err := triviaService.DiffQuestionRevisions(1, 7, &toRevisionId)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result.To.RevisionNumber)
+	assert.Empty(t, result.Changes)
+}
+
+// Test RollbackQuestion - Restores Content And Keeps The Published Status
+func TestTriviaService_RollbackQuestion_Success(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+	restored := &repositories.TriviaQuestionEntity{ID: 1, Question: "What is the capitol of Italy?", IsPublished: true}
+	revision := &repositories.TriviaQuestionRevisionEntity{
+		ID:             7,
+		QuestionID:     1,
+		RevisionNumber: 1,
+		Question:       "What is the capitol of Italy?",
+		CorrectAnswer:  "Rome",
+		Tags:           pq.StringArray{"geography", "italy"},
+	}
 
-	mockTriviaRepo.On("ToggleQuestionPublished", int64(1)).Return(nil)
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1, Question: "Wrong", IsPublished: true}, nil)
+	mockTriviaRepo.On("GetQuestionRevisionById", int64(1), int64(7)).Return(revision, nil)
+	mockTriviaRepo.On("UpdateQuestion", &models.TriviaQuestionUpdateDTO{
+		Question:      "What is the capitol of Italy?",
+		CorrectAnswer: "Rome",
+		Tags:          []string{"geography", "italy"},
+		IsPublished:   true,
+	}, int64(1), int64(5)).Return(restored, nil)
 
 	// Act
-	err := triviaService.ToggleQuestionPublished(1)
+	result, err := triviaService.RollbackQuestion(1, 7, 5)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, restored, result)
 	mockTriviaRepo.AssertExpectations(t)
 }
 
+// Test RollbackQuestion - Revision Not Found
+func TestTriviaService_RollbackQuestion_RevisionNotFound(t *testing.T) {
+	// Arrange
+	mockTriviaRepo := new(MockTriviaRepository)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
+
+	mockTriviaRepo.On("GetQuestionById", int64(1)).Return(&repositories.TriviaQuestionEntity{ID: 1}, nil)
+	mockTriviaRepo.On("GetQuestionRevisionById", int64(1), int64(70)).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := triviaService.RollbackQuestion(1, 70, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQuestionRevisionNotFound)
+	assert.Nil(t, result)
+	mockTriviaRepo.AssertNotCalled(t, "UpdateQuestion", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // ===========================================
 // WRONG ANSWER CRUD TESTS
 // ===========================================
@@ -734,7 +2180,7 @@ func TestTriviaService_ToggleQuestionPublished_Success(t *testing.T) {
 func TestTriviaService_GetWrongAnswers_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	count := 75
 	expectedAnswers := []*repositories.WrongAnswerPoolEntity{
@@ -776,7 +2222,7 @@ func TestTriviaService_GetWrongAnswers_Success(t *testing.T) {
 func TestTriviaService_GetWrongAnswers_WithFilters_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	count := 5
 	expectedAnswers := []*repositories.WrongAnswerPoolEntity{
@@ -808,7 +2254,7 @@ func TestTriviaService_GetWrongAnswers_WithFilters_Success(t *testing.T) {
 func TestTriviaService_GetWrongAnswerById_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	expectedAnswer := &repositories.WrongAnswerPoolEntity{
 		ID:         1,
@@ -837,7 +2283,7 @@ func TestTriviaService_GetWrongAnswerById_Success(t *testing.T) {
 func TestTriviaService_GetWrongAnswerById_NotFound(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	mockTriviaRepo.On("GetWrongAnswerById", int64(999)).Return(nil, errors.New("wrong answer not found"))
 
@@ -854,7 +2300,7 @@ func TestTriviaService_GetWrongAnswerById_NotFound(t *testing.T) {
 func TestTriviaService_CreateWrongAnswer_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	createDTO := &models.WrongAnswerCreateDTO{
 		AnswerText: "Berlin",
@@ -888,7 +2334,7 @@ func TestTriviaService_CreateWrongAnswer_Success(t *testing.T) {
 func TestTriviaService_CreateWrongAnswer_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	createDTO := &models.WrongAnswerCreateDTO{
 		AnswerText: "", // Empty answer text
@@ -914,7 +2360,7 @@ func TestTriviaService_CreateWrongAnswer_DuplicateError(t *testing.T) {
 func TestTriviaService_UpdateWrongAnswer_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	updateDTO := &models.WrongAnswerUpdateDTO{
 		AnswerText: "Munich",
@@ -947,7 +2393,7 @@ func TestTriviaService_UpdateWrongAnswer_Success(t *testing.T) {
 func TestTriviaService_UpdateWrongAnswer_ValidationError(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	updateDTO := &models.WrongAnswerUpdateDTO{
 		AnswerText: "", // Empty answer text
@@ -967,7 +2413,7 @@ func TestTriviaService_UpdateWrongAnswer_ValidationError(t *testing.T) {
 func TestTriviaService_ToggleWrongAnswerArchived_Success(t *testing.T) {
 	// Arrange
 	mockTriviaRepo := new(MockTriviaRepository)
-	triviaService := NewTriviaService(mockTriviaRepo)
+	triviaService := NewTriviaService(mockTriviaRepo, new(MockUserRepository))
 
 	mockTriviaRepo.On("ToggleWrongAnswerArchived", int64(1)).Return(nil)
 
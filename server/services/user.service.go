@@ -1,6 +1,9 @@
 package services
 
 import (
+	"database/sql"
+	"errors"
+
 	"github.com/snowlynxsoftware/oto-api/server/database/repositories"
 	"github.com/snowlynxsoftware/oto-api/server/models"
 )
@@ -12,6 +15,7 @@ type IUserService interface {
 	ToggleUserArchived(userId *int) error
 	BanUser(userId *int, reason string) error
 	UnbanUser(userId *int) error
+	GetLoginHistory(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error)
 }
 
 type UserService struct {
@@ -90,3 +94,36 @@ func (s *UserService) UnbanUser(userId *int) error {
 	_, err := s.userRepository.UnbanUserById(userId)
 	return err
 }
+
+// GetLoginHistory returns a page of the user's login attempts, newest first, including the failed ones.
+func (s *UserService) GetLoginHistory(userId int64, pageSize int, offset int) (*models.PaginatedResponse, error) {
+	user, err := s.userRepository.GetUserById(int(userId))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user == nil) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.userRepository.GetLoginHistory(userId, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.userRepository.GetLoginHistoryCount(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]any, len(history))
+	for i, login := range history {
+		results[i] = login
+	}
+
+	return &models.PaginatedResponse{
+		Results:  results,
+		Total:    *count,
+		PageSize: pageSize,
+		Page:     (offset / pageSize) + 1,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -93,6 +94,27 @@ func (m *MockUserRepository) ToggleUserArchived(userId *int) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CreateLoginHistory(dto *models.UserLoginHistoryCreateDTO) error {
+	args := m.Called(dto)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetLoginHistoryCount(userId int64) (*int, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockUserRepository) GetLoginHistory(userId int64, pageSize int, offset int) ([]*repositories.UserLoginHistoryEntity, error) {
+	args := m.Called(userId, pageSize, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.UserLoginHistoryEntity), args.Error(1)
+}
+
 // Test GetUserById - Success
 func TestUserService_GetUserById_Success(t *testing.T) {
 	// Arrange
@@ -353,3 +375,47 @@ func TestUserService_ToggleUserArchived_RepositoryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "archive failed")
 	mockRepo.AssertExpectations(t)
 }
+
+// Test GetLoginHistory - Success
+func TestUserService_GetLoginHistory_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo)
+
+	count := 3
+	history := []*repositories.UserLoginHistoryEntity{
+		{ID: 9, Outcome: &repositories.LoginOutcomeFailure},
+		{ID: 8, Outcome: &repositories.LoginOutcomeSuccess},
+	}
+
+	mockRepo.On("GetUserById", 123).Return(&repositories.UserEntity{ID: 123}, nil)
+	mockRepo.On("GetLoginHistory", int64(123), 2, 2).Return(history, nil)
+	mockRepo.On("GetLoginHistoryCount", int64(123)).Return(&count, nil)
+
+	// Act
+	result, err := userService.GetLoginHistory(123, 2, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 2, result.Page)
+	assert.Len(t, result.Results, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+// Test GetLoginHistory - User Not Found
+func TestUserService_GetLoginHistory_UserNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo)
+
+	mockRepo.On("GetUserById", 999).Return(nil, sql.ErrNoRows)
+
+	// Act
+	result, err := userService.GetLoginHistory(999, 25, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetLoginHistory", mock.Anything, mock.Anything, mock.Anything)
+}